@@ -1,6 +1,6 @@
 import React, { useState, useEffect } from 'react'
 import { Plus, MapPin, Users, Edit, Trash2 } from 'lucide-react'
-import { locationService } from '../services/locationService'
+import { locationService, isVersionConflict } from '../services/locationService'
 import { useAuth } from '../contexts/AuthContext'
 
 interface Location {
@@ -8,6 +8,7 @@ interface Location {
   code: string
   name: string
   address: string
+  version: number
   created_at: string
   updated_at: string
 }
@@ -17,7 +18,8 @@ interface Table {
   location_id: string
   code: string
   seats: number
-  status: 'free' | 'occupied' | 'reserved'
+  status: 'available' | 'occupied' | 'needs_cleaning' | 'reserved'
+  version: number
   created_at: string
   updated_at: string
 }
@@ -123,8 +125,9 @@ const Locations: React.FC = () => {
 
   const getStatusColor = (status: string) => {
     switch (status) {
-      case 'free': return 'bg-green-100 text-green-800'
+      case 'available': return 'bg-green-100 text-green-800'
       case 'occupied': return 'bg-red-100 text-red-800'
+      case 'needs_cleaning': return 'bg-blue-100 text-blue-800'
       case 'reserved': return 'bg-yellow-100 text-yellow-800'
       default: return 'bg-gray-100 text-gray-800'
     }
@@ -132,8 +135,9 @@ const Locations: React.FC = () => {
 
   const getStatusText = (status: string) => {
     switch (status) {
-      case 'free': return 'Libre'
+      case 'available': return 'Libre'
       case 'occupied': return 'Ocupada'
+      case 'needs_cleaning': return 'Por limpiar'
       case 'reserved': return 'Reservada'
       default: return status
     }
@@ -152,26 +156,41 @@ const Locations: React.FC = () => {
     if (!editingTable) return
     
     try {
-      await locationService.updateTable(editingTable.id, editTable)
+      await locationService.updateTable(editingTable.id, editingTable.version, editTable)
       setEditingTable(null)
       setEditTable({ code: '', seats: 4 })
       if (selectedLocation) {
         loadTables(selectedLocation)
       }
     } catch (error) {
+      if (isVersionConflict(error)) {
+        alert('Otro usuario modificó esta mesa. Se recargarán los datos.')
+        setEditingTable(null)
+        if (selectedLocation) {
+          loadTables(selectedLocation)
+        }
+        return
+      }
       console.error('Error updating table:', error)
     }
   }
 
-  const handleDeleteTable = async (tableId: string) => {
+  const handleDeleteTable = async (table: Table) => {
     if (!confirm('¿Estás seguro de que deseas eliminar esta mesa?')) return
     
     try {
-      await locationService.deleteTable(tableId)
+      await locationService.deleteTable(table.id, table.version)
       if (selectedLocation) {
         loadTables(selectedLocation)
       }
     } catch (error) {
+      if (isVersionConflict(error)) {
+        alert('Otro usuario modificó esta mesa. Se recargarán los datos.')
+        if (selectedLocation) {
+          loadTables(selectedLocation)
+        }
+        return
+      }
       console.error('Error deleting table:', error)
     }
   }
@@ -411,10 +430,13 @@ const Locations: React.FC = () => {
                       {canChangeTableStatus ? (
                         <button
                           onClick={() => {
-                            const statuses = ['free', 'occupied', 'reserved']
-                            const currentIndex = statuses.indexOf(table.status)
-                            const nextStatus = statuses[(currentIndex + 1) % statuses.length]
-                            handleChangeTableStatus(table.id, nextStatus)
+                            const nextStatus: Record<Table['status'], Table['status']> = {
+                              available: 'occupied',
+                              occupied: 'needs_cleaning',
+                              needs_cleaning: 'available',
+                              reserved: 'occupied',
+                            }
+                            handleChangeTableStatus(table.id, nextStatus[table.status])
                           }}
                           className={`px-2 py-1 rounded-full text-xs font-medium cursor-pointer hover:opacity-80 transition-opacity ${getStatusColor(table.status)}`}
                           title="Click to change status"
@@ -444,7 +466,7 @@ const Locations: React.FC = () => {
                         )}
                         {canDeleteTable && (
                           <button 
-                            onClick={() => handleDeleteTable(table.id)}
+                            onClick={() => handleDeleteTable(table)}
                             className="p-1 text-gray-400 hover:text-red-600 hover:bg-red-50 rounded"
                             title="Delete table"
                           >
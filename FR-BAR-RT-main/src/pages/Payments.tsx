@@ -254,9 +254,15 @@ const Payments: React.FC = () => {
                     <span className="font-semibold">{formatCurrency(order.subtotal_cents)}</span>
                   </div>
                   <div className="flex justify-between mb-2">
-                    <span className="text-gray-600">IVA (19%):</span>
+                    <span className="text-gray-600">IVA:</span>
                     <span className="font-semibold">{formatCurrency(order.tax_cents)}</span>
                   </div>
+                  {order.service_charge_cents > 0 && (
+                    <div className="flex justify-between mb-2">
+                      <span className="text-gray-600">Servicio:</span>
+                      <span className="font-semibold">{formatCurrency(order.service_charge_cents)}</span>
+                    </div>
+                  )}
                   {order.discount_cents > 0 && (
                     <div className="flex justify-between mb-2 text-red-600">
                       <span>Descuento:</span>
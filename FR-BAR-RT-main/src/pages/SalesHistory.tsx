@@ -372,9 +372,15 @@ const SalesHistory: React.FC = () => {
                   <span className="font-semibold">{formatCurrency(selectedOrder.subtotal_cents)}</span>
                 </div>
                 <div className="flex justify-between mb-2">
-                  <span className="text-gray-600">IVA (19%):</span>
+                  <span className="text-gray-600">IVA:</span>
                   <span className="font-semibold">{formatCurrency(selectedOrder.tax_cents)}</span>
                 </div>
+                {selectedOrder.service_charge_cents > 0 && (
+                  <div className="flex justify-between mb-2">
+                    <span className="text-gray-600">Servicio:</span>
+                    <span className="font-semibold">{formatCurrency(selectedOrder.service_charge_cents)}</span>
+                  </div>
+                )}
                 {selectedOrder.discount_cents > 0 && (
                   <div className="flex justify-between mb-2 text-red-600">
                     <span>Descuento:</span>
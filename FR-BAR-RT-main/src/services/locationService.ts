@@ -18,10 +18,27 @@ api.interceptors.request.use((config) => {
   return config
 })
 
+const ifMatch = (version: number) => ({ headers: { 'If-Match': `"${version}"` } })
+
+export const isVersionConflict = (error: any) => error?.response?.status === 412
+
+// Los listados vienen paginados: { items, next_cursor }. Recorre todas las páginas.
+const listAll = async (url: string, params: Record<string, unknown> = {}) => {
+  const items: any[] = []
+  let cursor: string | null = null
+  do {
+    const response: { data: { items: any[]; next_cursor: string | null } } = await api.get(url, {
+      params: { ...params, limit: 200, ...(cursor ? { cursor } : {}) },
+    })
+    items.push(...response.data.items)
+    cursor = response.data.next_cursor
+  } while (cursor)
+  return items
+}
+
 export const locationService = {
   async getLocations() {
-    const response = await api.get('/locations')
-    return response.data
+    return listAll('/locations')
   },
 
   async getLocation(id: string) {
@@ -35,8 +52,7 @@ export const locationService = {
   },
 
   async getTables(locationId: string) {
-    const response = await api.get(`/${locationId}/tables`)
-    return response.data
+    return listAll(`/${locationId}/tables`)
   },
 
   async getTable(id: string) {
@@ -49,28 +65,30 @@ export const locationService = {
     return response.data
   },
 
-  async updateTableStatus(id: string, status: string) {
-    const response = await api.put(`/tables/${id}/status`, { status })
+  async updateTableStatus(id: string, status: string, reason?: string) {
+    const response = await api.post(`/tables/${id}/transitions`, { status, reason })
     return response.data
   },
 
-  async updateLocation(id: string, data: { name: string; address: string; phone?: string }) {
-    const response = await api.put(`/locations/${id}`, data)
+  // version es el campo version (ETag) leído antes de editar; si otro usuario
+  // modificó el recurso entretanto, la API responde 412.
+  async updateLocation(id: string, version: number, data: { name: string; address: string; phone?: string }) {
+    const response = await api.put(`/locations/${id}`, data, ifMatch(version))
     return response.data
   },
 
-  async deleteLocation(id: string) {
-    const response = await api.delete(`/locations/${id}`)
+  async deleteLocation(id: string, version: number) {
+    const response = await api.delete(`/locations/${id}`, ifMatch(version))
     return response.data
   },
 
-  async updateTable(id: string, data: { code: string; seats: number }) {
-    const response = await api.put(`/tables/${id}`, data)
+  async updateTable(id: string, version: number, data: { code: string; seats: number }) {
+    const response = await api.put(`/tables/${id}`, data, ifMatch(version))
     return response.data
   },
 
-  async deleteTable(id: string) {
-    const response = await api.delete(`/tables/${id}`)
+  async deleteTable(id: string, version: number) {
+    const response = await api.delete(`/tables/${id}`, ifMatch(version))
     return response.data
   },
 }
@@ -35,6 +35,7 @@ export interface Order {
   status: 'pending' | 'confirmed' | 'preparing' | 'ready' | 'delivered' | 'cancelled' | 'closed'
   subtotal_cents: number
   tax_cents: number
+  service_charge_cents: number
   discount_cents: number
   total_cents: number
   notes?: string
@@ -384,6 +384,7 @@ INNER JOIN bar_system.locations l ON t.location_id = l.id;
 INSERT INTO bar_system.roles (code, name, description) VALUES 
     ('admin', 'Administrator', 'Full system access: user management, roles, venues, products and configuration'),
     ('cashier', 'Cashier', 'Payment management, bill closing and venue reports'),
+    ('manager', 'Manager', 'Venue management: tables, zones, floor plan and staff of their own venue'),
     ('waiter', 'Waiter', 'Order taking, table management and customer service')
 ON CONFLICT (code) DO NOTHING;
 
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"ms-auth-go/internal/handlers"
 	"ms-auth-go/internal/middleware"
+	"ms-auth-go/internal/migrations"
 	"ms-auth-go/internal/repository"
 	"ms-auth-go/internal/service"
 
@@ -25,6 +27,21 @@ func main() {
 		port = "8080"
 	}
 
+	// Migraciones: "migrate [up|down [pasos]|status]" solo gestiona el esquema
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.Run(context.Background(), dbURL, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		return
+	}
+
+	// Aplicar migraciones pendientes al iniciar (MIGRATE_ON_START=false lo desactiva)
+	if os.Getenv("MIGRATE_ON_START") != "false" {
+		if err := migrations.Run(context.Background(), dbURL, []string{"up"}, os.Stdout); err != nil {
+			log.Fatal("Error migrating database: ", err)
+		}
+	}
+
 	// Repositorio
 	repo, err := repository.NewAuthRepository(dbURL)
 	if err != nil {
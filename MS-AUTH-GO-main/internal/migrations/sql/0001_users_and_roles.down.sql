@@ -0,0 +1,10 @@
+-- Falla mientras otros servicios conserven tablas que dependan de estas;
+-- revertirlos primero.
+
+DROP VIEW IF EXISTS bar_system.v_users_with_roles;
+DROP TABLE IF EXISTS bar_system.password_reset_log;
+DROP TABLE IF EXISTS bar_system.login_attempts;
+DROP TABLE IF EXISTS bar_system.user_roles;
+DROP TABLE IF EXISTS bar_system.roles;
+DROP TABLE IF EXISTS bar_system.users;
+DROP FUNCTION IF EXISTS bar_system.update_updated_at_column();
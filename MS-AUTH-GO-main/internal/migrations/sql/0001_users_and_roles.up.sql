@@ -0,0 +1,144 @@
+-- ================================================
+-- MS-AUTH-GO: Usuarios, roles y auditoría de acceso
+-- ================================================
+-- Idempotente para adoptar bases creadas a mano con init.sql. Es la primera
+-- migración del sistema: crea el schema, las extensiones y la función de
+-- updated_at que usan los demás servicios.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;   -- gen_random_uuid()
+CREATE EXTENSION IF NOT EXISTS citext;     -- emails case-insensitive
+
+CREATE SCHEMA IF NOT EXISTS bar_system;
+
+CREATE OR REPLACE FUNCTION bar_system.update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Usuarios del sistema. venue_id apunta a bar_system.locations; la referencia
+-- se agrega en 0002_users_venue_reference, una vez existen las sedes.
+CREATE TABLE IF NOT EXISTS bar_system.users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email CITEXT UNIQUE NOT NULL,
+    password_hash TEXT NOT NULL,
+    first_name VARCHAR(100) NOT NULL,
+    last_name VARCHAR(100) NOT NULL,
+    document_number VARCHAR(20) NOT NULL,
+    document_type VARCHAR(10) NOT NULL CHECK (document_type IN ('CC', 'CE', 'PP', 'TI', 'RC')),
+    venue_id UUID,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    is_locked BOOLEAN NOT NULL DEFAULT FALSE,
+    failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failed_login_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(document_number, document_type)
+);
+
+-- Roles del sistema (RBAC)
+CREATE TABLE IF NOT EXISTS bar_system.roles (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Relación N:M entre usuarios y roles
+CREATE TABLE IF NOT EXISTS bar_system.user_roles (
+    user_id UUID NOT NULL,
+    role_id INTEGER NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id)
+        REFERENCES bar_system.users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id)
+        REFERENCES bar_system.roles (id) ON DELETE CASCADE
+);
+
+-- Registro de intentos de login (ISO 27001)
+CREATE TABLE IF NOT EXISTS bar_system.login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES bar_system.users(id) ON DELETE SET NULL,
+    email CITEXT NOT NULL,
+    ip_address INET NOT NULL,
+    user_agent TEXT,
+    success BOOLEAN NOT NULL,
+    attempted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Registro de cambios de contraseña (ISO 27001)
+CREATE TABLE IF NOT EXISTS bar_system.password_reset_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES bar_system.users(id) ON DELETE CASCADE,
+    admin_id UUID NOT NULL REFERENCES bar_system.users(id) ON DELETE CASCADE,
+    reset_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    ip_address INET NOT NULL,
+    user_agent TEXT,
+    reset_type VARCHAR(20) NOT NULL DEFAULT 'admin_reset' CHECK (reset_type IN ('admin_reset', 'user_request', 'system_generated'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_email ON bar_system.users(email);
+CREATE INDEX IF NOT EXISTS idx_users_is_active ON bar_system.users(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON bar_system.users(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_users_is_locked ON bar_system.users(is_locked);
+CREATE INDEX IF NOT EXISTS idx_users_locked_until ON bar_system.users(locked_until);
+
+CREATE INDEX IF NOT EXISTS idx_roles_code ON bar_system.roles(code);
+CREATE INDEX IF NOT EXISTS idx_roles_is_active ON bar_system.roles(is_active);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user_id ON bar_system.user_roles(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON bar_system.user_roles(role_id);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON bar_system.login_attempts(email);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON bar_system.login_attempts(ip_address);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_attempted_at ON bar_system.login_attempts(attempted_at);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_log_user_id ON bar_system.password_reset_log(user_id);
+CREATE INDEX IF NOT EXISTS idx_password_reset_log_admin_id ON bar_system.password_reset_log(admin_id);
+CREATE INDEX IF NOT EXISTS idx_password_reset_log_reset_at ON bar_system.password_reset_log(reset_at DESC);
+CREATE INDEX IF NOT EXISTS idx_password_reset_log_ip ON bar_system.password_reset_log(ip_address);
+
+CREATE OR REPLACE TRIGGER trg_users_updated_at
+    BEFORE UPDATE ON bar_system.users
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+CREATE OR REPLACE TRIGGER trg_roles_updated_at
+    BEFORE UPDATE ON bar_system.roles
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+-- Vista: Usuarios con roles asignados (para consultas frecuentes)
+CREATE OR REPLACE VIEW bar_system.v_users_with_roles AS
+SELECT
+    u.id,
+    u.email,
+    u.first_name || ' ' || u.last_name AS full_name,
+    u.is_active,
+    u.created_at,
+    u.updated_at,
+    COALESCE(array_agg(r.code) FILTER (WHERE r.code IS NOT NULL), '{}') AS role_codes,
+    COALESCE(array_agg(r.name) FILTER (WHERE r.name IS NOT NULL), '{}') AS role_names
+FROM bar_system.users u
+LEFT JOIN bar_system.user_roles ur ON u.id = ur.user_id
+LEFT JOIN bar_system.roles r ON ur.role_id = r.id AND r.is_active = TRUE
+GROUP BY u.id, u.email, u.first_name, u.last_name, u.is_active, u.created_at, u.updated_at;
+
+-- Roles del sistema
+INSERT INTO bar_system.roles (code, name, description) VALUES
+    ('admin', 'Administrator', 'Full system access: user management, roles, venues, products and configuration'),
+    ('cashier', 'Cashier', 'Payment management, bill closing and venue reports'),
+    ('manager', 'Manager', 'Venue management: tables, zones, floor plan and staff of their own venue'),
+    ('waiter', 'Waiter', 'Order taking, table management and customer service')
+ON CONFLICT (code) DO NOTHING;
+
+COMMENT ON SCHEMA bar_system IS 'Schema principal del sistema de gestión de bar';
+COMMENT ON TABLE bar_system.users IS 'Usuarios del sistema con autenticación';
+COMMENT ON TABLE bar_system.roles IS 'Roles para control de acceso basado en roles (RBAC)';
+COMMENT ON TABLE bar_system.user_roles IS 'Relación many-to-many entre usuarios y roles';
+COMMENT ON VIEW bar_system.v_users_with_roles IS 'Vista desnormalizada de usuarios con sus roles';
@@ -0,0 +1 @@
+ALTER TABLE bar_system.users DROP CONSTRAINT IF EXISTS users_venue_id_fkey;
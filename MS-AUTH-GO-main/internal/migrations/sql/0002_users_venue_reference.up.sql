@@ -0,0 +1,21 @@
+-- ================================================
+-- MS-AUTH-GO: Sede del personal
+-- ================================================
+-- Los managers gestionan mesas, zonas y personal solo de su sede
+-- (users.venue_id, enviado en el JWT como venue_id). La sede vive en
+-- bar_system.locations, de MS-VENUE-GO, que migra después de este servicio:
+-- en una base nueva la tabla aún no existe y la referencia se omite. Para
+-- agregarla, una vez MS-VENUE-GO haya migrado, revertir y volver a aplicar
+-- esta migración (migrate down 1 y migrate up).
+
+DO $$ BEGIN
+    IF to_regclass('bar_system.locations') IS NULL THEN
+        RAISE NOTICE 'bar_system.locations no existe; users.venue_id queda sin referencia';
+        RETURN;
+    END IF;
+
+    ALTER TABLE bar_system.users
+        ADD CONSTRAINT users_venue_id_fkey FOREIGN KEY (venue_id) REFERENCES bar_system.locations(id);
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
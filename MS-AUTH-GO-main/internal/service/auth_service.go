@@ -129,7 +129,7 @@ func (s *authService) Login(req *models.LoginRequest, ipAddress, userAgent strin
 	}
 
 	// Generar JWT
-	token, err := s.generateJWT(user.ID, user.Email, roles)
+	token, err := s.generateJWT(user.ID, user.Email, user.VenueID, roles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -237,7 +237,7 @@ func (s *authService) ValidateToken(tokenString string) (*jwt.Token, error) {
 	return token, nil
 }
 
-func (s *authService) generateJWT(userID, email string, roles []models.Role) (string, error) {
+func (s *authService) generateJWT(userID, email string, venueID *string, roles []models.Role) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
@@ -245,6 +245,11 @@ func (s *authService) generateJWT(userID, email string, roles []models.Role) (st
 		"iat":     time.Now().Unix(),
 	}
 
+	// Sede del usuario, usada por MS-VENUE-GO para limitar sus permisos
+	if venueID != nil {
+		claims["venue_id"] = *venueID
+	}
+
 	// Agregar roles al token
 	var roleCodes []string
 	for _, role := range roles {
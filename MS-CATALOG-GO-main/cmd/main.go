@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"ms-catalog-go/internal/handlers"
 	"ms-catalog-go/internal/middleware"
+	"ms-catalog-go/internal/migrations"
 	"ms-catalog-go/internal/repository"
 	"ms-catalog-go/internal/service"
 
@@ -25,6 +27,21 @@ func main() {
 		port = "8080"
 	}
 
+	// Migraciones: "migrate [up|down [pasos]|status]" solo gestiona el esquema
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.Run(context.Background(), dbURL, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		return
+	}
+
+	// Aplicar migraciones pendientes al iniciar (MIGRATE_ON_START=false lo desactiva)
+	if os.Getenv("MIGRATE_ON_START") != "false" {
+		if err := migrations.Run(context.Background(), dbURL, []string{"up"}, os.Stdout); err != nil {
+			log.Fatal("Error migrating database: ", err)
+		}
+	}
+
 	// Repositorio
 	repo, err := repository.NewProductRepository(dbURL)
 	if err != nil {
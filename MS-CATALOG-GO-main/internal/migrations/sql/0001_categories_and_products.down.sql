@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS bar_system.v_products_full;
+DROP TABLE IF EXISTS bar_system.products;
+DROP TABLE IF EXISTS bar_system.categories;
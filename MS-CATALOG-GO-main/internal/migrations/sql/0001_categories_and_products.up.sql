@@ -0,0 +1,148 @@
+-- ================================================
+-- MS-CATALOG-GO: Categorías y productos
+-- ================================================
+-- Idempotente para adoptar bases creadas a mano con init.sql.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;   -- gen_random_uuid()
+
+CREATE SCHEMA IF NOT EXISTS bar_system;
+
+-- Compartida por todos los servicios; MS-AUTH-GO la crea primero
+CREATE OR REPLACE FUNCTION bar_system.update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Categorías de productos
+CREATE TABLE IF NOT EXISTS bar_system.categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Productos (bebidas y aperitivos)
+CREATE TABLE IF NOT EXISTS bar_system.products (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category_id UUID NOT NULL,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_products_category FOREIGN KEY (category_id)
+        REFERENCES bar_system.categories (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_name ON bar_system.categories(name);
+CREATE INDEX IF NOT EXISTS idx_categories_is_active ON bar_system.categories(is_active);
+
+CREATE INDEX IF NOT EXISTS idx_products_code ON bar_system.products(code);
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON bar_system.products(category_id);
+CREATE INDEX IF NOT EXISTS idx_products_is_active ON bar_system.products(is_active);
+CREATE INDEX IF NOT EXISTS idx_products_name_search ON bar_system.products
+    USING gin(to_tsvector('spanish', name));
+
+CREATE OR REPLACE TRIGGER trg_categories_updated_at
+    BEFORE UPDATE ON bar_system.categories
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+CREATE OR REPLACE TRIGGER trg_products_updated_at
+    BEFORE UPDATE ON bar_system.products
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+-- Vista: Productos con información de categoría
+CREATE OR REPLACE VIEW bar_system.v_products_full AS
+SELECT
+    p.id,
+    p.code,
+    p.name,
+    p.price_cents,
+    ROUND(p.price_cents / 100.0, 2) AS price_decimal,
+    p.is_active,
+    p.created_at,
+    p.updated_at,
+    p.category_id,
+    c.name AS category_name,
+    c.description AS category_description
+FROM bar_system.products p
+INNER JOIN bar_system.categories c ON p.category_id = c.id;
+
+-- Categorías iniciales
+INSERT INTO bar_system.categories (name, description) VALUES
+    ('Cervezas', 'Cervezas nacionales, importadas y artesanales'),
+    ('Cócteles', 'Bebidas preparadas con licores y mezcladores'),
+    ('Licores', 'Licores puros: whisky, ron, vodka, tequila (shots y copas)'),
+    ('Vinos', 'Vinos tintos, blancos y rosados (copas y botellas)'),
+    ('Refrescos', 'Bebidas sin alcohol: agua, jugos, gaseosas'),
+    ('Aperitivos', 'Snacks y picadas para acompañar bebidas')
+ON CONFLICT (name) DO NOTHING;
+
+-- Productos de ejemplo
+DO $$
+DECLARE
+    cat_cervezas UUID;
+    cat_cocteles UUID;
+    cat_licores UUID;
+    cat_vinos UUID;
+    cat_refrescos UUID;
+    cat_aperitivos UUID;
+BEGIN
+    -- Obtener IDs de categorías
+    SELECT id INTO cat_cervezas FROM bar_system.categories WHERE name = 'Cervezas';
+    SELECT id INTO cat_cocteles FROM bar_system.categories WHERE name = 'Cócteles';
+    SELECT id INTO cat_licores FROM bar_system.categories WHERE name = 'Licores';
+    SELECT id INTO cat_vinos FROM bar_system.categories WHERE name = 'Vinos';
+    SELECT id INTO cat_refrescos FROM bar_system.categories WHERE name = 'Refrescos';
+    SELECT id INTO cat_aperitivos FROM bar_system.categories WHERE name = 'Aperitivos';
+    
+    -- Insertar productos de ejemplo
+    INSERT INTO bar_system.products (category_id, code, name, price_cents) VALUES
+        -- Cervezas (5 productos)
+        (cat_cervezas, 'CERV-001', 'Cerveza Club Colombia', 800),
+        (cat_cervezas, 'CERV-002', 'Cerveza Poker', 700),
+        (cat_cervezas, 'CERV-003', 'Cerveza Águila', 700),
+        (cat_cervezas, 'CERV-004', 'Cerveza Artesanal IPA', 1200),
+        (cat_cervezas, 'CERV-005', 'Corona Extra', 1000),
+        
+        -- Cócteles (5 productos)
+        (cat_cocteles, 'COCT-001', 'Mojito', 1500),
+        (cat_cocteles, 'COCT-002', 'Margarita', 1800),
+        (cat_cocteles, 'COCT-003', 'Piña Colada', 1600),
+        (cat_cocteles, 'COCT-004', 'Cuba Libre', 1200),
+        (cat_cocteles, 'COCT-005', 'Daiquiri', 1700),
+        
+        -- Licores (5 productos)
+        (cat_licores, 'LIC-001', 'Ron Medellín (shot)', 800),
+        (cat_licores, 'LIC-002', 'Aguardiente Antioqueño', 1000),
+        (cat_licores, 'LIC-003', 'Whisky Old Parr (copa)', 1500),
+        (cat_licores, 'LIC-004', 'Vodka Absolut (shot)', 900),
+        (cat_licores, 'LIC-005', 'Tequila José Cuervo (shot)', 1000),
+        
+        -- Vinos (3 productos)
+        (cat_vinos, 'VIN-001', 'Vino Tinto Copa', 1200),
+        (cat_vinos, 'VIN-002', 'Vino Blanco Copa', 1200),
+        (cat_vinos, 'VIN-003', 'Vino Tinto Botella', 6000),
+        
+        -- Refrescos (4 productos)
+        (cat_refrescos, 'REF-001', 'Agua Mineral', 300),
+        (cat_refrescos, 'REF-002', 'Coca-Cola', 500),
+        (cat_refrescos, 'REF-003', 'Jugo Natural', 700),
+        (cat_refrescos, 'REF-004', 'Limonada Natural', 600),
+        
+        -- Aperitivos (3 productos)
+        (cat_aperitivos, 'APE-001', 'Papas Fritas', 800),
+        (cat_aperitivos, 'APE-002', 'Alitas de Pollo', 1500),
+        (cat_aperitivos, 'APE-003', 'Nachos con Queso', 1200)
+    ON CONFLICT (code) DO NOTHING;
+END $$;
+
+COMMENT ON TABLE bar_system.categories IS 'Categorías de productos (bebidas y aperitivos)';
+COMMENT ON TABLE bar_system.products IS 'Catálogo de productos disponibles';
+COMMENT ON VIEW bar_system.v_products_full IS 'Vista de productos con información completa de categoría';
@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"ms-sales-go/internal/models"
+)
+
+// VenueClient consulta a MS-VENUE-GO, dueño de las sedes y su configuración.
+type VenueClient interface {
+	// GetLocationPricing devuelve el impuesto y el cargo por servicio de la
+	// sede. authorization es la cabecera Authorization de quien hace la
+	// petición y se reenvía tal cual.
+	GetLocationPricing(locationID, authorization string) (*models.LocationPricing, error)
+}
+
+type venueClient struct {
+	baseURL string
+	http    *http.Client
+}
+
+func NewVenueClient(baseURL string) VenueClient {
+	return &venueClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetLocationPricing lee la configuración de la sede en MS-VENUE-GO, que ya
+// aplica sus valores por defecto cuando la sede nunca la guardó.
+func (c *venueClient) GetLocationPricing(locationID, authorization string) (*models.LocationPricing, error) {
+	endpoint := fmt.Sprintf("%s/api/venue/locations/%s/settings", c.baseURL, url.PathEscape(locationID))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("venue service unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("venue service returned %d for location %s", resp.StatusCode, locationID)
+	}
+
+	var settings struct {
+		TaxRate              float64 `json:"tax_rate"`
+		ServiceChargePercent float64 `json:"service_charge_percent"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("invalid venue settings response: %w", err)
+	}
+
+	return &models.LocationPricing{
+		TaxRate:              settings.TaxRate,
+		ServiceChargePercent: settings.ServiceChargePercent,
+	}, nil
+}
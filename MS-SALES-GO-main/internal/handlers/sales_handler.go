@@ -35,7 +35,7 @@ func (h *SalesHandler) CreateOrder(c *gin.Context) {
 
 	waiterID, _ := middleware.GetUserIDFromContext(c)
 
-	order, err := h.salesService.CreateOrder(&req, waiterID)
+	order, err := h.salesService.CreateOrder(&req, waiterID, c.GetHeader("Authorization"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -118,7 +118,7 @@ func (h *SalesHandler) UpdateOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := h.salesService.UpdateOrder(id, &req)
+	order, err := h.salesService.UpdateOrder(id, &req, c.GetHeader("Authorization"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
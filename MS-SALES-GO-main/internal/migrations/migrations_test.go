@@ -0,0 +1,334 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := load(files)
+	if err != nil {
+		t.Fatalf("load embedded migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %d has version %d; versions must start at 1 without gaps", i, migration.Version)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []int
+		err   string
+	}{
+		{
+			name: "sorted by version",
+			files: map[string]string{
+				"sql/0002_b.up.sql":   "SELECT 2",
+				"sql/0002_b.down.sql": "SELECT -2",
+				"sql/0001_a.up.sql":   "SELECT 1",
+				"sql/0001_a.down.sql": "SELECT -1",
+			},
+			want: []int{1, 2},
+		},
+		{
+			name:  "missing down script",
+			files: map[string]string{"sql/0001_a.up.sql": "SELECT 1"},
+			err:   "needs both an up and a down script",
+		},
+		{
+			name:  "badly named file",
+			files: map[string]string{"sql/create_tables.sql": "SELECT 1"},
+			err:   "is not named",
+		},
+		{
+			name: "one version with two names",
+			files: map[string]string{
+				"sql/0001_a.up.sql":   "SELECT 1",
+				"sql/0001_b.down.sql": "SELECT -1",
+			},
+			err: "has two names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, content := range tt.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			migrations, err := load(fsys)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if len(migrations) != len(tt.want) {
+				t.Fatalf("loaded %d migrations, want %d", len(migrations), len(tt.want))
+			}
+			for i, version := range tt.want {
+				if migrations[i].Version != version {
+					t.Errorf("migration %d has version %d, want %d", i, migrations[i].Version, version)
+				}
+			}
+		})
+	}
+}
+
+func TestRunUpDownStatus(t *testing.T) {
+	db := &fakeDB{rows: []fakeRow{{service: "other-service", version: 1, name: "theirs"}}}
+	m := newTestMigrator(t, db, map[string]string{
+		"sql/0001_a.up.sql":   "CREATE a",
+		"sql/0001_a.down.sql": "DROP a",
+		"sql/0002_b.up.sql":   "CREATE b",
+		"sql/0002_b.down.sql": "DROP b",
+		"sql/0003_c.up.sql":   "CREATE c",
+		"sql/0003_c.down.sql": "DROP c",
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := m.run(context.Background(), args, &out); err != nil {
+			t.Fatalf("migrate %v: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if out := run(); out != "applied 0001_a\napplied 0002_b\napplied 0003_c\n" {
+		t.Errorf("up output = %q", out)
+	}
+	if out := run("up"); out != "schema is up to date\n" {
+		t.Errorf("second up output = %q", out)
+	}
+	if out := run("down", "2"); out != "reverted 0003_c\nreverted 0002_b\n" {
+		t.Errorf("down 2 output = %q", out)
+	}
+	if out := run("status"); !strings.Contains(out, "0001_a\t20") || !strings.Contains(out, "0002_b\tpending") || !strings.Contains(out, "0003_c\tpending") {
+		t.Errorf("status output = %q", out)
+	}
+
+	want := []string{"CREATE a", "CREATE b", "CREATE c", "DROP c", "DROP b"}
+	if strings.Join(db.scripts, "; ") != strings.Join(want, "; ") {
+		t.Errorf("scripts run = %q, want %q", db.scripts, want)
+	}
+	// The other service's version 1 neither hides ours nor is touched by it
+	if got := db.versions(service); len(got) != 1 || got[0] != 1 {
+		t.Errorf("%s versions = %v, want [1]", service, got)
+	}
+	if got := db.versions("other-service"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("other-service versions = %v, want [1]", got)
+	}
+}
+
+// Every service sharing the database must record its versions under its own
+// name, or one service's migrations would be taken as applied by another.
+func TestServiceName(t *testing.T) {
+	if service != "ms-sales-go" {
+		t.Errorf("schema_migrations rows are recorded as %q, want ms-sales-go", service)
+	}
+}
+
+func TestRunRejectsBadCommands(t *testing.T) {
+	m := newTestMigrator(t, &fakeDB{}, map[string]string{
+		"sql/0001_a.up.sql":   "CREATE a",
+		"sql/0001_a.down.sql": "DROP a",
+	})
+
+	for _, args := range [][]string{{"down", "0"}, {"down", "x"}, {"sideways"}} {
+		if err := m.run(context.Background(), args, io.Discard); err == nil {
+			t.Errorf("migrate %v succeeded", args)
+		}
+	}
+}
+
+func TestUpStopsAtFailedMigration(t *testing.T) {
+	db := &fakeDB{}
+	m := newTestMigrator(t, db, map[string]string{
+		"sql/0001_a.up.sql":   "CREATE a",
+		"sql/0001_a.down.sql": "DROP a",
+		"sql/0002_b.up.sql":   "FAIL",
+		"sql/0002_b.down.sql": "DROP b",
+		"sql/0003_c.up.sql":   "CREATE c",
+		"sql/0003_c.down.sql": "DROP c",
+	})
+
+	applied, err := m.Up(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "0002_b") {
+		t.Fatalf("up error = %v, want one naming 0002_b", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 1 {
+		t.Errorf("applied = %+v, want only version 1", applied)
+	}
+	if got := db.versions(service); len(got) != 1 || got[0] != 1 {
+		t.Errorf("recorded versions = %v, want [1]", got)
+	}
+}
+
+func newTestMigrator(t *testing.T, db *fakeDB, files map[string]string) *Migrator {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	migrations, err := load(fsys)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	conn := sql.OpenDB(db)
+	t.Cleanup(func() { conn.Close() })
+	return &Migrator{db: conn, migrations: migrations}
+}
+
+// fakeDB is a database/sql driver that understands the runner's bookkeeping
+// statements and records every other statement as a migration script. A
+// script reading FAIL returns an error; a transaction's writes are only kept
+// when it commits.
+type fakeDB struct {
+	mu      sync.Mutex
+	rows    []fakeRow
+	scripts []string
+}
+
+type fakeRow struct {
+	service   string
+	version   int64
+	name      string
+	appliedAt time.Time
+}
+
+func (db *fakeDB) versions(service string) []int64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var versions []int64
+	for _, row := range db.rows {
+		if row.service == service {
+			versions = append(versions, row.version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeDB
+	tx *fakeTx
+}
+
+type fakeTx struct {
+	conn    *fakeConn
+	rows    []fakeRow
+	scripts []string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake driver does not prepare statements")
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.tx = &fakeTx{conn: c, rows: append([]fakeRow(nil), c.db.rows...)}
+	return c.tx, nil
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.conn.db.mu.Lock()
+	defer tx.conn.db.mu.Unlock()
+	tx.conn.db.rows = tx.rows
+	tx.conn.db.scripts = append(tx.conn.db.scripts, tx.scripts...)
+	tx.conn.tx = nil
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.tx = nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query = strings.TrimSpace(query)
+	switch {
+	case strings.HasPrefix(query, "SELECT pg_advisory"), strings.HasPrefix(query, "CREATE SCHEMA IF NOT EXISTS bar_system"):
+		return driver.RowsAffected(0), nil
+	case c.tx == nil:
+		return nil, errors.New("fake driver only runs migrations inside a transaction")
+	case strings.HasPrefix(query, "INSERT INTO bar_system.schema_migrations"):
+		c.tx.rows = append(c.tx.rows, fakeRow{
+			service:   args[0].Value.(string),
+			version:   args[1].Value.(int64),
+			name:      args[2].Value.(string),
+			appliedAt: time.Now(),
+		})
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "DELETE FROM bar_system.schema_migrations"):
+		kept := c.tx.rows[:0:0]
+		for _, row := range c.tx.rows {
+			if row.service != args[0].Value.(string) || row.version != args[1].Value.(int64) {
+				kept = append(kept, row)
+			}
+		}
+		c.tx.rows = kept
+		return driver.RowsAffected(1), nil
+	case query == "FAIL":
+		return nil, errors.New("syntax error")
+	default:
+		c.tx.scripts = append(c.tx.scripts, query)
+		return driver.RowsAffected(0), nil
+	}
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, "SELECT version, applied_at FROM bar_system.schema_migrations") {
+		return nil, errors.New("fake driver cannot run " + query)
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	rows := &fakeRows{}
+	for _, row := range c.db.rows {
+		if row.service == args[0].Value.(string) {
+			rows.values = append(rows.values, []driver.Value{row.version, row.appliedAt})
+		}
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version", "applied_at"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
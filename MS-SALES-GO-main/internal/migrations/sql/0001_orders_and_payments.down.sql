@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS bar_system.payments;
+DROP TABLE IF EXISTS bar_system.order_items;
+DROP TABLE IF EXISTS bar_system.orders;
+DROP TYPE IF EXISTS bar_system.payment_status;
+DROP TYPE IF EXISTS bar_system.payment_method;
+DROP TYPE IF EXISTS bar_system.order_status;
@@ -0,0 +1 @@
+ALTER TABLE bar_system.orders DROP COLUMN IF EXISTS service_charge_cents;
@@ -0,0 +1,10 @@
+-- ================================================
+-- MS-SALES-GO: Cargo por servicio de las órdenes
+-- ================================================
+-- El impuesto y el cargo por servicio se calculan con la configuración de la
+-- sede (bar_system.location_settings, de MS-VENUE-GO). El total incluye ambos.
+
+ALTER TABLE bar_system.orders
+    ADD COLUMN IF NOT EXISTS service_charge_cents INTEGER NOT NULL DEFAULT 0 CHECK (service_charge_cents >= 0);
+
+COMMENT ON COLUMN bar_system.orders.service_charge_cents IS 'Cargo por servicio según service_charge_percent de la sede';
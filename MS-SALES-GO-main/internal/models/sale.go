@@ -37,6 +37,14 @@ const (
 	PaymentStatusRefunded PaymentStatus = "refunded"
 )
 
+// LocationPricing son las reglas de cobro de una sede, tomadas de la
+// configuración que expone MS-VENUE-GO. Las tasas son porcentajes (19
+// significa 19%).
+type LocationPricing struct {
+	TaxRate              float64
+	ServiceChargePercent float64
+}
+
 // Order representa una orden/pedido
 type Order struct {
 	ID           string     `json:"id" db:"id"`
@@ -48,6 +56,7 @@ type Order struct {
 	Status       OrderStatus `json:"status" db:"status"`
 	SubtotalCents int       `json:"subtotal_cents" db:"subtotal_cents"`
 	TaxCents     int        `json:"tax_cents" db:"tax_cents"`
+	ServiceChargeCents int  `json:"service_charge_cents" db:"service_charge_cents"`
 	DiscountCents int       `json:"discount_cents" db:"discount_cents"`
 	TotalCents   int       `json:"total_cents" db:"total_cents"`
 	Notes        *string    `json:"notes" db:"notes"`
@@ -60,16 +60,17 @@ func (r *salesRepository) CreateOrder(order *models.Order) error {
 	query := `
 		INSERT INTO bar_system.orders (
 			id, order_number, table_id, location_id, waiter_id, 
-			status, subtotal_cents, tax_cents, discount_cents, total_cents, notes
+			status, subtotal_cents, tax_cents, service_charge_cents, discount_cents, total_cents, notes
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING created_at, updated_at
 	`
 
 	err := r.db.QueryRow(
 		query,
 		order.ID, order.OrderNumber, order.TableID, order.LocationID, order.WaiterID,
-		order.Status, order.SubtotalCents, order.TaxCents, order.DiscountCents, order.TotalCents, order.Notes,
+		order.Status, order.SubtotalCents, order.TaxCents, order.ServiceChargeCents, order.DiscountCents,
+		order.TotalCents, order.Notes,
 	).Scan(&order.CreatedAt, &order.UpdatedAt)
 
 	return err
@@ -138,7 +139,7 @@ func (r *salesRepository) GetOrders(filter *models.OrderFilter) ([]models.Order,
 
 	query := fmt.Sprintf(`
 		SELECT o.id, o.order_number, o.table_id, o.location_id, o.waiter_id, o.cashier_id,
-		       o.status, o.subtotal_cents, o.tax_cents, o.discount_cents, o.total_cents,
+		       o.status, o.subtotal_cents, o.tax_cents, o.service_charge_cents, o.discount_cents, o.total_cents,
 		       o.notes, o.created_at, o.updated_at, o.closed_at
 		FROM bar_system.orders o
 		%s
@@ -160,7 +161,7 @@ func (r *salesRepository) GetOrders(filter *models.OrderFilter) ([]models.Order,
 		err := rows.Scan(
 			&order.ID, &order.OrderNumber, &order.TableID, &order.LocationID,
 			&order.WaiterID, &order.CashierID, &order.Status,
-			&order.SubtotalCents, &order.TaxCents, &order.DiscountCents, &order.TotalCents,
+			&order.SubtotalCents, &order.TaxCents, &order.ServiceChargeCents, &order.DiscountCents, &order.TotalCents,
 			&order.Notes, &order.CreatedAt, &order.UpdatedAt, &order.ClosedAt,
 		)
 		if err != nil {
@@ -175,7 +176,7 @@ func (r *salesRepository) GetOrders(filter *models.OrderFilter) ([]models.Order,
 func (r *salesRepository) GetOrder(id string) (*models.Order, error) {
 	query := `
 		SELECT o.id, o.order_number, o.table_id, o.location_id, o.waiter_id, o.cashier_id,
-		       o.status, o.subtotal_cents, o.tax_cents, o.discount_cents, o.total_cents,
+		       o.status, o.subtotal_cents, o.tax_cents, o.service_charge_cents, o.discount_cents, o.total_cents,
 		       o.notes, o.created_at, o.updated_at, o.closed_at
 		FROM bar_system.orders o
 		WHERE o.id = $1
@@ -185,7 +186,7 @@ func (r *salesRepository) GetOrder(id string) (*models.Order, error) {
 	err := r.db.QueryRow(query, id).Scan(
 		&order.ID, &order.OrderNumber, &order.TableID, &order.LocationID,
 		&order.WaiterID, &order.CashierID, &order.Status,
-		&order.SubtotalCents, &order.TaxCents, &order.DiscountCents, &order.TotalCents,
+		&order.SubtotalCents, &order.TaxCents, &order.ServiceChargeCents, &order.DiscountCents, &order.TotalCents,
 		&order.Notes, &order.CreatedAt, &order.UpdatedAt, &order.ClosedAt,
 	)
 
@@ -202,15 +203,15 @@ func (r *salesRepository) GetOrder(id string) (*models.Order, error) {
 func (r *salesRepository) UpdateOrder(id string, order *models.Order) error {
 	query := `
 		UPDATE bar_system.orders
-		SET status = $1, subtotal_cents = $2, tax_cents = $3, 
-		    discount_cents = $4, total_cents = $5, notes = $6, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $7
+		SET status = $1, subtotal_cents = $2, tax_cents = $3, service_charge_cents = $4,
+		    discount_cents = $5, total_cents = $6, notes = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $8
 		RETURNING updated_at
 	`
 
 	err := r.db.QueryRow(
 		query,
-		order.Status, order.SubtotalCents, order.TaxCents,
+		order.Status, order.SubtotalCents, order.TaxCents, order.ServiceChargeCents,
 		order.DiscountCents, order.TotalCents, order.Notes, id,
 	).Scan(&order.UpdatedAt)
 
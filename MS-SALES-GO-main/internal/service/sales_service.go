@@ -2,8 +2,10 @@ package service
 
 import (
 	"fmt"
+	"math"
 	"time"
 
+	"ms-sales-go/internal/clients"
 	"ms-sales-go/internal/models"
 	"ms-sales-go/internal/repository"
 
@@ -12,10 +14,10 @@ import (
 
 type SalesService interface {
 	// Order operations
-	CreateOrder(req *models.CreateOrderRequest, waiterID string) (*models.Order, error)
+	CreateOrder(req *models.CreateOrderRequest, waiterID, authorization string) (*models.Order, error)
 	GetOrders(filter *models.OrderFilter) ([]models.Order, error)
 	GetOrder(id string) (*models.Order, error)
-	UpdateOrder(id string, req *models.UpdateOrderRequest) (*models.Order, error)
+	UpdateOrder(id string, req *models.UpdateOrderRequest, authorization string) (*models.Order, error)
 	CloseOrder(id string, req *models.CloseOrderRequest, cashierID string) (*models.Order, error)
 
 	// Payment operations
@@ -29,12 +31,14 @@ type SalesService interface {
 }
 
 type salesService struct {
-	repo repository.SalesRepository
+	repo  repository.SalesRepository
+	venue clients.VenueClient
 }
 
-func NewSalesService(repo repository.SalesRepository) SalesService {
+func NewSalesService(repo repository.SalesRepository, venue clients.VenueClient) SalesService {
 	return &salesService{
-		repo: repo,
+		repo:  repo,
+		venue: venue,
 	}
 }
 
@@ -42,7 +46,7 @@ func NewSalesService(repo repository.SalesRepository) SalesService {
 // Order operations
 // ================================================
 
-func (s *salesService) CreateOrder(req *models.CreateOrderRequest, waiterID string) (*models.Order, error) {
+func (s *salesService) CreateOrder(req *models.CreateOrderRequest, waiterID, authorization string) (*models.Order, error) {
 	// Generar número de orden único
 	orderNumber := generateOrderNumber()
 
@@ -70,10 +74,6 @@ func (s *salesService) CreateOrder(req *models.CreateOrderRequest, waiterID stri
 		subtotalCents += itemSubtotal
 	}
 
-	// Calcular impuestos (19% IVA en Colombia)
-	taxCents := int(float64(subtotalCents) * 0.19)
-	totalCents := subtotalCents + taxCents
-
 	// Crear orden
 	order := &models.Order{
 		ID:            generateUUID(),
@@ -83,12 +83,15 @@ func (s *salesService) CreateOrder(req *models.CreateOrderRequest, waiterID stri
 		WaiterID:      &waiterID,
 		Status:        models.OrderStatusPending,
 		SubtotalCents: subtotalCents,
-		TaxCents:      taxCents,
 		DiscountCents: 0, // Se puede actualizar después
-		TotalCents:    totalCents,
 		Notes:         req.Notes,
 	}
 
+	// Calcular impuestos y cargo por servicio con la configuración de la sede
+	if err := s.priceOrder(order, authorization); err != nil {
+		return nil, err
+	}
+
 	// Guardar orden
 	err := s.repo.CreateOrder(order)
 	if err != nil {
@@ -147,7 +150,7 @@ func (s *salesService) GetOrder(id string) (*models.Order, error) {
 	return order, nil
 }
 
-func (s *salesService) UpdateOrder(id string, req *models.UpdateOrderRequest) (*models.Order, error) {
+func (s *salesService) UpdateOrder(id string, req *models.UpdateOrderRequest, authorization string) (*models.Order, error) {
 	order, err := s.repo.GetOrder(id)
 	if err != nil {
 		return nil, fmt.Errorf("order not found: %w", err)
@@ -191,7 +194,9 @@ func (s *salesService) UpdateOrder(id string, req *models.UpdateOrderRequest) (*
 		}
 
 		order.SubtotalCents = subtotalCents
-		order.TaxCents = int(float64(subtotalCents) * 0.19)
+		if err := s.priceOrder(order, authorization); err != nil {
+			return nil, err
+		}
 	}
 
 	// Actualizar descuento si se proporciona
@@ -205,7 +210,7 @@ func (s *salesService) UpdateOrder(id string, req *models.UpdateOrderRequest) (*
 	}
 
 	// Recalcular total
-	order.TotalCents = order.SubtotalCents + order.TaxCents - order.DiscountCents
+	order.TotalCents = orderTotal(order)
 
 	// Guardar cambios
 	err = s.repo.UpdateOrder(id, order)
@@ -342,6 +347,29 @@ func (s *salesService) GetSalesSummary(startDate, endDate *time.Time, locationID
 // Helper functions
 // ================================================
 
+// priceOrder calcula el impuesto, el cargo por servicio y el total de la orden
+// a partir de su subtotal, con las tasas que MS-VENUE-GO tiene para su sede.
+// Ambos se calculan sobre el subtotal y se redondean al centavo.
+func (s *salesService) priceOrder(order *models.Order, authorization string) error {
+	pricing, err := s.venue.GetLocationPricing(order.LocationID, authorization)
+	if err != nil {
+		return fmt.Errorf("failed to get location settings: %w", err)
+	}
+
+	order.TaxCents = percentOf(order.SubtotalCents, pricing.TaxRate)
+	order.ServiceChargeCents = percentOf(order.SubtotalCents, pricing.ServiceChargePercent)
+	order.TotalCents = orderTotal(order)
+	return nil
+}
+
+func percentOf(cents int, percent float64) int {
+	return int(math.Round(float64(cents) * percent / 100))
+}
+
+func orderTotal(order *models.Order) int {
+	return order.SubtotalCents + order.TaxCents + order.ServiceChargeCents - order.DiscountCents
+}
+
 func generateUUID() string {
 	return uuid.New().String()
 }
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Waiter assignment handlers
+func (h *VenueHandler) AssignWaiter(c *gin.Context) {
+	var req models.CreateWaiterAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	assignment, err := h.venueService.AssignWaiter(c.Request.Context(), &req, userID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// GetWaiterAssignments lists assignments; everyone but admins only sees
+// their own venue's.
+func (h *VenueHandler) GetWaiterAssignments(c *gin.Context) {
+	filter := &models.WaiterAssignmentFilter{}
+
+	locationID, ok := venueScope(c, c.Query("location_id"))
+	if !ok {
+		return
+	}
+	if locationID != "" {
+		filter.LocationID = &locationID
+	}
+	if waiterID := c.Query("waiter_id"); waiterID != "" {
+		filter.WaiterID = &waiterID
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			respondValidationError(c, "from must be an RFC3339 timestamp")
+			return
+		}
+		filter.From = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			respondValidationError(c, "to must be an RFC3339 timestamp")
+			return
+		}
+		filter.To = &to
+	}
+
+	assignments, err := h.venueService.GetWaiterAssignments(c.Request.Context(), filter)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+func (h *VenueHandler) GetWaiterAssignmentByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Assignment ID is required")
+		return
+	}
+
+	assignment, err := h.venueService.GetWaiterAssignmentByID(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+func (h *VenueHandler) UnassignWaiter(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Assignment ID is required")
+		return
+	}
+
+	if err := h.venueService.UnassignWaiter(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Assignment removed successfully"})
+}
+
+// GetMyTables returns the tables the caller covers now, or at the RFC3339
+// time given in at.
+func (h *VenueHandler) GetMyTables(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok || userID == "" {
+		respondError(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "User ID not found in token")
+		return
+	}
+
+	at := time.Now()
+	if atStr := c.Query("at"); atStr != "" {
+		parsed, err := time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			respondValidationError(c, "at must be an RFC3339 timestamp")
+			return
+		}
+		at = parsed
+	}
+
+	tables, err := h.venueService.GetWaiterTables(c.Request.Context(), userID, at)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tables)
+}
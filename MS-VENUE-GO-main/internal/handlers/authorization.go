@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Role sets used by the route guards. Admins are always allowed and may act
+// on any location; everyone else only on the location in their venue_id claim.
+var (
+	ManagerRoles = []string{middleware.RoleAdmin, middleware.RoleManager}
+	FloorRoles   = []string{middleware.RoleAdmin, middleware.RoleManager, middleware.RoleWaiter}
+	StaffRoles   = []string{middleware.RoleAdmin, middleware.RoleManager, middleware.RoleCashier, middleware.RoleWaiter}
+)
+
+// locationResolver finds the location a request acts on.
+type locationResolver func(c *gin.Context) (string, error)
+
+// maxGuardedBodyBytes caps the body ForBodyLocation reads before the handler
+// binds it.
+const maxGuardedBodyBytes = 1 << 20
+
+// errLocationIDRequired is returned by ForBodyLocation when the body names
+// no location.
+var errLocationIDRequired = &service.Error{Code: models.ErrorCodeValidation, Message: "location_id is required"}
+
+// errBodyTooLarge is returned by ForBodyLocation when the body is over
+// maxGuardedBodyBytes.
+var errBodyTooLarge = &service.Error{Code: models.ErrorCodeValidation, Message: "request body is too large"}
+
+// Authorization guards
+
+// ForLocation guards routes whose :id is a location.
+func (h *VenueHandler) ForLocation(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		return c.Param("id"), nil
+	})
+}
+
+// ForBodyLocation guards routes whose JSON body carries a location_id.
+func (h *VenueHandler) ForBodyLocation(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxGuardedBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", errBodyTooLarge
+		}
+		if err != nil {
+			return "", errLocationIDRequired
+		}
+		// Put the body back for the handler to bind
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			LocationID string `json:"location_id"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.LocationID == "" {
+			return "", errLocationIDRequired
+		}
+		return req.LocationID, nil
+	})
+}
+
+// ForTable guards routes whose :id is a table.
+func (h *VenueHandler) ForTable(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		table, err := h.venueService.GetTableByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return table.LocationID, nil
+	})
+}
+
+// ForZone guards routes whose :id is a zone.
+func (h *VenueHandler) ForZone(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		zone, err := h.venueService.GetZoneByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return zone.LocationID, nil
+	})
+}
+
+// ForTableGroup guards routes whose :id is a table group.
+func (h *VenueHandler) ForTableGroup(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		group, err := h.venueService.GetTableGroupByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return group.LocationID, nil
+	})
+}
+
+// ForScheduleException guards routes whose :id is a schedule exception.
+func (h *VenueHandler) ForScheduleException(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		exception, err := h.venueService.GetScheduleExceptionByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return exception.LocationID, nil
+	})
+}
+
+// ForReservation guards routes whose :id is a reservation.
+func (h *VenueHandler) ForReservation(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		reservation, err := h.venueService.GetReservationByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return reservation.LocationID, nil
+	})
+}
+
+// ForWaitlistEntry guards routes whose :id is a waitlist entry.
+func (h *VenueHandler) ForWaitlistEntry(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		entry, err := h.venueService.GetWaitlistEntry(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return entry.LocationID, nil
+	})
+}
+
+// ForWaiterAssignment guards routes whose :id is a waiter assignment.
+func (h *VenueHandler) ForWaiterAssignment(roles ...string) gin.HandlerFunc {
+	return h.requireVenueRole(roles, func(c *gin.Context) (string, error) {
+		assignment, err := h.venueService.GetWaiterAssignmentByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return assignment.LocationID, nil
+	})
+}
+
+// canViewInactive reports whether the caller may list deleted records of the
+// location; an empty locationID means every location, which only admins see.
+func canViewInactive(c *gin.Context, locationID string) bool {
+	if middleware.HasAnyRole(c, middleware.RoleAdmin) {
+		return true
+	}
+	return locationID != "" && middleware.HasAnyRole(c, middleware.RoleManager) &&
+		middleware.GetVenueIDFromContext(c) == locationID
+}
+
+// venueScope returns the location a listing is limited to: the requested one
+// for admins, where empty means every location, and the caller's venue for
+// everyone else. It responds 403 and returns false when a non-admin asks for
+// another location or has no venue.
+func venueScope(c *gin.Context, requested string) (string, bool) {
+	if middleware.HasAnyRole(c, middleware.RoleAdmin) {
+		return requested, true
+	}
+	venueID := middleware.GetVenueIDFromContext(c)
+	if venueID == "" || (requested != "" && requested != venueID) {
+		respondError(c, http.StatusForbidden, models.ErrorCodeForbidden, "You can only view your own venue")
+		return "", false
+	}
+	return venueID, true
+}
+
+// requireVenueRole lets the request through when the caller has one of the
+// roles and, unless an admin, belongs to the location being acted on.
+func (h *VenueHandler) requireVenueRole(roles []string, resolve locationResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !middleware.HasAnyRole(c, roles...) {
+			respondError(c, http.StatusForbidden, models.ErrorCodeForbidden, "Insufficient permissions")
+			c.Abort()
+			return
+		}
+		if middleware.HasAnyRole(c, middleware.RoleAdmin) {
+			c.Next()
+			return
+		}
+
+		locationID, err := resolve(c)
+		if err != nil {
+			respondServiceError(c, err)
+			c.Abort()
+			return
+		}
+
+		venueID := middleware.GetVenueIDFromContext(c)
+		if venueID == "" || venueID != locationID {
+			respondError(c, http.StatusForbidden, models.ErrorCodeForbidden, "You can only manage your own venue")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	locationCSVHeader = []string{"code", "name", "address", "city", "phone", "email", "latitude", "longitude", "max_capacity"}
+	tableCSVHeader    = []string{"location_code", "code", "seats", "zone", "shape", "pos_x", "pos_y", "rotation", "width", "height"}
+)
+
+// Bulk handlers
+
+// ImportLocations accepts a CSV (Content-Type text/csv) or a JSON array of
+// locations. With dry_run=true nothing is written and the per-row report is
+// returned as is.
+func (h *VenueHandler) ImportLocations(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+
+	var rows []models.LocationImportRow
+	var rowErrors []models.ImportRowError
+	if isCSVRequest(c) {
+		records, err := readCSV(c.Request.Body, locationCSVHeader)
+		if err != nil {
+			respondValidationError(c, err.Error())
+			return
+		}
+		rows, rowErrors = parseLocationRecords(records)
+	} else if err := c.ShouldBindJSON(&rows); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		respondImport(c, &models.ImportResult{DryRun: dryRun, Rows: len(rows), Errors: rowErrors})
+		return
+	}
+
+	result, err := h.venueService.ImportLocations(c.Request.Context(), rows, dryRun)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondImport(c, result)
+}
+
+// ImportTables accepts a CSV (Content-Type text/csv) or a JSON array of
+// tables. With dry_run=true nothing is written and the per-row report is
+// returned as is.
+func (h *VenueHandler) ImportTables(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+
+	var rows []models.TableImportRow
+	var rowErrors []models.ImportRowError
+	if isCSVRequest(c) {
+		records, err := readCSV(c.Request.Body, tableCSVHeader)
+		if err != nil {
+			respondValidationError(c, err.Error())
+			return
+		}
+		rows, rowErrors = parseTableRecords(records)
+	} else if err := c.ShouldBindJSON(&rows); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		respondImport(c, &models.ImportResult{DryRun: dryRun, Rows: len(rows), Errors: rowErrors})
+		return
+	}
+
+	result, err := h.venueService.ImportTables(c.Request.Context(), rows, dryRun)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondImport(c, result)
+}
+
+// ExportLocations returns the active locations as CSV (format=csv, default)
+// or JSON, in the same shape ImportLocations accepts.
+func (h *VenueHandler) ExportLocations(c *gin.Context) {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.venueService.ExportLocations(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, []string{
+			row.Code, row.Name, row.Address, row.City, row.Phone, row.Email,
+			formatOptionalFloat(row.Latitude), formatOptionalFloat(row.Longitude), formatOptionalInt(row.MaxCapacity),
+		})
+	}
+	writeCSV(c, "locations.csv", locationCSVHeader, records)
+}
+
+// ExportTables returns the active tables of location_code, or of every active
+// location, as CSV (format=csv, default) or JSON, in the same shape
+// ImportTables accepts.
+func (h *VenueHandler) ExportTables(c *gin.Context) {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.venueService.ExportTables(c.Request.Context(), c.Query("location_code"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, []string{
+			row.LocationCode, row.Code, strconv.Itoa(row.Seats), row.Zone, row.Shape,
+			formatFloat(row.PosX), formatFloat(row.PosY), formatFloat(row.Rotation),
+			formatFloat(row.Width), formatFloat(row.Height),
+		})
+	}
+	writeCSV(c, "tables.csv", tableCSVHeader, records)
+}
+
+func parseDryRun(c *gin.Context) (bool, bool) {
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr == "" {
+		return false, true
+	}
+	dryRun, err := strconv.ParseBool(dryRunStr)
+	if err != nil {
+		respondValidationError(c, "dry_run must be true or false")
+		return false, false
+	}
+	return dryRun, true
+}
+
+func parseExportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		respondValidationError(c, "format must be csv or json")
+		return "", false
+	}
+	return format, true
+}
+
+// respondImport answers 200 for dry runs and applied imports, and 422 when
+// an import was rejected because of invalid rows.
+func respondImport(c *gin.Context, result *models.ImportResult) {
+	if result.Errors == nil {
+		result.Errors = make([]models.ImportRowError, 0)
+	}
+	if !result.DryRun && len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func isCSVRequest(c *gin.Context) bool {
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	return err == nil && mediaType == "text/csv"
+}
+
+// readCSV reads every record after the header, keyed by column name. The
+// header may list the columns in any order and may leave out optional ones.
+func readCSV(body io.Reader, columns []string) ([]map[string]string, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !known[column] {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+		header[i] = column
+	}
+
+	records := make([]map[string]string, 0)
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			record[column] = strings.TrimSpace(fields[i])
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseLocationRecords(records []map[string]string) ([]models.LocationImportRow, []models.ImportRowError) {
+	rows := make([]models.LocationImportRow, 0, len(records))
+	rowErrors := make([]models.ImportRowError, 0)
+	for i, record := range records {
+		p := fieldParser{record: record}
+		row := models.LocationImportRow{
+			Code:        record["code"],
+			Name:        record["name"],
+			Address:     record["address"],
+			City:        record["city"],
+			Phone:       record["phone"],
+			Email:       record["email"],
+			Latitude:    p.optionalFloat("latitude"),
+			Longitude:   p.optionalFloat("longitude"),
+			MaxCapacity: p.optionalInt("max_capacity"),
+		}
+		if p.err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{Row: i + 1, Message: p.err.Error()})
+		}
+		rows = append(rows, row)
+	}
+	return rows, rowErrors
+}
+
+func parseTableRecords(records []map[string]string) ([]models.TableImportRow, []models.ImportRowError) {
+	rows := make([]models.TableImportRow, 0, len(records))
+	rowErrors := make([]models.ImportRowError, 0)
+	for i, record := range records {
+		p := fieldParser{record: record}
+		row := models.TableImportRow{
+			LocationCode: record["location_code"],
+			Code:         record["code"],
+			Seats:        p.int("seats"),
+			Zone:         record["zone"],
+			Shape:        record["shape"],
+			PosX:         p.float("pos_x"),
+			PosY:         p.float("pos_y"),
+			Rotation:     p.float("rotation"),
+			Width:        p.float("width"),
+			Height:       p.float("height"),
+		}
+		if p.err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{Row: i + 1, Message: p.err.Error()})
+		}
+		rows = append(rows, row)
+	}
+	return rows, rowErrors
+}
+
+// fieldParser converts CSV fields and keeps the first conversion error, so a
+// row is parsed in one pass and reported once.
+type fieldParser struct {
+	record map[string]string
+	err    error
+}
+
+func (p *fieldParser) float(column string) float64 {
+	if value := p.optionalFloat(column); value != nil {
+		return *value
+	}
+	return 0
+}
+
+func (p *fieldParser) int(column string) int {
+	if value := p.optionalInt(column); value != nil {
+		return *value
+	}
+	return 0
+}
+
+func (p *fieldParser) optionalFloat(column string) *float64 {
+	raw := p.record[column]
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		if p.err == nil {
+			p.err = fmt.Errorf("%s must be a number", column)
+		}
+		return nil
+	}
+	return &value
+}
+
+func (p *fieldParser) optionalInt(column string) *int {
+	raw := p.record[column]
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		if p.err == nil {
+			p.err = fmt.Errorf("%s must be a whole number", column)
+		}
+		return nil
+	}
+	return &value
+}
+
+func writeCSV(c *gin.Context, filename string, header []string, records [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write(header)
+	writer.WriteAll(records)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatFloat(*value)
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Clone handlers
+func (h *VenueHandler) CloneLocation(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.CloneLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.venueService.CloneLocation(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatus maps the codes of service errors to HTTP statuses.
+var errorStatus = map[string]int{
+	models.ErrorCodeValidation:       http.StatusBadRequest,
+	models.ErrorCodeNotFound:         http.StatusNotFound,
+	models.ErrorCodeDuplicateCode:    http.StatusConflict,
+	models.ErrorCodeInvalidReference: http.StatusUnprocessableEntity,
+	models.ErrorCodeConflict:         http.StatusConflict,
+	models.ErrorCodeStillReferenced:  http.StatusConflict,
+	models.ErrorCodeVersionConflict:  http.StatusPreconditionFailed,
+}
+
+// respondError answers with the venue API error envelope.
+func respondError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, models.ErrorResponse{Code: code, Error: message})
+}
+
+// respondValidationError answers 400 for a malformed request.
+func respondValidationError(c *gin.Context, message string) {
+	respondError(c, http.StatusBadRequest, models.ErrorCodeValidation, message)
+}
+
+// respondServiceError answers with the status of a classified service
+// error. Anything else is an internal failure: it is logged and hidden from
+// the client.
+func respondServiceError(c *gin.Context, err error) {
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		status, ok := errorStatus[serviceErr.Code]
+		if !ok {
+			status = http.StatusBadRequest
+		}
+		respondError(c, status, serviceErr.Code, serviceErr.Message)
+		return
+	}
+
+	log.Printf("%s %s: %v", c.Request.Method, c.FullPath(), err)
+	respondError(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Internal server error")
+}
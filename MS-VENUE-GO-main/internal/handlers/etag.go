@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag exposes a resource version as a strong ETag, e.g. "3".
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch reads the version a write is conditioned on. If-Match is
+// required; "*" only asks for the resource to exist and is returned as 0.
+// Weak ETags never match a write (RFC 9110 13.1.1). It answers the request
+// itself and returns false when the header is missing, weak or invalid.
+func parseIfMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		respondError(c, http.StatusPreconditionRequired, models.ErrorCodePreconditionRequired, "If-Match header is required")
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+	if strings.HasPrefix(header, "W/") {
+		respondError(c, http.StatusPreconditionFailed, models.ErrorCodeVersionConflict, "If-Match does not accept weak ETags; send the ETag returned by this service")
+		return 0, false
+	}
+
+	if unquoted, err := strconv.Unquote(header); err == nil {
+		if version, err := strconv.Atoi(unquoted); err == nil && version > 0 {
+			return version, true
+		}
+	}
+
+	respondValidationError(c, "If-Match must be a single ETag returned by this service")
+	return 0, false
+}
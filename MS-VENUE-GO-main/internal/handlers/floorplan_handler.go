@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Floor plan handlers
+func (h *VenueHandler) GetFloorPlan(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	plan, err := h.venueService.GetFloorPlan(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *VenueHandler) SaveFloorPlan(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.SaveFloorPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	plan, err := h.venueService.SaveFloorPlan(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Geosearch handlers
+func (h *VenueHandler) FindNearbyLocations(c *gin.Context) {
+	query := &models.NearbyLocationsQuery{}
+
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		respondValidationError(c, "lat is required and must be a number")
+		return
+	}
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		respondValidationError(c, "lng is required and must be a number")
+		return
+	}
+	query.Latitude = lat
+	query.Longitude = lng
+
+	if radiusStr := c.Query("radius_km"); radiusStr != "" {
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			respondValidationError(c, "radius_km must be a number")
+			return
+		}
+		query.RadiusKm = radius
+	}
+	if openOnly := c.Query("open_only"); openOnly != "" {
+		parsed, err := strconv.ParseBool(openOnly)
+		if err != nil {
+			respondValidationError(c, "open_only must be true or false")
+			return
+		}
+		query.OpenOnly = parsed
+	}
+
+	locations, err := h.venueService.FindNearbyLocations(c.Request.Context(), query)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata" // location time zones must resolve without system zoneinfo
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+	"ms-venue-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testJWTSecret = "test-jwt-secret"
+
+// testServer wires the real routes to a venue service backed by the
+// in-memory repository and records which routes answered successfully.
+type testServer struct {
+	router *gin.Engine
+	repo   *repository.MemoryVenueRepository
+	svc    service.VenueService
+
+	mu   sync.Mutex
+	hits map[string]bool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repo := repository.NewMemoryVenueRepository()
+	s := &testServer{
+		router: gin.New(),
+		repo:   repo,
+		svc:    service.NewVenueService(repo, service.Config{QRTokenSecret: "test-qr-secret", MenuBaseURL: "http://menu.test"}),
+		hits:   make(map[string]bool),
+	}
+	s.router.Use(func(c *gin.Context) {
+		c.Next()
+		if c.FullPath() != "" && c.Writer.Status() < http.StatusBadRequest {
+			s.mu.Lock()
+			s.hits[c.Request.Method+" "+c.FullPath()] = true
+			s.mu.Unlock()
+		}
+	})
+	RegisterRoutes(s.router, NewVenueHandler(s.svc), middleware.NewAuthService(testJWTSecret))
+	return s
+}
+
+// token registers a user and returns its ID with a signed bearer token.
+func (s *testServer) token(t *testing.T, venueID string, roles ...string) (string, string) {
+	t.Helper()
+	userID := uuid.NewString()
+	s.repo.AddUser(userID, nil)
+	return userID, signToken(t, testJWTSecret, middleware.Claims{
+		UserID:  userID,
+		Email:   userID + "@example.com",
+		VenueID: venueID,
+		Roles:   roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+func signToken(t *testing.T, secret string, claims middleware.Claims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+// do sends a request through the router. A string body is sent as is, any
+// other non-nil body as JSON; headers are name and value pairs.
+func (s *testServer) do(t *testing.T, method, path, token string, body interface{}, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		payload, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// expect sends a request and fails the test unless it answers status.
+func (s *testServer) expect(t *testing.T, status int, method, path, token string, body interface{}, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := s.do(t, method, path, token, body, headers...)
+	if w.Code != status {
+		t.Fatalf("%s %s: status %d, want %d: %s", method, path, w.Code, status, w.Body.String())
+	}
+	return w
+}
+
+func decodeJSON[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode response %q: %v", w.Body.String(), err)
+	}
+	return v
+}
+
+func assertErrorResponse(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status %d, want %d: %s", w.Code, status, w.Body.String())
+	}
+	if resp := decodeJSON[models.ErrorResponse](t, w); resp.Code != code {
+		t.Fatalf("error code %q, want %q", resp.Code, code)
+	}
+}
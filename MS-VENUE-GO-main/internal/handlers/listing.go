@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePageRequest reads the sort, cursor and limit query parameters of a
+// listing. It answers the request itself and returns false when limit is not
+// a number.
+func parsePageRequest(c *gin.Context) (models.PageRequest, bool) {
+	req := models.PageRequest{Sort: c.Query("sort"), Cursor: c.Query("cursor")}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			respondValidationError(c, "limit must be a number")
+			return req, false
+		}
+		req.Limit = limit
+	}
+	return req, true
+}
+
+// queryInt reads an optional integer query parameter into dst. It answers
+// the request itself and returns false when the value is not a number.
+func queryInt(c *gin.Context, name string, dst **int) bool {
+	raw := c.Query(name)
+	if raw == "" {
+		return true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		respondValidationError(c, name+" must be a number")
+		return false
+	}
+	*dst = &value
+	return true
+}
+
+// queryBool reads an optional boolean query parameter into dst. It answers
+// the request itself and returns false when the value is not a boolean.
+func queryBool(c *gin.Context, name string, dst **bool) bool {
+	raw := c.Query(name)
+	if raw == "" {
+		return true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		respondValidationError(c, name+" must be true or false")
+		return false
+	}
+	*dst = &value
+	return true
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// messageResponse is the body of writes that answer with a confirmation
+// instead of the record, e.g. deletes.
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+type healthResponse struct {
+	Status  string `json:"status"`
+	Service string `json:"service"`
+}
+
+// Every route mounted by RegisterRoutes must be listed here; the handler
+// tests fail otherwise.
+var apiRoutes = []openapi.Route{
+	// Service
+	{Method: "GET", Path: "/health", Tag: "service", Summary: "Health check", Response: healthResponse{}},
+	{Method: "GET", Path: "/openapi.json", Tag: "service", Summary: "This OpenAPI document", Response: map[string]interface{}{}},
+	{Method: "GET", Path: "/docs", Tag: "service", Summary: "Interactive API documentation", Response: "", ResponseTypes: []string{"text/html"}},
+
+	// Locations
+	{Method: "GET", Path: "/api/venue/locations", Tag: "locations", Summary: "List locations",
+		Description:  "One page of locations, newest first unless sort says otherwise. Deleted locations (include_inactive=true or is_active=false) are only listed to admins.",
+		AuthOptional: true, Query: append(pageParams("created_at, name, code", "-created_at"),
+			boolQuery("is_active", "Only active (true) or only deleted (false) locations"),
+			boolQuery("include_inactive", "Also list deleted locations"),
+			stringQuery("code_prefix", "Only codes starting with this prefix"),
+		), Response: models.LocationPage{}, Errors: []int{http.StatusForbidden}},
+	{Method: "GET", Path: "/api/venue/locations/nearby", Tag: "locations", Summary: "Find locations near a point",
+		Query: []openapi.Parameter{
+			requiredQuery(numberQuery("lat", "Latitude")),
+			requiredQuery(numberQuery("lng", "Longitude")),
+			numberQuery("radius_km", "Search radius, 10 km by default"),
+			boolQuery("open_only", "Only locations open now"),
+		}, Response: []models.NearbyLocation{}},
+	{Method: "GET", Path: "/api/venue/locations/:id/is-open", Tag: "schedule", Summary: "Whether a location is open",
+		Query: []openapi.Parameter{timeQuery("at", "Moment to check, now by default")}, Response: models.OpenStatus{}},
+	{Method: "POST", Path: "/api/venue/locations", Tag: "locations", Summary: "Create a location", Auth: true,
+		Body: models.CreateLocationRequest{}, Status: http.StatusCreated, Response: models.Location{}, Errors: []int{http.StatusConflict}},
+	{Method: "GET", Path: "/api/venue/locations/:id", Tag: "locations", Summary: "Get a location", Auth: true,
+		Response: models.Location{}, ETag: true},
+	{Method: "PUT", Path: "/api/venue/locations/:id", Tag: "locations", Summary: "Update a location",
+		Description: "is_active may only repeat the current value: delete and restore the location through their own routes.", Auth: true,
+		Headers: ifMatchHeader, Body: models.UpdateLocationRequest{}, Response: models.Location{}, ETag: true, Errors: conditionalErrors},
+	{Method: "DELETE", Path: "/api/venue/locations/:id", Tag: "locations", Summary: "Delete a location", Auth: true,
+		Headers: ifMatchHeader, Response: messageResponse{}, Errors: conditionalErrors},
+	{Method: "POST", Path: "/api/venue/locations/:id/restore", Tag: "locations", Summary: "Restore a deleted location", Auth: true,
+		Response: models.Location{}, Errors: []int{http.StatusConflict}},
+	{Method: "POST", Path: "/api/venue/locations/:id/clone", Tag: "locations", Summary: "Clone a location with its zones and tables", Auth: true,
+		Body: models.CloneLocationRequest{}, Status: http.StatusCreated, Response: models.CloneLocationResult{}, Errors: []int{http.StatusConflict}},
+	{Method: "GET", Path: "/api/venue/locations/:id/settings", Tag: "settings", Summary: "Get location settings", Auth: true,
+		Response: models.LocationSettings{}},
+	{Method: "PUT", Path: "/api/venue/locations/:id/settings", Tag: "settings", Summary: "Update location settings", Auth: true,
+		Body: models.UpdateLocationSettingsRequest{}, Response: models.LocationSettings{}},
+
+	// Floor plan and seating
+	{Method: "GET", Path: "/api/venue/locations/:id/floorplan", Tag: "floorplan", Summary: "Get the floor plan", Auth: true,
+		Response: models.FloorPlan{}},
+	{Method: "PUT", Path: "/api/venue/locations/:id/floorplan", Tag: "floorplan", Summary: "Save the floor plan", Auth: true,
+		Body: models.SaveFloorPlanRequest{}, Response: models.FloorPlan{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/venue/locations/:id/seating-suggestions", Tag: "floorplan", Summary: "Suggest tables for a party", Auth: true,
+		Body: models.SeatingSuggestionRequest{}, Response: []models.SeatingSuggestion{}},
+
+	// Opening hours
+	{Method: "GET", Path: "/api/venue/locations/:id/hours", Tag: "schedule", Summary: "Get opening hours and exceptions", Auth: true,
+		Response: models.LocationSchedule{}},
+	{Method: "PUT", Path: "/api/venue/locations/:id/hours", Tag: "schedule", Summary: "Replace the weekly opening hours", Auth: true,
+		Body: models.SetOpeningHoursRequest{}, Response: models.LocationSchedule{}},
+	{Method: "POST", Path: "/api/venue/locations/:id/hours/exceptions", Tag: "schedule", Summary: "Add a special day or closure", Auth: true,
+		Body: models.CreateScheduleExceptionRequest{}, Status: http.StatusCreated, Response: models.ScheduleException{}},
+	{Method: "DELETE", Path: "/api/venue/schedule-exceptions/:id", Tag: "schedule", Summary: "Delete a special day or closure", Auth: true,
+		Response: messageResponse{}},
+
+	// Tables
+	{Method: "GET", Path: "/api/venue/:locationId/tables", Tag: "tables", Summary: "List a location's tables",
+		Description:  "One page of tables, by code unless sort says otherwise. Deleted tables (include_inactive=true or is_active=false) are only listed to admins and the venue's managers.",
+		AuthOptional: true, Query: append(pageParams("code, seats, created_at", "code"),
+			stringQuery("zone_id", "Only tables of this zone"),
+			enumQuery("status", "Only tables listed in this status, after bookings about to start are applied", models.TableStatusAvailable, models.TableStatusOccupied, models.TableStatusNeedsCleaning, models.TableStatusReserved),
+			intQuery("seats_min", "Only tables with at least this many seats"),
+			intQuery("seats_max", "Only tables with at most this many seats"),
+			stringQuery("code_prefix", "Only codes starting with this prefix"),
+			boolQuery("is_active", "Only active (true) or only deleted (false) tables"),
+			boolQuery("include_inactive", "Also list deleted tables"),
+		), Response: models.TablePage{}, Errors: []int{http.StatusForbidden}},
+	{Method: "GET", Path: "/api/venue/:locationId/tables/stream", Tag: "tables", Summary: "Stream table changes",
+		Description: "Server-Sent Events: a snapshot event with every table, then table_created, table_updated, table_status_changed, table_deleted and resync events.",
+		Response:    "", ResponseTypes: []string{"text/event-stream"}},
+	{Method: "GET", Path: "/api/venue/tables/resolve/:token", Tag: "tables", Summary: "Resolve a scanned table QR token",
+		Response: models.TableResolution{}},
+	{Method: "POST", Path: "/api/venue/tables", Tag: "tables", Summary: "Create a table", Auth: true,
+		Body: models.CreateTableRequest{}, Status: http.StatusCreated, Response: models.Table{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/tables/:id", Tag: "tables", Summary: "Get a table", Auth: true,
+		Response: models.Table{}, ETag: true},
+	{Method: "PUT", Path: "/api/venue/tables/:id", Tag: "tables", Summary: "Update a table",
+		Description: "is_active may only repeat the current value: delete and restore the table through their own routes.", Auth: true,
+		Headers: ifMatchHeader, Body: models.UpdateTableRequest{}, Response: models.Table{}, ETag: true, Errors: conditionalErrors},
+	{Method: "DELETE", Path: "/api/venue/tables/:id", Tag: "tables", Summary: "Delete a table", Auth: true,
+		Headers: ifMatchHeader, Response: messageResponse{}, Errors: conditionalErrors},
+	{Method: "POST", Path: "/api/venue/tables/:id/restore", Tag: "tables", Summary: "Restore a deleted table", Auth: true,
+		Response: models.Table{}, Errors: []int{http.StatusConflict}},
+	{Method: "POST", Path: "/api/venue/tables/:id/transitions", Tag: "tables", Summary: "Change a table's status", Auth: true,
+		Body: models.TableTransitionRequest{}, Response: models.Table{}, Errors: []int{http.StatusConflict}},
+	{Method: "GET", Path: "/api/venue/tables/:id/transitions", Tag: "tables", Summary: "Get a table's status history", Auth: true,
+		Response: []models.TableStatusChange{}},
+	{Method: "GET", Path: "/api/venue/tables/:id/qr", Tag: "tables", Summary: "Render a table's QR sticker",
+		Description: "Tables without a QR token answer 409 until a manager rotates one.", Auth: true,
+		Query: []openapi.Parameter{
+			enumQuery("format", "Image format, png by default", "png", "svg", "json"),
+			intQuery("size", "Image side in pixels, 512 by default"),
+		}, Response: models.TableQRCode{}, ResponseTypes: []string{"image/png", "image/svg+xml", "application/json"}, Errors: []int{http.StatusConflict}},
+	{Method: "POST", Path: "/api/venue/tables/:id/qr/rotate", Tag: "tables", Summary: "Issue a new QR token for a table", Auth: true,
+		Response: models.TableQRCode{}},
+
+	// Table groups
+	{Method: "POST", Path: "/api/venue/table-groups", Tag: "table-groups", Summary: "Merge tables into a group", Auth: true,
+		Body: models.CreateTableGroupRequest{}, Status: http.StatusCreated, Response: models.TableGroup{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/locations/:id/table-groups", Tag: "table-groups", Summary: "List a location's table groups", Auth: true,
+		Response: []models.TableGroup{}},
+	{Method: "GET", Path: "/api/venue/table-groups/:id", Tag: "table-groups", Summary: "Get a table group", Auth: true,
+		Response: models.TableGroup{}},
+	{Method: "POST", Path: "/api/venue/table-groups/:id/transitions", Tag: "table-groups", Summary: "Change a table group's status", Auth: true,
+		Body: models.TableTransitionRequest{}, Response: models.TableGroup{}, Errors: []int{http.StatusConflict}},
+	{Method: "DELETE", Path: "/api/venue/table-groups/:id", Tag: "table-groups", Summary: "Split a table group", Auth: true,
+		Response: messageResponse{}, Errors: []int{http.StatusConflict}},
+
+	// Zones
+	{Method: "POST", Path: "/api/venue/zones", Tag: "zones", Summary: "Create a zone", Auth: true,
+		Body: models.CreateZoneRequest{}, Status: http.StatusCreated, Response: models.Zone{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/locations/:id/zones", Tag: "zones", Summary: "List a location's zones", Auth: true,
+		Response: []models.Zone{}},
+	{Method: "GET", Path: "/api/venue/locations/:id/occupancy", Tag: "zones", Summary: "Occupancy by zone", Auth: true,
+		Response: []models.ZoneOccupancy{}},
+	{Method: "GET", Path: "/api/venue/zones/:id", Tag: "zones", Summary: "Get a zone", Auth: true,
+		Response: models.Zone{}},
+	{Method: "PUT", Path: "/api/venue/zones/:id", Tag: "zones", Summary: "Update a zone", Auth: true,
+		Body: models.UpdateZoneRequest{}, Response: models.Zone{}, Errors: []int{http.StatusConflict}},
+	{Method: "DELETE", Path: "/api/venue/zones/:id", Tag: "zones", Summary: "Delete a zone", Auth: true,
+		Response: messageResponse{}, Errors: []int{http.StatusConflict}},
+
+	// Reservations
+	{Method: "POST", Path: "/api/venue/reservations", Tag: "reservations", Summary: "Book a table", Auth: true,
+		Body: models.CreateReservationRequest{}, Status: http.StatusCreated, Response: models.Reservation{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/reservations", Tag: "reservations", Summary: "List reservations",
+		Description: "Everyone but admins only sees the reservations of their own venue.", Auth: true,
+		Query: []openapi.Parameter{
+			stringQuery("location_id", "Only reservations of this location"),
+			stringQuery("table_id", "Only reservations of this table"),
+			enumQuery("status", "Only reservations in this status", models.ReservationStatusBooked, models.ReservationStatusSeated, models.ReservationStatusNoShow, models.ReservationStatusCancelled, models.ReservationStatusCompleted),
+			timeQuery("from", "Only reservations ending after this moment"),
+			timeQuery("to", "Only reservations starting before this moment"),
+		}, Response: []models.Reservation{}},
+	{Method: "GET", Path: "/api/venue/reservations/:id", Tag: "reservations", Summary: "Get a reservation", Auth: true,
+		Response: models.Reservation{}},
+	{Method: "PUT", Path: "/api/venue/reservations/:id", Tag: "reservations", Summary: "Update a reservation", Auth: true,
+		Body: models.UpdateReservationRequest{}, Response: models.Reservation{}, Errors: []int{http.StatusConflict}},
+	{Method: "PUT", Path: "/api/venue/reservations/:id/status", Tag: "reservations", Summary: "Change a reservation's status", Auth: true,
+		Body: models.UpdateReservationStatusRequest{}, Response: models.Reservation{}, Errors: []int{http.StatusConflict}},
+	{Method: "DELETE", Path: "/api/venue/reservations/:id", Tag: "reservations", Summary: "Cancel a reservation", Auth: true,
+		Response: models.Reservation{}, Errors: []int{http.StatusConflict}},
+
+	// Waiter assignments
+	{Method: "POST", Path: "/api/venue/waiter-assignments", Tag: "waiter-assignments", Summary: "Assign a waiter to a section", Auth: true,
+		Body: models.CreateWaiterAssignmentRequest{}, Status: http.StatusCreated, Response: models.WaiterAssignment{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/waiter-assignments", Tag: "waiter-assignments", Summary: "List waiter assignments",
+		Description: "Everyone but admins only sees the assignments of their own venue.", Auth: true,
+		Query: []openapi.Parameter{
+			stringQuery("location_id", "Only assignments of this location"),
+			stringQuery("waiter_id", "Only assignments of this waiter"),
+			timeQuery("from", "Only shifts ending after this moment"),
+			timeQuery("to", "Only shifts starting before this moment"),
+		}, Response: []models.WaiterAssignment{}},
+	{Method: "GET", Path: "/api/venue/waiter-assignments/me", Tag: "waiter-assignments", Summary: "Tables assigned to the caller", Auth: true,
+		Query: []openapi.Parameter{timeQuery("at", "Moment of the shift, now by default")}, Response: models.WaiterTables{}},
+	{Method: "GET", Path: "/api/venue/waiter-assignments/:id", Tag: "waiter-assignments", Summary: "Get a waiter assignment", Auth: true,
+		Response: models.WaiterAssignment{}},
+	{Method: "DELETE", Path: "/api/venue/waiter-assignments/:id", Tag: "waiter-assignments", Summary: "Remove a waiter assignment", Auth: true,
+		Response: messageResponse{}},
+
+	// Waitlist
+	{Method: "POST", Path: "/api/venue/waitlist", Tag: "waitlist", Summary: "Add a walk-in party to the waitlist", Auth: true,
+		Body: models.CreateWaitlistEntryRequest{}, Status: http.StatusCreated, Response: models.WaitlistEntry{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/locations/:id/waitlist", Tag: "waitlist", Summary: "Get a location's waitlist", Auth: true,
+		Response: []models.WaitlistEntry{}},
+	{Method: "PUT", Path: "/api/venue/locations/:id/waitlist/order", Tag: "waitlist", Summary: "Reorder the waitlist", Auth: true,
+		Body: models.ReorderWaitlistRequest{}, Response: []models.WaitlistEntry{}},
+	{Method: "GET", Path: "/api/venue/waitlist/:id", Tag: "waitlist", Summary: "Get a waitlist entry", Auth: true,
+		Response: models.WaitlistEntry{}},
+	{Method: "POST", Path: "/api/venue/waitlist/:id/notify", Tag: "waitlist", Summary: "Notify a party that its table is ready", Auth: true,
+		Response: models.WaitlistEntry{}, Errors: []int{http.StatusConflict}},
+	{Method: "POST", Path: "/api/venue/waitlist/:id/seat", Tag: "waitlist", Summary: "Seat a waiting party", Auth: true,
+		Body: models.SeatWaitlistEntryRequest{}, Response: models.WaitlistEntry{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: "DELETE", Path: "/api/venue/waitlist/:id", Tag: "waitlist", Summary: "Remove a party from the waitlist", Auth: true,
+		Response: models.WaitlistEntry{}, Errors: []int{http.StatusConflict}},
+
+	// Admin
+	{Method: "POST", Path: "/api/venue/admin/import/locations", Tag: "admin", Summary: "Import locations from CSV or JSON", Auth: true,
+		Query: []openapi.Parameter{boolQuery("dry_run", "Validate without writing")},
+		Body:  []models.LocationImportRow{}, BodyTypes: []string{"application/json", "text/csv"},
+		Response: models.ImportResult{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{Method: "POST", Path: "/api/venue/admin/import/tables", Tag: "admin", Summary: "Import tables from CSV or JSON", Auth: true,
+		Query: []openapi.Parameter{boolQuery("dry_run", "Validate without writing")},
+		Body:  []models.TableImportRow{}, BodyTypes: []string{"application/json", "text/csv"},
+		Response: models.ImportResult{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{Method: "GET", Path: "/api/venue/admin/export/locations", Tag: "admin", Summary: "Export active locations", Auth: true,
+		Query:    []openapi.Parameter{enumQuery("format", "csv by default", "csv", "json")},
+		Response: []models.LocationImportRow{}, ResponseTypes: []string{"text/csv", "application/json"}},
+	{Method: "GET", Path: "/api/venue/admin/export/tables", Tag: "admin", Summary: "Export active tables", Auth: true,
+		Query: []openapi.Parameter{
+			enumQuery("format", "csv by default", "csv", "json"),
+			stringQuery("location_code", "Only tables of this location"),
+		}, Response: []models.TableImportRow{}, ResponseTypes: []string{"text/csv", "application/json"}},
+	{Method: "DELETE", Path: "/api/venue/admin/locations/:id/purge", Tag: "admin", Summary: "Permanently remove a deleted location", Auth: true,
+		Response: messageResponse{}, Errors: []int{http.StatusConflict}},
+	{Method: "DELETE", Path: "/api/venue/admin/tables/:id/purge", Tag: "admin", Summary: "Permanently remove a deleted table", Auth: true,
+		Response: messageResponse{}, Errors: []int{http.StatusConflict}},
+}
+
+var (
+	ifMatchHeader = []openapi.Parameter{{
+		Name: "If-Match", In: "header", Required: true, Schema: &openapi.Schema{Type: "string"},
+		Description: `Strong ETag of the version being changed, e.g. "3", or * for any version`,
+	}}
+	conditionalErrors = []int{http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired}
+)
+
+func pageParams(sorts, defaultSort string) []openapi.Parameter {
+	return []openapi.Parameter{
+		stringQuery("sort", "One of "+sorts+"; a leading - sorts descending. Default "+defaultSort),
+		stringQuery("cursor", "next_cursor of the previous page, listed with the same sort"),
+		intQuery("limit", "Page size, 50 by default and at most 200"),
+	}
+}
+
+func stringQuery(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "string"}}
+}
+
+func enumQuery(name, description string, values ...string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "string", Enum: values}}
+}
+
+func intQuery(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "integer"}}
+}
+
+func numberQuery(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "number"}}
+}
+
+func boolQuery(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "boolean"}}
+}
+
+func timeQuery(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description + " (RFC 3339)", Schema: &openapi.Schema{Type: "string", Format: "date-time"}}
+}
+
+func requiredQuery(p openapi.Parameter) openapi.Parameter {
+	p.Required = true
+	return p
+}
+
+var apiTags = map[string]string{
+	"service":            "Health and API documentation",
+	"locations":          "Venues of the bar",
+	"settings":           "Tax and receipt settings of a location",
+	"floorplan":          "Floor plan layout and seating suggestions",
+	"schedule":           "Opening hours, special days and closures",
+	"tables":             "Tables, their status lifecycle and QR stickers",
+	"table-groups":       "Tables merged for large parties",
+	"zones":              "Areas of a location",
+	"reservations":       "Table bookings",
+	"waiter-assignments": "Waiter sections per shift",
+	"waitlist":           "Walk-in waitlist",
+	"admin":              "Bulk import, export and permanent removal",
+}
+
+// apiDocument is built once, on first request.
+var apiDocument = sync.OnceValue(func() *openapi.Document {
+	b := openapi.New(openapi.Info{
+		Title:       "MS-VENUE-GO",
+		Description: "Locations, tables, zones, reservations and floor operations of the bar management system. Errors answer with the ErrorResponse envelope; clients should branch on its code.",
+		Version:     "1.0.0",
+	}, models.ErrorResponse{})
+	for name, description := range apiTags {
+		b.Tag(name, description)
+	}
+	for _, route := range apiRoutes {
+		b.Add(route)
+	}
+	return b.Document()
+})
+
+// OpenAPISpec serves the OpenAPI document of the venue API.
+func (h *VenueHandler) OpenAPISpec(c *gin.Context) {
+	c.JSON(http.StatusOK, apiDocument())
+}
+
+// APIDocs serves Swagger UI for the OpenAPI document.
+func (h *VenueHandler) APIDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(apiDocsPage))
+}
+
+const apiDocsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>MS-VENUE-GO API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
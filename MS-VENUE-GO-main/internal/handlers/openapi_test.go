@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"ms-venue-go/internal/openapi"
+)
+
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	s := newTestServer(t)
+	doc := decodeJSON[map[string]interface{}](t, s.expect(t, http.StatusOK, "GET", "/openapi.json", "", nil))
+
+	if doc["openapi"] != openapi.Version {
+		t.Errorf("openapi = %v, want %s", doc["openapi"], openapi.Version)
+	}
+	paths, _ := doc["paths"].(map[string]interface{})
+
+	registered := make(map[string]bool)
+	for _, route := range s.router.Routes() {
+		path, method := openapi.Path(route.Path), strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+		item, _ := paths[path].(map[string]interface{})
+		if _, ok := item[method]; !ok {
+			t.Errorf("%s %s is registered but missing from the OpenAPI document", route.Method, route.Path)
+		}
+	}
+	for path, item := range paths {
+		for method := range item.(map[string]interface{}) {
+			if !registered[method+" "+path] {
+				t.Errorf("%s %s is documented but not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+
+	schemas, _ := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	var checkRefs func(v interface{})
+	checkRefs = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				if _, ok := schemas[strings.TrimPrefix(ref, "#/components/schemas/")]; !ok {
+					t.Errorf("unresolved reference %s", ref)
+				}
+			}
+			for _, child := range v {
+				checkRefs(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				checkRefs(child)
+			}
+		}
+	}
+	checkRefs(doc)
+
+	for _, name := range []string{"Location", "Table", "CreateLocationRequest", "UpdateTableRequest", "ErrorResponse"} {
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("schema %s is missing", name)
+		}
+	}
+}
+
+func TestAPIDocs(t *testing.T) {
+	s := newTestServer(t)
+	w := s.expect(t, http.StatusOK, "GET", "/docs", "", nil)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("content type %q, want text/html", ct)
+	}
+	if !strings.Contains(w.Body.String(), "openapi.json") {
+		t.Error("docs page does not load openapi.json")
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Reservation handlers
+func (h *VenueHandler) CreateReservation(c *gin.Context) {
+	var req models.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	reservation, err := h.venueService.CreateReservation(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// GetReservations lists reservations; everyone but admins only sees their
+// own venue's.
+func (h *VenueHandler) GetReservations(c *gin.Context) {
+	filter := &models.ReservationFilter{}
+
+	locationID, ok := venueScope(c, c.Query("location_id"))
+	if !ok {
+		return
+	}
+	if locationID != "" {
+		filter.LocationID = &locationID
+	}
+	if tableID := c.Query("table_id"); tableID != "" {
+		filter.TableID = &tableID
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			respondValidationError(c, "from must be an RFC3339 timestamp")
+			return
+		}
+		filter.From = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			respondValidationError(c, "to must be an RFC3339 timestamp")
+			return
+		}
+		filter.To = &to
+	}
+
+	reservations, err := h.venueService.GetReservations(c.Request.Context(), filter)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+func (h *VenueHandler) GetReservationByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Reservation ID is required")
+		return
+	}
+
+	reservation, err := h.venueService.GetReservationByID(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *VenueHandler) UpdateReservation(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Reservation ID is required")
+		return
+	}
+
+	var req models.UpdateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	reservation, err := h.venueService.UpdateReservation(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *VenueHandler) UpdateReservationStatus(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Reservation ID is required")
+		return
+	}
+
+	var req models.UpdateReservationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	reservation, err := h.venueService.UpdateReservationStatus(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *VenueHandler) CancelReservation(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Reservation ID is required")
+		return
+	}
+
+	reservation, err := h.venueService.CancelReservation(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
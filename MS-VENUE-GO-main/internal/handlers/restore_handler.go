@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Restore and purge handlers
+func (h *VenueHandler) RestoreLocation(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	location, err := h.venueService.RestoreLocation(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+func (h *VenueHandler) RestoreTable(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	table, err := h.venueService.RestoreTable(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+func (h *VenueHandler) PurgeLocation(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	if err := h.venueService.PurgeLocation(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Location purged successfully"})
+}
+
+func (h *VenueHandler) PurgeTable(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	if err := h.venueService.PurgeTable(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Table purged successfully"})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"ms-venue-go/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes mounts every venue endpoint on r.
+func RegisterRoutes(r *gin.Engine, h *VenueHandler, authService middleware.AuthService) {
+	// Health check endpoint
+	r.GET("/health", h.HealthCheck)
+
+	// API description (see apiRoutes) and its interactive docs
+	r.GET("/openapi.json", h.OpenAPISpec)
+	r.GET("/docs", h.APIDocs)
+
+	// Public endpoints (no auth required)
+	public := r.Group("/api/venue")
+	{
+		// Get all locations (public for frontend)
+		public.GET("/locations", middleware.OptionalAuth(authService), h.GetAllLocations)
+		public.GET("/locations/nearby", h.FindNearbyLocations)
+		public.GET("/locations/:id/is-open", h.IsLocationOpen)
+
+		// Resolve a scanned table QR sticker (public for guests)
+		public.GET("/tables/resolve/:token", h.ResolveTableToken)
+
+		// Get tables by location (public for frontend)
+		public.GET("/:locationId/tables", middleware.OptionalAuth(authService), h.GetTablesByLocation)
+		public.GET("/:locationId/tables/stream", h.StreamTableEvents)
+	}
+
+	// Protected endpoints (require authentication)
+	protected := r.Group("/api/venue")
+	protected.Use(middleware.RequireAuth(authService))
+
+	// Writes are scoped by role and venue: admins manage locations and act on
+	// any venue, managers run their own venue and waiters only move its
+	// tables through their statuses. Reads of guest details are limited to
+	// the venue's staff.
+	adminOnly := middleware.RequireAnyRole(middleware.RoleAdmin)
+	manager := ManagerRoles
+	floor := FloorRoles
+	staff := StaffRoles
+	{
+		// Location management
+		protected.POST("/locations", adminOnly, h.CreateLocation)
+		protected.GET("/locations/:id", h.GetLocationByID)
+		protected.PUT("/locations/:id", adminOnly, h.UpdateLocation)
+		protected.DELETE("/locations/:id", adminOnly, h.DeleteLocation)
+		protected.POST("/locations/:id/restore", adminOnly, h.RestoreLocation)
+		protected.POST("/locations/:id/clone", adminOnly, h.CloneLocation)
+		protected.GET("/locations/:id/floorplan", h.GetFloorPlan)
+		protected.PUT("/locations/:id/floorplan", h.ForLocation(manager...), h.SaveFloorPlan)
+		protected.POST("/locations/:id/seating-suggestions", h.ForLocation(floor...), h.SuggestSeating)
+		protected.GET("/locations/:id/settings", h.GetLocationSettings)
+		protected.PUT("/locations/:id/settings", h.ForLocation(manager...), h.UpdateLocationSettings)
+
+		// Opening hours, special days and closures
+		protected.GET("/locations/:id/hours", h.GetLocationSchedule)
+		protected.PUT("/locations/:id/hours", h.ForLocation(manager...), h.SetOpeningHours)
+		protected.POST("/locations/:id/hours/exceptions", h.ForLocation(manager...), h.CreateScheduleException)
+		protected.DELETE("/schedule-exceptions/:id", h.ForScheduleException(manager...), h.DeleteScheduleException)
+
+		// Table management
+		protected.POST("/tables", h.ForBodyLocation(manager...), h.CreateTable)
+		protected.GET("/tables/:id", h.GetTableByID)
+		protected.PUT("/tables/:id", h.ForTable(manager...), h.UpdateTable)
+		protected.DELETE("/tables/:id", h.ForTable(manager...), h.DeleteTable)
+		protected.POST("/tables/:id/restore", h.ForTable(manager...), h.RestoreTable)
+		protected.POST("/tables/:id/transitions", h.ForTable(floor...), h.TransitionTable)
+		protected.GET("/tables/:id/transitions", h.GetTableStatusHistory)
+		protected.GET("/tables/:id/qr", h.ForTable(staff...), h.GetTableQRCode)
+		protected.POST("/tables/:id/qr/rotate", h.ForTable(manager...), h.RotateTableQRCode)
+
+		// Table groups (merged tables)
+		protected.POST("/table-groups", h.ForBodyLocation(manager...), h.CreateTableGroup)
+		protected.GET("/locations/:id/table-groups", h.GetTableGroupsByLocation)
+		protected.GET("/table-groups/:id", h.GetTableGroupByID)
+		protected.POST("/table-groups/:id/transitions", h.ForTableGroup(floor...), h.TransitionTableGroup)
+		protected.DELETE("/table-groups/:id", h.ForTableGroup(manager...), h.SplitTableGroup)
+
+		// Zone management
+		protected.POST("/zones", h.ForBodyLocation(manager...), h.CreateZone)
+		protected.GET("/locations/:id/zones", h.GetZonesByLocation)
+		protected.GET("/locations/:id/occupancy", h.GetOccupancyByZone)
+		protected.GET("/zones/:id", h.GetZoneByID)
+		protected.PUT("/zones/:id", h.ForZone(manager...), h.UpdateZone)
+		protected.DELETE("/zones/:id", h.ForZone(manager...), h.DeleteZone)
+
+		// Reservation management
+		protected.POST("/reservations", h.ForBodyLocation(manager...), h.CreateReservation)
+		protected.GET("/reservations", h.GetReservations)
+		protected.GET("/reservations/:id", h.ForReservation(staff...), h.GetReservationByID)
+		protected.PUT("/reservations/:id", h.ForReservation(manager...), h.UpdateReservation)
+		protected.PUT("/reservations/:id/status", h.ForReservation(manager...), h.UpdateReservationStatus)
+		protected.DELETE("/reservations/:id", h.ForReservation(manager...), h.CancelReservation)
+
+		// Waiter section assignments
+		protected.POST("/waiter-assignments", h.ForBodyLocation(manager...), h.AssignWaiter)
+		protected.GET("/waiter-assignments", h.GetWaiterAssignments)
+		protected.GET("/waiter-assignments/me", h.GetMyTables)
+		protected.GET("/waiter-assignments/:id", h.ForWaiterAssignment(staff...), h.GetWaiterAssignmentByID)
+		protected.DELETE("/waiter-assignments/:id", h.ForWaiterAssignment(manager...), h.UnassignWaiter)
+
+		// Walk-in waitlist
+		protected.POST("/waitlist", h.ForBodyLocation(manager...), h.JoinWaitlist)
+		protected.GET("/locations/:id/waitlist", h.ForLocation(staff...), h.GetWaitlist)
+		protected.PUT("/locations/:id/waitlist/order", h.ForLocation(manager...), h.ReorderWaitlist)
+		protected.GET("/waitlist/:id", h.ForWaitlistEntry(staff...), h.GetWaitlistEntry)
+		protected.POST("/waitlist/:id/notify", h.ForWaitlistEntry(manager...), h.NotifyWaitlistEntry)
+		protected.POST("/waitlist/:id/seat", h.ForWaitlistEntry(manager...), h.SeatWaitlistEntry)
+		protected.DELETE("/waitlist/:id", h.ForWaitlistEntry(manager...), h.CancelWaitlistEntry)
+	}
+
+	// Admin-only endpoints
+	admin := r.Group("/api/venue/admin")
+	admin.Use(middleware.RequireRole(authService, middleware.RoleAdmin))
+	{
+		// Bulk import and export across every location
+		admin.POST("/import/locations", h.ImportLocations)
+		admin.POST("/import/tables", h.ImportTables)
+		admin.GET("/export/locations", h.ExportLocations)
+		admin.GET("/export/tables", h.ExportTables)
+
+		// Permanent removal of deleted locations and tables
+		admin.DELETE("/locations/:id/purge", h.PurgeLocation)
+		admin.DELETE("/tables/:id/purge", h.PurgeTable)
+	}
+}
@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// TestRoutes walks every registered route through a successful request and
+// fails if one is left out.
+func TestRoutes(t *testing.T) {
+	s := newTestServer(t)
+	_, admin := s.token(t, "", middleware.RoleAdmin)
+
+	s.expect(t, http.StatusOK, "GET", "/health", "", nil)
+	s.expect(t, http.StatusOK, "GET", "/openapi.json", "", nil)
+	s.expect(t, http.StatusOK, "GET", "/docs", "", nil)
+
+	// Locations
+	main := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{
+		Code: "MAIN", Name: "Main", Address: "Calle 1", Latitude: floatPtr(4.65), Longitude: floatPtr(-74.05),
+	}))
+	other := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{
+		Code: "OTHER", Name: "Other", Address: "Calle 2",
+	}))
+	_, manager := s.token(t, main.ID, middleware.RoleManager)
+	waiterID, waiter := s.token(t, main.ID, middleware.RoleWaiter)
+	locationPath := "/api/venue/locations/" + main.ID
+
+	if locations := decodeJSON[models.LocationPage](t, s.expect(t, http.StatusOK, "GET", "/api/venue/locations", "", nil)); len(locations.Items) != 2 {
+		t.Fatalf("listed %d locations, want 2", len(locations.Items))
+	}
+	s.expect(t, http.StatusOK, "GET", "/api/venue/locations/nearby?lat=4.65&lng=-74.05", "", nil)
+	s.expect(t, http.StatusOK, "GET", locationPath+"/is-open", "", nil)
+
+	w := s.expect(t, http.StatusOK, "GET", locationPath, manager, nil)
+	w = s.expect(t, http.StatusOK, "PUT", locationPath, admin, models.UpdateLocationRequest{Name: "Main Bar"}, "If-Match", w.Header().Get("ETag"))
+	if etag := w.Header().Get("ETag"); etag != `"2"` {
+		t.Errorf("ETag after update = %s, want \"2\"", etag)
+	}
+
+	s.expect(t, http.StatusOK, "PUT", locationPath+"/settings", manager, models.UpdateLocationSettingsRequest{CurrencyCode: stringPtr("COP")})
+	s.expect(t, http.StatusOK, "GET", locationPath+"/settings", manager, nil)
+
+	s.expect(t, http.StatusOK, "PUT", locationPath+"/hours", manager, models.SetOpeningHoursRequest{
+		Hours: []models.OpeningHoursInput{{DayOfWeek: intPtr(5), OpensAt: "18:00", ClosesAt: "02:00"}},
+	})
+	s.expect(t, http.StatusOK, "GET", locationPath+"/hours", manager, nil)
+	s.expect(t, http.StatusOK, "PUT", locationPath+"/hours", manager, models.SetOpeningHoursRequest{Hours: []models.OpeningHoursInput{}})
+	exception := decodeJSON[models.ScheduleException](t, s.expect(t, http.StatusCreated, "POST", locationPath+"/hours/exceptions", manager, models.CreateScheduleExceptionRequest{
+		StartDate: "2030-12-24", IsClosed: true, Reason: "Christmas Eve",
+	}))
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/schedule-exceptions/"+exception.ID, manager, nil)
+
+	// Zones and tables
+	zone := decodeJSON[models.Zone](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/zones", manager, models.CreateZoneRequest{
+		LocationID: main.ID, Name: "Terrace", Environment: models.ZoneEnvironmentOutdoor,
+	}))
+	s.expect(t, http.StatusOK, "GET", locationPath+"/zones", manager, nil)
+	s.expect(t, http.StatusOK, "GET", "/api/venue/zones/"+zone.ID, manager, nil)
+	s.expect(t, http.StatusOK, "PUT", "/api/venue/zones/"+zone.ID, manager, models.UpdateZoneRequest{SortOrder: intPtr(1)})
+	unused := decodeJSON[models.Zone](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/zones", manager, models.CreateZoneRequest{
+		LocationID: main.ID, Name: "Cellar", Environment: models.ZoneEnvironmentIndoor,
+	}))
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/zones/"+unused.ID, manager, nil)
+
+	var tables []models.Table
+	for _, code := range []string{"T1", "T2", "T3"} {
+		tables = append(tables, decodeJSON[models.Table](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/tables", manager, models.CreateTableRequest{
+			LocationID: main.ID, ZoneID: &zone.ID, Code: code, Seats: 4,
+		})))
+	}
+	t1, t2, t3 := tables[0], tables[1], tables[2]
+	tablePath := "/api/venue/tables/" + t1.ID
+
+	if listed := decodeJSON[models.TablePage](t, s.expect(t, http.StatusOK, "GET", "/api/venue/"+main.ID+"/tables", "", nil)); len(listed.Items) != 3 {
+		t.Fatalf("listed %d tables, want 3", len(listed.Items))
+	}
+	w = s.expect(t, http.StatusOK, "GET", tablePath, waiter, nil)
+	s.expect(t, http.StatusOK, "PUT", tablePath, manager, models.UpdateTableRequest{Seats: 6}, "If-Match", w.Header().Get("ETag"))
+
+	qr := decodeJSON[models.TableQRCode](t, s.expect(t, http.StatusOK, "GET", tablePath+"/qr?format=json", waiter, nil))
+	if w := s.expect(t, http.StatusOK, "GET", tablePath+"/qr", waiter, nil); w.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("QR content type = %s, want image/png", w.Header().Get("Content-Type"))
+	}
+	rotated := decodeJSON[models.TableQRCode](t, s.expect(t, http.StatusOK, "POST", tablePath+"/qr/rotate", manager, nil))
+	if rotated.Token == qr.Token {
+		t.Error("rotating the QR code should issue a new token")
+	}
+	s.expect(t, http.StatusNotFound, "GET", "/api/venue/tables/resolve/"+qr.Token, "", nil)
+	s.expect(t, http.StatusOK, "GET", "/api/venue/tables/resolve/"+rotated.Token, "", nil)
+
+	for _, status := range []string{models.TableStatusOccupied, models.TableStatusNeedsCleaning, models.TableStatusAvailable} {
+		s.expect(t, http.StatusOK, "POST", tablePath+"/transitions", waiter, models.TableTransitionRequest{Status: status})
+	}
+	if history := decodeJSON[[]models.TableStatusChange](t, s.expect(t, http.StatusOK, "GET", tablePath+"/transitions", waiter, nil)); len(history) != 3 {
+		t.Errorf("history has %d entries, want 3", len(history))
+	}
+	s.expect(t, http.StatusOK, "GET", locationPath+"/occupancy", waiter, nil)
+
+	s.expect(t, http.StatusOK, "PUT", locationPath+"/floorplan", manager, models.SaveFloorPlanRequest{Canvas: models.Canvas{Width: 800, Height: 600}})
+	s.expect(t, http.StatusOK, "GET", locationPath+"/floorplan", waiter, nil)
+	s.expect(t, http.StatusOK, "POST", locationPath+"/seating-suggestions", waiter, models.SeatingSuggestionRequest{PartySize: 2})
+
+	// Table groups
+	group := decodeJSON[models.TableGroup](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/table-groups", manager, models.CreateTableGroupRequest{
+		LocationID: main.ID, TableIDs: []string{t2.ID, t3.ID},
+	}))
+	s.expect(t, http.StatusOK, "GET", locationPath+"/table-groups", waiter, nil)
+	s.expect(t, http.StatusOK, "GET", "/api/venue/table-groups/"+group.ID, waiter, nil)
+	s.expect(t, http.StatusOK, "POST", "/api/venue/table-groups/"+group.ID+"/transitions", waiter, models.TableTransitionRequest{Status: models.TableStatusOccupied})
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/table-groups/"+group.ID, manager, nil)
+
+	// Reservations
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	var reservations []models.Reservation
+	for i := 0; i < 2; i++ {
+		starts := tomorrow.Add(time.Duration(i) * 3 * time.Hour)
+		reservations = append(reservations, decodeJSON[models.Reservation](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/reservations", manager, models.CreateReservationRequest{
+			LocationID: main.ID, TableID: t1.ID, PartyName: "Gómez", Phone: "+573001234567", PartySize: 2,
+			StartsAt: starts, EndsAt: starts.Add(2 * time.Hour),
+		})))
+	}
+	reservationPath := "/api/venue/reservations/" + reservations[0].ID
+	s.expect(t, http.StatusOK, "GET", "/api/venue/reservations?location_id="+main.ID, waiter, nil)
+	s.expect(t, http.StatusOK, "GET", reservationPath, waiter, nil)
+	s.expect(t, http.StatusOK, "PUT", reservationPath, manager, models.UpdateReservationRequest{PartySize: 3})
+	s.expect(t, http.StatusOK, "PUT", reservationPath+"/status", manager, models.UpdateReservationStatusRequest{Status: models.ReservationStatusNoShow})
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/reservations/"+reservations[1].ID, manager, nil)
+
+	// Waiter assignments
+	assignment := decodeJSON[models.WaiterAssignment](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/waiter-assignments", manager, models.CreateWaiterAssignmentRequest{
+		LocationID: main.ID, WaiterID: waiterID, TableIDs: []string{t1.ID},
+		ShiftStart: time.Now().Add(-time.Hour), ShiftEnd: time.Now().Add(4 * time.Hour),
+	}))
+	if listed := decodeJSON[[]models.WaiterAssignment](t, s.expect(t, http.StatusOK, "GET", "/api/venue/waiter-assignments", manager, nil)); len(listed) != 1 || listed[0].LocationID != main.ID {
+		t.Errorf("manager lists %+v, want only the assignment of their venue", listed)
+	}
+	mine := decodeJSON[models.WaiterTables](t, s.expect(t, http.StatusOK, "GET", "/api/venue/waiter-assignments/me", waiter, nil))
+	if len(mine.Tables) != 1 || mine.Tables[0].ID != t1.ID {
+		t.Errorf("waiter covers %+v, want only T1", mine.Tables)
+	}
+	s.expect(t, http.StatusOK, "GET", "/api/venue/waiter-assignments/"+assignment.ID, manager, nil)
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/waiter-assignments/"+assignment.ID, manager, nil)
+
+	// Waitlist
+	var entries []models.WaitlistEntry
+	for _, party := range []string{"First", "Second"} {
+		entries = append(entries, decodeJSON[models.WaitlistEntry](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/waitlist", manager, models.CreateWaitlistEntryRequest{
+			LocationID: main.ID, PartyName: party, Phone: "+573001234567", PartySize: 2,
+		})))
+	}
+	s.expect(t, http.StatusOK, "GET", locationPath+"/waitlist", waiter, nil)
+	s.expect(t, http.StatusOK, "PUT", locationPath+"/waitlist/order", manager, models.ReorderWaitlistRequest{EntryIDs: []string{entries[1].ID, entries[0].ID}})
+	s.expect(t, http.StatusOK, "GET", "/api/venue/waitlist/"+entries[0].ID, waiter, nil)
+	s.expect(t, http.StatusOK, "POST", "/api/venue/waitlist/"+entries[0].ID+"/notify", manager, nil)
+	s.expect(t, http.StatusOK, "POST", "/api/venue/waitlist/"+entries[0].ID+"/seat", manager, models.SeatWaitlistEntryRequest{TableID: t1.ID})
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/waitlist/"+entries[1].ID, manager, nil)
+
+	// Cloning and bulk import and export
+	s.expect(t, http.StatusCreated, "POST", locationPath+"/clone", admin, models.CloneLocationRequest{Code: "COPY", Name: "Copy"})
+	if w := s.expect(t, http.StatusOK, "GET", "/api/venue/admin/export/locations", admin, nil); !strings.HasPrefix(w.Body.String(), strings.Join(locationCSVHeader, ",")) {
+		t.Errorf("locations export does not start with the CSV header: %q", w.Body.String())
+	}
+	s.expect(t, http.StatusOK, "GET", "/api/venue/admin/export/tables?format=json&location_code=MAIN", admin, nil)
+	s.expect(t, http.StatusOK, "POST", "/api/venue/admin/import/locations?dry_run=true", admin, []models.LocationImportRow{{Code: "NEW", Name: "New", Address: "Calle 9"}})
+	s.expect(t, http.StatusOK, "POST", "/api/venue/admin/import/tables?dry_run=true", admin, "location_code,code,seats\nMAIN,T9,2\n", "Content-Type", "text/csv")
+
+	// Soft delete, restore and purge
+	t3Path := "/api/venue/tables/" + t3.ID
+	s.expect(t, http.StatusOK, "DELETE", t3Path, manager, nil, "If-Match", "*")
+	s.expect(t, http.StatusOK, "POST", t3Path+"/restore", manager, nil)
+	s.expect(t, http.StatusOK, "DELETE", t3Path, manager, nil, "If-Match", "*")
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/admin/tables/"+t3.ID+"/purge", admin, nil)
+	s.expect(t, http.StatusNotFound, "GET", t3Path, manager, nil)
+
+	otherPath := "/api/venue/locations/" + other.ID
+	s.expect(t, http.StatusOK, "DELETE", otherPath, admin, nil, "If-Match", "*")
+	s.expect(t, http.StatusOK, "POST", otherPath+"/restore", admin, nil)
+	s.expect(t, http.StatusOK, "DELETE", otherPath, admin, nil, "If-Match", "*")
+	s.expect(t, http.StatusOK, "DELETE", "/api/venue/admin/locations/"+other.ID+"/purge", admin, nil)
+	s.expect(t, http.StatusNotFound, "GET", otherPath, admin, nil)
+
+	// Table event stream
+	server := httptest.NewServer(s.router)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/venue/"+main.ID+"/tables/stream", nil)
+	if err != nil {
+		t.Fatalf("new stream request: %v", err)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || line != "event:snapshot\n" {
+		t.Errorf("first stream line = %q (%v), want the snapshot event", line, err)
+	}
+	cancel()
+	resp.Body.Close()
+	server.Close()
+
+	for _, route := range s.router.Routes() {
+		if key := route.Method + " " + route.Path; !s.hits[key] {
+			t.Errorf("route %s was not exercised", key)
+		}
+	}
+}
+
+func TestAuthFailures(t *testing.T) {
+	s := newTestServer(t)
+	_, admin := s.token(t, "", middleware.RoleAdmin)
+	main := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{Code: "MAIN", Name: "Main", Address: "Calle 1"}))
+	other := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{Code: "OTHER", Name: "Other", Address: "Calle 2"}))
+	table := decodeJSON[models.Table](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/tables", admin, models.CreateTableRequest{LocationID: main.ID, Code: "T1", Seats: 4}))
+
+	_, manager := s.token(t, main.ID, middleware.RoleManager)
+	_, foreignManager := s.token(t, other.ID, middleware.RoleManager)
+	_, cashier := s.token(t, main.ID, middleware.RoleCashier)
+	waiterID, waiter := s.token(t, main.ID, middleware.RoleWaiter)
+	_, unassigned := s.token(t, "", middleware.RoleWaiter)
+	assignment := decodeJSON[models.WaiterAssignment](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/waiter-assignments", manager, models.CreateWaiterAssignmentRequest{
+		LocationID: main.ID, WaiterID: waiterID, TableIDs: []string{table.ID},
+		ShiftStart: time.Now().Add(time.Hour), ShiftEnd: time.Now().Add(4 * time.Hour),
+	}))
+	expired := signToken(t, testJWTSecret, middleware.Claims{
+		UserID: "expired", Roles: []string{middleware.RoleAdmin},
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	})
+	forged := signToken(t, "another-secret", middleware.Claims{UserID: "forged", Roles: []string{middleware.RoleAdmin}})
+	tablePath := "/api/venue/tables/" + table.ID
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		body   interface{}
+		status int
+		code   string
+	}{
+		{name: "missing header", method: "GET", path: tablePath, status: http.StatusUnauthorized, code: models.ErrorCodeUnauthorized},
+		{name: "not a bearer token", method: "GET", path: tablePath, auth: "Basic YWRtaW46YWRtaW4=", status: http.StatusUnauthorized, code: models.ErrorCodeUnauthorized},
+		{name: "expired token", method: "GET", path: tablePath, auth: "Bearer " + expired, status: http.StatusUnauthorized, code: models.ErrorCodeUnauthorized},
+		{name: "token signed with another secret", method: "GET", path: tablePath, auth: "Bearer " + forged, status: http.StatusUnauthorized, code: models.ErrorCodeUnauthorized},
+		{name: "admin route without token", method: "GET", path: "/api/venue/admin/export/locations", status: http.StatusUnauthorized, code: models.ErrorCodeUnauthorized},
+		{name: "admin route as manager", method: "GET", path: "/api/venue/admin/export/locations", auth: "Bearer " + manager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "create location as manager", method: "POST", path: "/api/venue/locations", auth: "Bearer " + manager, body: models.CreateLocationRequest{Code: "X", Name: "X", Address: "X"}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "table of another venue", method: "PUT", path: tablePath, auth: "Bearer " + foreignManager, body: models.UpdateTableRequest{Seats: 2}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "create table in another venue", method: "POST", path: "/api/venue/tables", auth: "Bearer " + foreignManager, body: models.CreateTableRequest{LocationID: main.ID, Code: "T2", Seats: 2}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "create table without location", method: "POST", path: "/api/venue/tables", auth: "Bearer " + foreignManager, body: models.CreateTableRequest{Code: "T2", Seats: 2}, status: http.StatusBadRequest, code: models.ErrorCodeValidation},
+		{name: "cashier moving a table", method: "POST", path: tablePath + "/transitions", auth: "Bearer " + cashier, body: models.TableTransitionRequest{Status: models.TableStatusOccupied}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter without venue", method: "POST", path: tablePath + "/transitions", auth: "Bearer " + unassigned, body: models.TableTransitionRequest{Status: models.TableStatusOccupied}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "deleted locations as manager", method: "GET", path: "/api/venue/locations?include_inactive=true", auth: "Bearer " + manager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "deleted tables of another venue", method: "GET", path: "/api/venue/" + main.ID + "/tables?include_inactive=true", auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "inactive locations as manager", method: "GET", path: "/api/venue/locations?is_active=false", auth: "Bearer " + manager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter booking a table", method: "POST", path: "/api/venue/reservations", auth: "Bearer " + waiter, body: models.CreateReservationRequest{LocationID: main.ID, TableID: table.ID, PartyName: "Gómez", Phone: "+573001234567", PartySize: 2, StartsAt: time.Now().Add(time.Hour), EndsAt: time.Now().Add(2 * time.Hour)}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter grouping tables", method: "POST", path: "/api/venue/table-groups", auth: "Bearer " + waiter, body: models.CreateTableGroupRequest{LocationID: main.ID, TableIDs: []string{table.ID}}, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "oversized body", method: "POST", path: "/api/venue/tables", auth: "Bearer " + manager, body: models.CreateTableRequest{LocationID: main.ID, Code: strings.Repeat("T", 1<<20), Seats: 2}, status: http.StatusBadRequest, code: models.ErrorCodeValidation},
+		{name: "reservations of another venue", method: "GET", path: "/api/venue/reservations?location_id=" + main.ID, auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "reservations without venue", method: "GET", path: "/api/venue/reservations", auth: "Bearer " + unassigned, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waitlist of another venue", method: "GET", path: "/api/venue/locations/" + main.ID + "/waitlist", auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "QR code of another venue", method: "GET", path: tablePath + "/qr", auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter assignments of another venue", method: "GET", path: "/api/venue/waiter-assignments?location_id=" + main.ID, auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter assignments without venue", method: "GET", path: "/api/venue/waiter-assignments", auth: "Bearer " + unassigned, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "waiter assignment of another venue", method: "GET", path: "/api/venue/waiter-assignments/" + assignment.ID, auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+		{name: "inactive tables of another venue", method: "GET", path: "/api/venue/" + main.ID + "/tables?is_active=false", auth: "Bearer " + foreignManager, status: http.StatusForbidden, code: models.ErrorCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var headers []string
+			if tt.auth != "" {
+				headers = []string{"Authorization", tt.auth}
+			}
+			w := s.do(t, tt.method, tt.path, "", tt.body, headers...)
+			assertErrorResponse(t, w, tt.status, tt.code)
+		})
+	}
+}
+
+func TestConditionalWrites(t *testing.T) {
+	s := newTestServer(t)
+	_, admin := s.token(t, "", middleware.RoleAdmin)
+	location := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{Code: "MAIN", Name: "Main", Address: "Calle 1"}))
+	table := decodeJSON[models.Table](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/tables", admin, models.CreateTableRequest{LocationID: location.ID, Code: "T1", Seats: 4}))
+
+	// Move both records to version 2 so that "1" is stale
+	s.expect(t, http.StatusOK, "PUT", "/api/venue/locations/"+location.ID, admin, models.UpdateLocationRequest{City: stringPtr("Bogotá")}, "If-Match", "*")
+	s.expect(t, http.StatusOK, "PUT", "/api/venue/tables/"+table.ID, admin, models.UpdateTableRequest{Seats: 6}, "If-Match", "*")
+
+	resources := []struct {
+		name string
+		path string
+		body interface{}
+	}{
+		{name: "location", path: "/api/venue/locations/" + location.ID, body: models.UpdateLocationRequest{Name: "Renamed"}},
+		{name: "table", path: "/api/venue/tables/" + table.ID, body: models.UpdateTableRequest{Seats: 2}},
+	}
+	tests := []struct {
+		name    string
+		method  string
+		ifMatch string
+		status  int
+		code    string
+	}{
+		{name: "update without If-Match", method: "PUT", status: http.StatusPreconditionRequired, code: models.ErrorCodePreconditionRequired},
+		{name: "delete without If-Match", method: "DELETE", status: http.StatusPreconditionRequired, code: models.ErrorCodePreconditionRequired},
+		{name: "malformed If-Match", method: "PUT", ifMatch: "version-2", status: http.StatusBadRequest, code: models.ErrorCodeValidation},
+		{name: "stale update", method: "PUT", ifMatch: `"1"`, status: http.StatusPreconditionFailed, code: models.ErrorCodeVersionConflict},
+		{name: "stale delete", method: "DELETE", ifMatch: `"1"`, status: http.StatusPreconditionFailed, code: models.ErrorCodeVersionConflict},
+		{name: "weak ETag update", method: "PUT", ifMatch: `W/"2"`, status: http.StatusPreconditionFailed, code: models.ErrorCodeVersionConflict},
+		{name: "weak ETag delete", method: "DELETE", ifMatch: `W/"2"`, status: http.StatusPreconditionFailed, code: models.ErrorCodeVersionConflict},
+	}
+
+	for _, resource := range resources {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s %s", resource.name, tt.name), func(t *testing.T) {
+				var headers []string
+				if tt.ifMatch != "" {
+					headers = []string{"If-Match", tt.ifMatch}
+				}
+				var body interface{}
+				if tt.method == "PUT" {
+					body = resource.body
+				}
+				w := s.do(t, tt.method, resource.path, admin, body, headers...)
+				assertErrorResponse(t, w, tt.status, tt.code)
+			})
+		}
+
+		t.Run(resource.name+" current version", func(t *testing.T) {
+			w := s.expect(t, http.StatusOK, "PUT", resource.path, admin, resource.body, "If-Match", `"2"`)
+			if etag := w.Header().Get("ETag"); etag != `"3"` {
+				t.Errorf("ETag = %s, want \"3\"", etag)
+			}
+		})
+	}
+}
+
+// If-Match: * only requires the resource to exist, so concurrent writes made
+// with it all apply instead of failing on each other's version bumps.
+func TestIfMatchAnyVersion(t *testing.T) {
+	s := newTestServer(t)
+	_, admin := s.token(t, "", middleware.RoleAdmin)
+	location := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{Code: "MAIN", Name: "Main", Address: "Calle 1"}))
+	table := decodeJSON[models.Table](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/tables", admin, models.CreateTableRequest{LocationID: location.ID, Code: "T1", Seats: 4}))
+
+	const writers = 8
+	statuses := make([]int, writers)
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := s.do(t, "PUT", "/api/venue/tables/"+table.ID, admin, models.UpdateTableRequest{Seats: i + 1}, "If-Match", "*")
+			statuses[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("writer %d got %d, want 200", i, status)
+		}
+	}
+	updated := decodeJSON[models.Table](t, s.expect(t, http.StatusOK, "GET", "/api/venue/tables/"+table.ID, admin, nil))
+	if updated.Version != table.Version+writers {
+		t.Errorf("version = %d, want %d", updated.Version, table.Version+writers)
+	}
+
+	missing := "/api/venue/tables/" + uuid.NewString()
+	assertErrorResponse(t, s.do(t, "PUT", missing, admin, models.UpdateTableRequest{Seats: 2}, "If-Match", "*"), http.StatusNotFound, models.ErrorCodeNotFound)
+	assertErrorResponse(t, s.do(t, "DELETE", missing, admin, nil, "If-Match", "*"), http.StatusNotFound, models.ErrorCodeNotFound)
+}
+
+func TestListingQueries(t *testing.T) {
+	s := newTestServer(t)
+	_, admin := s.token(t, "", middleware.RoleAdmin)
+	location := decodeJSON[models.Location](t, s.expect(t, http.StatusCreated, "POST", "/api/venue/locations", admin, models.CreateLocationRequest{Code: "MAIN", Name: "Main", Address: "Calle 1"}))
+	for i, seats := range []int{2, 8, 4, 6, 4} {
+		s.expect(t, http.StatusCreated, "POST", "/api/venue/tables", admin, models.CreateTableRequest{LocationID: location.ID, Code: fmt.Sprintf("T%d", i+1), Seats: seats})
+	}
+	tablesPath := "/api/venue/" + location.ID + "/tables"
+
+	t.Run("pages follow next_cursor", func(t *testing.T) {
+		var seats []int
+		path := tablesPath + "?sort=-seats&limit=2&seats_min=4"
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatal("listing did not end")
+			}
+			page := decodeJSON[models.TablePage](t, s.expect(t, http.StatusOK, "GET", path, "", nil))
+			for _, table := range page.Items {
+				seats = append(seats, table.Seats)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			path = tablesPath + "?sort=-seats&limit=2&seats_min=4&cursor=" + *page.NextCursor
+		}
+		if fmt.Sprint(seats) != "[8 6 4 4]" {
+			t.Errorf("listed seats %v, want [8 6 4 4]", seats)
+		}
+	})
+
+	first := decodeJSON[models.TablePage](t, s.expect(t, http.StatusOK, "GET", tablesPath+"?limit=1", "", nil))
+	if first.NextCursor == nil {
+		t.Fatal("first page has no next_cursor")
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "limit not a number", path: tablesPath + "?limit=ten"},
+		{name: "limit too large", path: tablesPath + "?limit=1000"},
+		{name: "unknown sort", path: tablesPath + "?sort=name"},
+		{name: "malformed cursor", path: tablesPath + "?cursor=not-a-cursor"},
+		{name: "cursor of another sort", path: tablesPath + "?sort=seats&cursor=" + *first.NextCursor},
+		{name: "seats_min not a number", path: tablesPath + "?seats_min=many"},
+		{name: "seats range reversed", path: tablesPath + "?seats_min=6&seats_max=2"},
+		{name: "unknown status", path: tablesPath + "?status=broken"},
+		{name: "is_active not a boolean", path: "/api/venue/locations?is_active=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertErrorResponse(t, s.do(t, "GET", tt.path, "", nil), http.StatusBadRequest, models.ErrorCodeValidation)
+		})
+	}
+}
+
+func stringPtr(s string) *string { return &s }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func intPtr(i int) *int { return &i }
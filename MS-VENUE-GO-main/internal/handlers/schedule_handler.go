@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Schedule handlers
+func (h *VenueHandler) GetLocationSchedule(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	schedule, err := h.venueService.GetLocationSchedule(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *VenueHandler) SetOpeningHours(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.SetOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	schedule, err := h.venueService.SetOpeningHours(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *VenueHandler) CreateScheduleException(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.CreateScheduleExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	exception, err := h.venueService.CreateScheduleException(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, exception)
+}
+
+func (h *VenueHandler) DeleteScheduleException(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Schedule exception ID is required")
+		return
+	}
+
+	err := h.venueService.DeleteScheduleException(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule exception deleted successfully"})
+}
+
+func (h *VenueHandler) IsLocationOpen(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	at := time.Now()
+	if atStr := c.Query("at"); atStr != "" {
+		parsed, err := time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			respondValidationError(c, "at must be an RFC3339 timestamp")
+			return
+		}
+		at = parsed
+	}
+
+	status, err := h.venueService.GetOpenStatus(c.Request.Context(), locationID, at)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
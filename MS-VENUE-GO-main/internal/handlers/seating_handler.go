@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Seating handlers
+func (h *VenueHandler) SuggestSeating(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.SeatingSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	suggestions, err := h.venueService.SuggestSeating(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
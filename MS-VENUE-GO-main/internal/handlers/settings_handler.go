@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Settings handlers
+func (h *VenueHandler) GetLocationSettings(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	settings, err := h.venueService.GetLocationSettings(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *VenueHandler) UpdateLocationSettings(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.UpdateLocationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	settings, err := h.venueService.UpdateLocationSettings(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tableStreamHeartbeat keeps idle streams from being closed by proxies.
+const tableStreamHeartbeat = 25 * time.Second
+
+// Table event handlers
+
+// StreamTableEvents is a Server-Sent Events stream of a location's tables.
+// It starts with a snapshot event holding every table, followed by
+// table_created, table_updated, table_status_changed and table_deleted
+// events. On a resync event clients should reload the tables.
+func (h *VenueHandler) StreamTableEvents(c *gin.Context) {
+	locationID := c.Param("locationId")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	// Subscribe before loading the snapshot so no change falls in between
+	events, unsubscribe := h.venueService.SubscribeTableEvents(locationID)
+	defer unsubscribe()
+
+	tables, err := h.venueService.GetTablesByLocation(c.Request.Context(), locationID, nil)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("snapshot", tables)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(tableStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				// Dropped for falling behind; the client reconnects and reloads
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			io.WriteString(w, ": heartbeat\n\n")
+			return true
+		}
+	})
+}
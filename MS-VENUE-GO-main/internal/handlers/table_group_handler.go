@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Table group handlers
+func (h *VenueHandler) CreateTableGroup(c *gin.Context) {
+	var req models.CreateTableGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	group, err := h.venueService.CreateTableGroup(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (h *VenueHandler) GetTableGroupsByLocation(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	groups, err := h.venueService.GetTableGroupsByLocation(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (h *VenueHandler) GetTableGroupByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table group ID is required")
+		return
+	}
+
+	group, err := h.venueService.GetTableGroupByID(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (h *VenueHandler) TransitionTableGroup(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table group ID is required")
+		return
+	}
+
+	var req models.TableTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	group, err := h.venueService.TransitionTableGroup(c.Request.Context(), id, &req, userID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (h *VenueHandler) SplitTableGroup(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table group ID is required")
+		return
+	}
+
+	err := h.venueService.SplitTableGroup(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Table group split successfully"})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/qrcode"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultQRSize = 512
+	maxQRSize     = 2048
+)
+
+// Table QR handlers
+
+// GetTableQRCode renders the table's sticker. format is png (default), svg
+// or json; size is the image side in pixels.
+func (h *VenueHandler) GetTableQRCode(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	size := defaultQRSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		parsed, err := strconv.Atoi(sizeStr)
+		if err != nil || parsed <= 0 || parsed > maxQRSize {
+			respondValidationError(c, "size must be a number of pixels between 1 and 2048")
+			return
+		}
+		size = parsed
+	}
+
+	format := c.DefaultQuery("format", "png")
+	if format != "png" && format != "svg" && format != "json" {
+		respondValidationError(c, "format must be png, svg or json")
+		return
+	}
+
+	qr, err := h.venueService.GetTableQRCode(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, qr)
+		return
+	}
+	h.renderTableQRCode(c, qr, format, size)
+}
+
+func (h *VenueHandler) RotateTableQRCode(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	qr, err := h.venueService.RotateTableQRCode(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, qr)
+}
+
+func (h *VenueHandler) ResolveTableToken(c *gin.Context) {
+	token := c.Param("token")
+	if token == "" {
+		respondValidationError(c, "Token is required")
+		return
+	}
+
+	resolution, err := h.venueService.ResolveTableToken(c.Request.Context(), token)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resolution)
+}
+
+func (h *VenueHandler) renderTableQRCode(c *gin.Context, qr *models.TableQRCode, format string, size int) {
+	code, err := qrcode.Encode([]byte(qr.URL))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	if format == "svg" {
+		c.Data(http.StatusOK, "image/svg+xml", []byte(code.SVG(size)))
+		return
+	}
+
+	// Whole pixels per module keep the modules sharp
+	scale := size / (code.Size + 2*qrcode.QuietZone)
+	if scale < 1 {
+		scale = 1
+	}
+	image, err := code.PNG(scale)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "image/png", image)
+}
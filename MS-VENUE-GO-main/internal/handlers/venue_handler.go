@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"ms-venue-go/internal/middleware"
 	"ms-venue-go/internal/models"
 	"ms-venue-go/internal/service"
 
@@ -23,23 +24,42 @@ func NewVenueHandler(venueService service.VenueService) *VenueHandler {
 func (h *VenueHandler) CreateLocation(c *gin.Context) {
 	var req models.CreateLocationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondValidationError(c, err.Error())
 		return
 	}
 
-	location, err := h.venueService.CreateLocation(&req)
+	location, err := h.venueService.CreateLocation(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, location)
 }
 
+// GetAllLocations lists one page of locations. Deleted locations, through
+// include_inactive=true or is_active=false, are only listed to admins.
 func (h *VenueHandler) GetAllLocations(c *gin.Context) {
-	locations, err := h.venueService.GetAllLocations()
+	filter := &models.LocationFilter{CodePrefix: c.Query("code_prefix")}
+	if !queryBool(c, "is_active", &filter.IsActive) {
+		return
+	}
+	filter.IncludeInactive = c.Query("include_inactive") == "true"
+	if filter.IncludeInactive || (filter.IsActive != nil && !*filter.IsActive) {
+		if !canViewInactive(c, "") {
+			respondError(c, http.StatusForbidden, models.ErrorCodeForbidden, "Only admins can list deleted locations")
+			return
+		}
+	}
+
+	page, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+
+	locations, err := h.venueService.ListLocations(c.Request.Context(), filter, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
@@ -49,51 +69,63 @@ func (h *VenueHandler) GetAllLocations(c *gin.Context) {
 func (h *VenueHandler) GetLocationByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Location ID is required"})
+		respondValidationError(c, "Location ID is required")
 		return
 	}
 
-	location, err := h.venueService.GetLocationByID(id)
+	location, err := h.venueService.GetLocationByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Location not found"})
+		respondServiceError(c, err)
 		return
 	}
 
+	setETag(c, location.Version)
 	c.JSON(http.StatusOK, location)
 }
 
 func (h *VenueHandler) UpdateLocation(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Location ID is required"})
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
 	var req models.UpdateLocationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondValidationError(c, err.Error())
 		return
 	}
 
-	location, err := h.venueService.UpdateLocation(id, &req)
+	location, err := h.venueService.UpdateLocation(c.Request.Context(), id, &req, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
+	setETag(c, location.Version)
 	c.JSON(http.StatusOK, location)
 }
 
 func (h *VenueHandler) DeleteLocation(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Location ID is required"})
+		respondValidationError(c, "Location ID is required")
 		return
 	}
 
-	err := h.venueService.DeleteLocation(id)
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	err := h.venueService.DeleteLocation(c.Request.Context(), id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
@@ -104,29 +136,56 @@ func (h *VenueHandler) DeleteLocation(c *gin.Context) {
 func (h *VenueHandler) CreateTable(c *gin.Context) {
 	var req models.CreateTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondValidationError(c, err.Error())
 		return
 	}
 
-	table, err := h.venueService.CreateTable(&req)
+	table, err := h.venueService.CreateTable(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, table)
 }
 
+// GetTablesByLocation lists one page of a location's tables. Deleted tables,
+// through include_inactive=true or is_active=false, are only listed to admins
+// and the venue's managers.
 func (h *VenueHandler) GetTablesByLocation(c *gin.Context) {
 	locationID := c.Param("locationId")
 	if locationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Location ID is required"})
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	filter := &models.TableFilter{CodePrefix: c.Query("code_prefix")}
+	if zoneID := c.Query("zone_id"); zoneID != "" {
+		filter.ZoneID = &zoneID
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if !queryInt(c, "seats_min", &filter.SeatsMin) || !queryInt(c, "seats_max", &filter.SeatsMax) ||
+		!queryBool(c, "is_active", &filter.IsActive) {
+		return
+	}
+	filter.IncludeInactive = c.Query("include_inactive") == "true"
+	if filter.IncludeInactive || (filter.IsActive != nil && !*filter.IsActive) {
+		if !canViewInactive(c, locationID) {
+			respondError(c, http.StatusForbidden, models.ErrorCodeForbidden, "Only admins and the venue's managers can list deleted tables")
+			return
+		}
+	}
+
+	page, ok := parsePageRequest(c)
+	if !ok {
 		return
 	}
 
-	tables, err := h.venueService.GetTablesByLocation(locationID)
+	tables, err := h.venueService.ListTables(c.Request.Context(), locationID, filter, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
@@ -136,57 +195,110 @@ func (h *VenueHandler) GetTablesByLocation(c *gin.Context) {
 func (h *VenueHandler) GetTableByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Table ID is required"})
+		respondValidationError(c, "Table ID is required")
 		return
 	}
 
-	table, err := h.venueService.GetTableByID(id)
+	table, err := h.venueService.GetTableByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		respondServiceError(c, err)
 		return
 	}
 
+	setETag(c, table.Version)
 	c.JSON(http.StatusOK, table)
 }
 
 func (h *VenueHandler) UpdateTable(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Table ID is required"})
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
 	var req models.UpdateTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondValidationError(c, err.Error())
 		return
 	}
 
-	table, err := h.venueService.UpdateTable(id, &req)
+	table, err := h.venueService.UpdateTable(c.Request.Context(), id, &req, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
+	setETag(c, table.Version)
 	c.JSON(http.StatusOK, table)
 }
 
 func (h *VenueHandler) DeleteTable(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Table ID is required"})
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
-	err := h.venueService.DeleteTable(id)
+	err := h.venueService.DeleteTable(c.Request.Context(), id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Table deleted successfully"})
 }
 
+// Table status handlers
+func (h *VenueHandler) TransitionTable(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	var req models.TableTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	table, err := h.venueService.TransitionTable(c.Request.Context(), id, &req, userID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+func (h *VenueHandler) GetTableStatusHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Table ID is required")
+		return
+	}
+
+	history, err := h.venueService.GetTableStatusHistory(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 // Health check
 func (h *VenueHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
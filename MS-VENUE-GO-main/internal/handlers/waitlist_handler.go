@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/middleware"
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Waitlist handlers
+func (h *VenueHandler) JoinWaitlist(c *gin.Context) {
+	var req models.CreateWaitlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	entry, err := h.venueService.JoinWaitlist(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *VenueHandler) GetWaitlist(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	entries, err := h.venueService.GetWaitlist(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *VenueHandler) ReorderWaitlist(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	var req models.ReorderWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	entries, err := h.venueService.ReorderWaitlist(c.Request.Context(), locationID, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *VenueHandler) GetWaitlistEntry(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Waitlist entry ID is required")
+		return
+	}
+
+	entry, err := h.venueService.GetWaitlistEntry(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *VenueHandler) NotifyWaitlistEntry(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Waitlist entry ID is required")
+		return
+	}
+
+	entry, err := h.venueService.NotifyWaitlistEntry(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *VenueHandler) SeatWaitlistEntry(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Waitlist entry ID is required")
+		return
+	}
+
+	var req models.SeatWaitlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	entry, err := h.venueService.SeatWaitlistEntry(c.Request.Context(), id, &req, userID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *VenueHandler) CancelWaitlistEntry(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Waitlist entry ID is required")
+		return
+	}
+
+	entry, err := h.venueService.CancelWaitlistEntry(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
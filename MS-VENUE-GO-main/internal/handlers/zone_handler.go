@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Zone handlers
+func (h *VenueHandler) CreateZone(c *gin.Context) {
+	var req models.CreateZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	zone, err := h.venueService.CreateZone(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+func (h *VenueHandler) GetZonesByLocation(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	zones, err := h.venueService.GetZonesByLocation(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+func (h *VenueHandler) GetZoneByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Zone ID is required")
+		return
+	}
+
+	zone, err := h.venueService.GetZoneByID(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+func (h *VenueHandler) UpdateZone(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Zone ID is required")
+		return
+	}
+
+	var req models.UpdateZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err.Error())
+		return
+	}
+
+	zone, err := h.venueService.UpdateZone(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+func (h *VenueHandler) DeleteZone(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondValidationError(c, "Zone ID is required")
+		return
+	}
+
+	err := h.venueService.DeleteZone(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Zone deleted successfully"})
+}
+
+func (h *VenueHandler) GetOccupancyByZone(c *gin.Context) {
+	locationID := c.Param("id")
+	if locationID == "" {
+		respondValidationError(c, "Location ID is required")
+		return
+	}
+
+	occupancy, err := h.venueService.GetOccupancyByZone(c.Request.Context(), locationID)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, occupancy)
+}
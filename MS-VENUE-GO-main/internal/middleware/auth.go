@@ -5,14 +5,25 @@ import (
 	"net/http"
 	"strings"
 
+	"ms-venue-go/internal/models"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Role codes issued by MS-AUTH-GO
+const (
+	RoleAdmin   = "admin"
+	RoleManager = "manager"
+	RoleCashier = "cashier"
+	RoleWaiter  = "waiter"
+)
+
 type Claims struct {
-	UserID string   `json:"user_id"`
-	Email  string   `json:"email"`
-	Roles  []string `json:"roles"`
+	UserID  string   `json:"user_id"`
+	Email   string   `json:"email"`
+	VenueID string   `json:"venue_id"` // empty for staff not tied to a location
+	Roles   []string `json:"roles"`
 	jwt.RegisteredClaims
 }
 
@@ -54,21 +65,21 @@ func RequireAuth(authService AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Authorization header required"})
 			c.Abort()
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Bearer token required"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Bearer token required"})
 			c.Abort()
 			return
 		}
 
 		claims, err := authService.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Invalid token"})
 			c.Abort()
 			return
 		}
@@ -76,6 +87,7 @@ func RequireAuth(authService AuthService) gin.HandlerFunc {
 		// Add user info to context
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("venue_id", claims.VenueID)
 		c.Set("roles", claims.Roles)
 
 		c.Next()
@@ -87,36 +99,28 @@ func RequireRole(authService AuthService, requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Authorization header required"})
 			c.Abort()
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Bearer token required"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Bearer token required"})
 			c.Abort()
 			return
 		}
 
 		claims, err := authService.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{Code: models.ErrorCodeUnauthorized, Error: "Invalid token"})
 			c.Abort()
 			return
 		}
 
 		// Check if user has required role
-		hasRole := false
-		for _, role := range claims.Roles {
-			if role == requiredRole {
-				hasRole = true
-				break
-			}
-		}
-
-		if !hasRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		if !hasAnyRole(claims.Roles, requiredRole) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{Code: models.ErrorCodeForbidden, Error: "Insufficient permissions"})
 			c.Abort()
 			return
 		}
@@ -124,6 +128,7 @@ func RequireRole(authService AuthService, requiredRole string) gin.HandlerFunc {
 		// Add user info to context
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("venue_id", claims.VenueID)
 		c.Set("roles", claims.Roles)
 
 		c.Next()
@@ -154,8 +159,60 @@ func OptionalAuth(authService AuthService) gin.HandlerFunc {
 		// Add user info to context if token is valid
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("venue_id", claims.VenueID)
 		c.Set("roles", claims.Roles)
 
 		c.Next()
 	}
 }
+
+// GetUserIDFromContext returns the user_id stored by the auth middlewares
+func GetUserIDFromContext(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return "", false
+	}
+
+	userIDStr, ok := userID.(string)
+	return userIDStr, ok
+}
+
+// RequireAnyRole middleware must run after RequireAuth; it lets the request
+// through when the caller has at least one of the roles.
+func RequireAnyRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasAnyRole(c, roles...) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{Code: models.ErrorCodeForbidden, Error: "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetVenueIDFromContext returns the venue_id stored by the auth middlewares;
+// it is empty for staff not tied to a location
+func GetVenueIDFromContext(c *gin.Context) string {
+	venueID, _ := c.Get("venue_id")
+	venueIDStr, _ := venueID.(string)
+	return venueIDStr
+}
+
+// HasAnyRole reports whether the caller has at least one of the roles
+func HasAnyRole(c *gin.Context, roles ...string) bool {
+	value, _ := c.Get("roles")
+	callerRoles, _ := value.([]string)
+	return hasAnyRole(callerRoles, roles...)
+}
+
+func hasAnyRole(callerRoles []string, roles ...string) bool {
+	for _, callerRole := range callerRoles {
+		for _, role := range roles {
+			if callerRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+-- Falla mientras bar_system.users conserve la referencia a las sedes
+-- (0002_users_venue_reference de MS-AUTH-GO); revertirla primero.
+
+DROP VIEW IF EXISTS bar_system.v_tables_full;
+DROP TABLE IF EXISTS bar_system.tables;
+DROP TABLE IF EXISTS bar_system.locations;
+DROP TYPE IF EXISTS bar_system.table_status;
@@ -0,0 +1,88 @@
+-- ================================================
+-- MS-VENUE-GO: Sedes y mesas
+-- ================================================
+-- Idempotente para adoptar bases creadas a mano con init.sql.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;   -- gen_random_uuid()
+
+CREATE SCHEMA IF NOT EXISTS bar_system;
+
+-- Compartida por todos los servicios; MS-AUTH-GO la crea primero
+CREATE OR REPLACE FUNCTION bar_system.update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$ BEGIN
+    CREATE TYPE bar_system.table_status AS ENUM ('available', 'occupied', 'reserved');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Sedes o sucursales del bar
+CREATE TABLE IF NOT EXISTS bar_system.locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    phone VARCHAR(20),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mesas de cada sede
+CREATE TABLE IF NOT EXISTS bar_system.tables (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    seats INTEGER NOT NULL CHECK (seats > 0),
+    status bar_system.table_status NOT NULL DEFAULT 'available',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (location_id, code),
+    CONSTRAINT fk_tables_location FOREIGN KEY (location_id)
+        REFERENCES bar_system.locations (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_locations_code ON bar_system.locations(code);
+CREATE INDEX IF NOT EXISTS idx_locations_is_active ON bar_system.locations(is_active);
+
+CREATE INDEX IF NOT EXISTS idx_tables_location_id ON bar_system.tables(location_id);
+CREATE INDEX IF NOT EXISTS idx_tables_status ON bar_system.tables(status);
+CREATE INDEX IF NOT EXISTS idx_tables_is_active ON bar_system.tables(is_active);
+
+CREATE OR REPLACE TRIGGER trg_locations_updated_at
+    BEFORE UPDATE ON bar_system.locations
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+CREATE OR REPLACE TRIGGER trg_tables_updated_at
+    BEFORE UPDATE ON bar_system.tables
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+-- Vista: Mesas con información de sede
+CREATE OR REPLACE VIEW bar_system.v_tables_full AS
+SELECT
+    t.id,
+    t.code AS table_code,
+    t.seats,
+    t.status,
+    t.is_active AS table_active,
+    t.created_at,
+    t.updated_at,
+    l.id AS location_id,
+    l.code AS location_code,
+    l.name AS location_name,
+    l.address AS location_address,
+    l.phone AS location_phone,
+    l.is_active AS location_active
+FROM bar_system.tables t
+INNER JOIN bar_system.locations l ON t.location_id = l.id;
+
+COMMENT ON TABLE bar_system.locations IS 'Sedes o sucursales del bar';
+COMMENT ON TABLE bar_system.tables IS 'Mesas disponibles en cada sede';
+COMMENT ON VIEW bar_system.v_tables_full IS 'Vista de mesas con información completa de sede';
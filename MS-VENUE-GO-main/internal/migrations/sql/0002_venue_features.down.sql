@@ -0,0 +1,49 @@
+-- El valor needs_cleaning de table_status no se puede quitar de un ENUM;
+-- se conserva al revertir.
+
+DROP TRIGGER IF EXISTS trg_tables_version ON bar_system.tables;
+DROP TRIGGER IF EXISTS trg_locations_version ON bar_system.locations;
+DROP FUNCTION IF EXISTS bar_system.bump_version();
+
+DROP TRIGGER IF EXISTS trg_tables_notify ON bar_system.tables;
+DROP FUNCTION IF EXISTS bar_system.notify_table_event();
+
+DROP TABLE IF EXISTS bar_system.waiter_assignment_tables;
+DROP TABLE IF EXISTS bar_system.waiter_assignments;
+DROP TABLE IF EXISTS bar_system.location_settings;
+DROP TABLE IF EXISTS bar_system.location_schedule_exceptions;
+DROP TABLE IF EXISTS bar_system.location_hours;
+DROP TABLE IF EXISTS bar_system.waitlist_entries;
+DROP TYPE IF EXISTS bar_system.waitlist_status;
+DROP TABLE IF EXISTS bar_system.table_status_history;
+DROP TABLE IF EXISTS bar_system.reservations;
+DROP TYPE IF EXISTS bar_system.reservation_status;
+
+ALTER TABLE bar_system.tables
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS qr_rotated_at,
+    DROP COLUMN IF EXISTS qr_token,
+    DROP COLUMN IF EXISTS group_id,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS shape,
+    DROP COLUMN IF EXISTS rotation,
+    DROP COLUMN IF EXISTS pos_y,
+    DROP COLUMN IF EXISTS pos_x,
+    DROP COLUMN IF EXISTS zone_id;
+
+ALTER TABLE bar_system.locations
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS manager_id,
+    DROP COLUMN IF EXISTS max_capacity,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS canvas_height,
+    DROP COLUMN IF EXISTS canvas_width;
+
+DROP TABLE IF EXISTS bar_system.table_groups;
+DROP TABLE IF EXISTS bar_system.zones;
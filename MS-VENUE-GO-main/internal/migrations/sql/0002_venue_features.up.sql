@@ -0,0 +1,365 @@
+-- ================================================
+-- MS-VENUE-GO: Reservas, zonas, plano, horarios y demás funciones de sede
+-- ================================================
+-- Idempotente para adoptar bases creadas a mano antes de las migraciones.
+-- Referencia bar_system.users, por lo que MS-AUTH-GO debe migrar antes.
+
+-- ================================================
+-- RESERVAS DE MESAS
+-- ================================================
+
+DO $$ BEGIN
+    CREATE TYPE bar_system.reservation_status AS ENUM ('booked', 'seated', 'no_show', 'cancelled');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS bar_system.reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    table_id UUID NOT NULL REFERENCES bar_system.tables(id) ON DELETE CASCADE,
+    party_name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    party_size INTEGER NOT NULL CHECK (party_size > 0),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status bar_system.reservation_status NOT NULL DEFAULT 'booked',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_location_id ON bar_system.reservations(location_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_table_window ON bar_system.reservations(table_id, starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_status ON bar_system.reservations(status);
+
+DROP TRIGGER IF EXISTS trg_reservations_updated_at ON bar_system.reservations;
+CREATE TRIGGER trg_reservations_updated_at
+    BEFORE UPDATE ON bar_system.reservations
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+COMMENT ON TABLE bar_system.reservations IS 'Reservas de mesas por franja horaria';
+
+-- ================================================
+-- CICLO DE VIDA DE MESAS E HISTORIAL DE ESTADOS
+-- ================================================
+
+ALTER TYPE bar_system.table_status ADD VALUE IF NOT EXISTS 'needs_cleaning';
+
+CREATE TABLE IF NOT EXISTS bar_system.table_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    table_id UUID NOT NULL REFERENCES bar_system.tables(id) ON DELETE CASCADE,
+    from_status bar_system.table_status NOT NULL,
+    to_status bar_system.table_status NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_by UUID REFERENCES bar_system.users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_table_status_history_table_id ON bar_system.table_status_history(table_id, changed_at DESC);
+
+COMMENT ON TABLE bar_system.table_status_history IS 'Historial de cambios de estado de mesas (quién, cuándo y por qué)';
+
+-- ================================================
+-- ZONAS DENTRO DE CADA SEDE
+-- ================================================
+
+CREATE TABLE IF NOT EXISTS bar_system.zones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    is_smoking BOOLEAN NOT NULL DEFAULT FALSE,
+    environment VARCHAR(10) NOT NULL DEFAULT 'indoor' CHECK (environment IN ('indoor', 'outdoor')),
+    sort_order INTEGER NOT NULL DEFAULT 0 CHECK (sort_order >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (location_id, name)
+);
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS zone_id UUID REFERENCES bar_system.zones(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_zones_location_id ON bar_system.zones(location_id);
+CREATE INDEX IF NOT EXISTS idx_tables_zone_id ON bar_system.tables(zone_id);
+
+DROP TRIGGER IF EXISTS trg_zones_updated_at ON bar_system.zones;
+CREATE TRIGGER trg_zones_updated_at
+    BEFORE UPDATE ON bar_system.zones
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+COMMENT ON TABLE bar_system.zones IS 'Zonas o secciones de cada sede (terraza, salón, VIP, barra)';
+
+-- ================================================
+-- PLANO DE SALA (GEOMETRÍA DE MESAS)
+-- ================================================
+-- Coordenadas en unidades de lienzo; (pos_x, pos_y) es el centro de la mesa
+-- y rotation se expresa en grados en sentido horario.
+
+ALTER TABLE bar_system.locations
+    ADD COLUMN IF NOT EXISTS canvas_width NUMERIC(10, 2) NOT NULL DEFAULT 1000 CHECK (canvas_width > 0),
+    ADD COLUMN IF NOT EXISTS canvas_height NUMERIC(10, 2) NOT NULL DEFAULT 800 CHECK (canvas_height > 0);
+
+ALTER TABLE bar_system.zones
+    ADD COLUMN IF NOT EXISTS canvas_width NUMERIC(10, 2) CHECK (canvas_width > 0),
+    ADD COLUMN IF NOT EXISTS canvas_height NUMERIC(10, 2) CHECK (canvas_height > 0);
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS pos_x NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS pos_y NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rotation NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (rotation >= 0 AND rotation < 360),
+    ADD COLUMN IF NOT EXISTS shape VARCHAR(20) NOT NULL DEFAULT 'square' CHECK (shape IN ('round', 'square', 'rectangle', 'bar_stool')),
+    ADD COLUMN IF NOT EXISTS width NUMERIC(10, 2) NOT NULL DEFAULT 60 CHECK (width > 0),
+    ADD COLUMN IF NOT EXISTS height NUMERIC(10, 2) NOT NULL DEFAULT 60 CHECK (height > 0);
+
+-- ================================================
+-- GRUPOS DE MESAS (MESAS UNIDAS)
+-- ================================================
+
+CREATE TABLE IF NOT EXISTS bar_system.table_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    code VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    dissolved_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES bar_system.table_groups(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_table_groups_location_active ON bar_system.table_groups(location_id) WHERE dissolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tables_group_id ON bar_system.tables(group_id);
+
+COMMENT ON TABLE bar_system.table_groups IS 'Mesas unidas temporalmente para grupos grandes';
+
+-- ================================================
+-- LISTA DE ESPERA (WALK-INS)
+-- ================================================
+
+DO $$ BEGIN
+    CREATE TYPE bar_system.waitlist_status AS ENUM ('waiting', 'notified', 'seated', 'cancelled');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS bar_system.waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    party_name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    party_size INTEGER NOT NULL CHECK (party_size > 0),
+    position INTEGER NOT NULL CHECK (position > 0),
+    quoted_wait_minutes INTEGER NOT NULL DEFAULT 0 CHECK (quoted_wait_minutes >= 0),
+    status bar_system.waitlist_status NOT NULL DEFAULT 'waiting',
+    table_id UUID REFERENCES bar_system.tables(id) ON DELETE SET NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    seated_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_location_active ON bar_system.waitlist_entries(location_id, position) WHERE status IN ('waiting', 'notified');
+
+DROP TRIGGER IF EXISTS trg_waitlist_entries_updated_at ON bar_system.waitlist_entries;
+CREATE TRIGGER trg_waitlist_entries_updated_at
+    BEFORE UPDATE ON bar_system.waitlist_entries
+    FOR EACH ROW EXECUTE FUNCTION bar_system.update_updated_at_column();
+
+COMMENT ON TABLE bar_system.waitlist_entries IS 'Lista de espera de clientes sin reserva por sede';
+
+-- ================================================
+-- HORARIOS DE ATENCIÓN, FESTIVOS Y CIERRES
+-- ================================================
+-- Un rango cuyo cierre no es posterior a la apertura termina al día
+-- siguiente (p. ej. 18:00-03:00). day_of_week: 0 = domingo ... 6 = sábado.
+
+CREATE TABLE IF NOT EXISTS bar_system.location_hours (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_hours_location_id ON bar_system.location_hours(location_id, day_of_week);
+
+CREATE TABLE IF NOT EXISTS bar_system.location_schedule_exceptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    is_closed BOOLEAN NOT NULL DEFAULT false,
+    opens_at TIME,
+    closes_at TIME,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date),
+    CHECK (is_closed OR (opens_at IS NOT NULL AND closes_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_exceptions_location_dates ON bar_system.location_schedule_exceptions(location_id, start_date, end_date);
+
+COMMENT ON TABLE bar_system.location_hours IS 'Horario semanal de atención por sede';
+COMMENT ON TABLE bar_system.location_schedule_exceptions IS 'Días especiales, festivos y cierres por sede';
+
+-- ================================================
+-- CONFIGURACIÓN POR SEDE (ZONA HORARIA, MONEDA, IMPUESTOS)
+-- ================================================
+-- Las tasas son porcentajes (19 = 19 %). Las sedes sin fila usan los
+-- valores por defecto del servicio (America/Bogota, COP, IVA 19 %).
+
+CREATE TABLE IF NOT EXISTS bar_system.location_settings (
+    location_id UUID PRIMARY KEY REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Bogota',
+    currency_code CHAR(3) NOT NULL DEFAULT 'COP',
+    tax_rate NUMERIC(5, 2) NOT NULL DEFAULT 19 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+    tax_profiles JSONB NOT NULL DEFAULT '[]',
+    service_charge_percent NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (service_charge_percent >= 0 AND service_charge_percent <= 100),
+    receipt_header TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE bar_system.location_settings IS 'Reglas de negocio por sede: zona horaria, moneda, impuestos y recibos';
+
+-- ================================================
+-- PERFIL DE SEDE (CONTACTO, UBICACIÓN Y CAPACIDAD)
+-- ================================================
+-- phone ya existe desde 0001; se guarda en formato E.164.
+
+ALTER TABLE bar_system.locations
+    ADD COLUMN IF NOT EXISTS city VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN IF NOT EXISTS max_capacity INTEGER CHECK (max_capacity > 0),
+    ADD COLUMN IF NOT EXISTS manager_id UUID REFERENCES bar_system.users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_locations_manager_id ON bar_system.locations(manager_id);
+
+-- ================================================
+-- CÓDIGOS QR DE MESA
+-- ================================================
+-- qr_token es un nonce aleatorio firmado con HMAC; rotarlo invalida las
+-- etiquetas impresas anteriormente.
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS qr_token VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS qr_rotated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tables_qr_token ON bar_system.tables(qr_token) WHERE qr_token IS NOT NULL;
+
+-- ================================================
+-- ASIGNACIÓN DE MESEROS POR TURNO
+-- ================================================
+-- Cada asignación cubre una zona completa o una lista de mesas. El servicio
+-- rechaza asignaciones con turnos solapados que cubran la misma mesa.
+
+CREATE TABLE IF NOT EXISTS bar_system.waiter_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES bar_system.locations(id) ON DELETE CASCADE,
+    waiter_id UUID NOT NULL REFERENCES bar_system.users(id) ON DELETE CASCADE,
+    zone_id UUID REFERENCES bar_system.zones(id) ON DELETE CASCADE,
+    shift_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    shift_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    assigned_by UUID REFERENCES bar_system.users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    removed_at TIMESTAMP WITH TIME ZONE,
+    CHECK (shift_end > shift_start)
+);
+
+CREATE TABLE IF NOT EXISTS bar_system.waiter_assignment_tables (
+    assignment_id UUID NOT NULL REFERENCES bar_system.waiter_assignments(id) ON DELETE CASCADE,
+    table_id UUID NOT NULL REFERENCES bar_system.tables(id) ON DELETE CASCADE,
+    PRIMARY KEY (assignment_id, table_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waiter_assignments_location_shift ON bar_system.waiter_assignments(location_id, shift_start, shift_end) WHERE removed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_waiter_assignments_waiter_shift ON bar_system.waiter_assignments(waiter_id, shift_start, shift_end) WHERE removed_at IS NULL;
+
+COMMENT ON TABLE bar_system.waiter_assignments IS 'Zonas o mesas a cargo de cada mesero por turno';
+
+-- ================================================
+-- EVENTOS DE MESAS EN TIEMPO REAL
+-- ================================================
+-- Cada escritura en tables se anuncia por NOTIFY en el canal
+-- venue_table_events; todas las réplicas de MS-VENUE-GO lo escuchan y lo
+-- reenvían a sus clientes SSE.
+
+CREATE OR REPLACE FUNCTION bar_system.notify_table_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_type TEXT;
+    previous_status TEXT := '';
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        event_type := 'table_created';
+    ELSIF OLD.is_active AND NOT NEW.is_active THEN
+        event_type := 'table_deleted';
+    ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+        event_type := 'table_status_changed';
+        previous_status := OLD.status::text;
+    ELSE
+        event_type := 'table_updated';
+    END IF;
+
+    PERFORM pg_notify('venue_table_events', json_build_object(
+        'type', event_type,
+        'location_id', NEW.location_id,
+        'table_id', NEW.id,
+        'previous_status', previous_status
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_tables_notify ON bar_system.tables;
+CREATE TRIGGER trg_tables_notify
+    AFTER INSERT OR UPDATE ON bar_system.tables
+    FOR EACH ROW EXECUTE FUNCTION bar_system.notify_table_event();
+
+-- ================================================
+-- RESTAURACIÓN DE SEDES Y MESAS ELIMINADAS
+-- ================================================
+-- Al eliminar una sede, sus mesas activas reciben el mismo deleted_at; al
+-- restaurarla solo vuelven esas mesas.
+
+ALTER TABLE bar_system.locations
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE bar_system.locations SET deleted_at = updated_at WHERE is_active = false AND deleted_at IS NULL;
+UPDATE bar_system.tables SET deleted_at = updated_at WHERE is_active = false AND deleted_at IS NULL;
+
+-- ================================================
+-- CONCURRENCIA OPTIMISTA (ETAG / IF-MATCH)
+-- ================================================
+-- version sube en cada UPDATE; la API la expone como ETag y exige If-Match
+-- en PUT/DELETE para no pisar cambios de otro usuario.
+
+ALTER TABLE bar_system.locations
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE bar_system.tables
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION bar_system.bump_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_locations_version ON bar_system.locations;
+CREATE TRIGGER trg_locations_version
+    BEFORE UPDATE ON bar_system.locations
+    FOR EACH ROW EXECUTE FUNCTION bar_system.bump_version();
+
+DROP TRIGGER IF EXISTS trg_tables_version ON bar_system.tables;
+CREATE TRIGGER trg_tables_version
+    BEFORE UPDATE ON bar_system.tables
+    FOR EACH ROW EXECUTE FUNCTION bar_system.bump_version();
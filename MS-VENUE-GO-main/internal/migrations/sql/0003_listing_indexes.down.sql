@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS bar_system.idx_tables_location_status;
+DROP INDEX IF EXISTS bar_system.idx_tables_location_code_prefix;
+DROP INDEX IF EXISTS bar_system.idx_tables_location_created_at_id;
+DROP INDEX IF EXISTS bar_system.idx_tables_location_seats_id;
+DROP INDEX IF EXISTS bar_system.idx_locations_code_prefix;
+DROP INDEX IF EXISTS bar_system.idx_locations_name_id;
+DROP INDEX IF EXISTS bar_system.idx_locations_created_at_id;
@@ -0,0 +1,16 @@
+-- ================================================
+-- MS-VENUE-GO: Índices de los listados paginados de sedes y mesas
+-- ================================================
+-- Los listados se ordenan por la columna pedida y luego por id, y cada página
+-- continúa con (columna, id) > (valor, id) del último registro. Los índices
+-- con text_pattern_ops resuelven los filtros por prefijo de código (LIKE 'x%').
+
+CREATE INDEX IF NOT EXISTS idx_locations_created_at_id ON bar_system.locations(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_locations_name_id ON bar_system.locations(name, id);
+CREATE INDEX IF NOT EXISTS idx_locations_code_prefix ON bar_system.locations(code text_pattern_ops);
+
+-- Orden por código: lo cubre el UNIQUE (location_id, code)
+CREATE INDEX IF NOT EXISTS idx_tables_location_seats_id ON bar_system.tables(location_id, seats, id);
+CREATE INDEX IF NOT EXISTS idx_tables_location_created_at_id ON bar_system.tables(location_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tables_location_code_prefix ON bar_system.tables(location_id, code text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_tables_location_status ON bar_system.tables(location_id, status);
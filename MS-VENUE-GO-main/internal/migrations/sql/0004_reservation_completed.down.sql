@@ -0,0 +1,4 @@
+-- El valor completed de reservation_status no se puede quitar de un ENUM; se
+-- conserva al revertir y las reservas completadas vuelven a quedar sentadas.
+
+UPDATE bar_system.reservations SET status = 'seated' WHERE status = 'completed';
@@ -0,0 +1,7 @@
+-- ================================================
+-- MS-VENUE-GO: Reservas completadas
+-- ================================================
+-- Una reserva sentada pasa a completed cuando su mesa queda libre o por
+-- limpiar; así deja de bloquear la mesa para nuevas reservas.
+
+ALTER TYPE bar_system.reservation_status ADD VALUE IF NOT EXISTS 'completed';
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// WaiterAssignment puts a waiter in charge of a zone, or of an explicit set
+// of tables, of one location for a shift. A zone assignment covers whatever
+// tables are in the zone while the shift runs.
+type WaiterAssignment struct {
+	ID         string     `json:"id" db:"id"`
+	LocationID string     `json:"location_id" db:"location_id"`
+	WaiterID   string     `json:"waiter_id" db:"waiter_id"`
+	ZoneID     *string    `json:"zone_id" db:"zone_id"`
+	TableIDs   []string   `json:"table_ids"`
+	ShiftStart time.Time  `json:"shift_start" db:"shift_start"`
+	ShiftEnd   time.Time  `json:"shift_end" db:"shift_end"`
+	AssignedBy *string    `json:"assigned_by" db:"assigned_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	RemovedAt  *time.Time `json:"removed_at" db:"removed_at"`
+}
+
+// CreateWaiterAssignmentRequest needs exactly one of ZoneID or TableIDs.
+type CreateWaiterAssignmentRequest struct {
+	LocationID string    `json:"location_id" binding:"required"`
+	WaiterID   string    `json:"waiter_id" binding:"required"`
+	ZoneID     *string   `json:"zone_id"`
+	TableIDs   []string  `json:"table_ids" binding:"dive,required"`
+	ShiftStart time.Time `json:"shift_start" binding:"required"`
+	ShiftEnd   time.Time `json:"shift_end" binding:"required"`
+}
+
+// WaiterAssignmentFilter narrows assignment listings; nil fields are
+// ignored. Only assignments that have not been removed are listed.
+type WaiterAssignmentFilter struct {
+	LocationID *string
+	WaiterID   *string
+	From       *time.Time
+	To         *time.Time
+}
+
+// WaiterTables is what a waiter covers at a given time.
+type WaiterTables struct {
+	WaiterID    string             `json:"waiter_id"`
+	At          time.Time          `json:"at"`
+	Assignments []WaiterAssignment `json:"assignments"`
+	Tables      []Table            `json:"tables"`
+}
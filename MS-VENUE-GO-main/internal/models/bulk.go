@@ -0,0 +1,49 @@
+package models
+
+// LocationImportRow is one location in a bulk import or export. Locations are
+// matched by code, so files can move between environments.
+type LocationImportRow struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	City        string   `json:"city"`
+	Phone       string   `json:"phone"`
+	Email       string   `json:"email"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	MaxCapacity *int     `json:"max_capacity"`
+}
+
+// TableImportRow is one table in a bulk import or export. The table is
+// matched by location code and table code; its zone by name, and zones that
+// do not exist yet are created.
+type TableImportRow struct {
+	LocationCode string  `json:"location_code"`
+	Code         string  `json:"code"`
+	Seats        int     `json:"seats"`
+	Zone         string  `json:"zone"`
+	Shape        string  `json:"shape"`
+	PosX         float64 `json:"pos_x"`
+	PosY         float64 `json:"pos_y"`
+	Rotation     float64 `json:"rotation"`
+	Width        float64 `json:"width"`
+	Height       float64 `json:"height"`
+}
+
+// ImportResult reports what a bulk import did, or would do in a dry run.
+// Nothing is applied when Errors is not empty.
+type ImportResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Applied bool             `json:"applied"`
+	Rows    int              `json:"rows"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// ImportRowError is a problem with one row, numbered from 1 after the CSV
+// header or array start. Row 0 refers to the file as a whole.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// CloneLocationRequest names the new location. Address and city default to
+// the source location's when left empty.
+type CloneLocationRequest struct {
+	Code    string `json:"code" binding:"required"`
+	Name    string `json:"name" binding:"required"`
+	Address string `json:"address"`
+	City    string `json:"city"`
+}
+
+// LocationClone is everything copied from one location into a new one, with
+// new IDs already assigned. It is written in a single transaction.
+type LocationClone struct {
+	Location     *Location
+	Canvas       Canvas
+	Zones        []Zone
+	ZoneCanvases []ZoneCanvas
+	Tables       []Table
+	Settings     *LocationSettings
+	Hours        []OpeningHours
+}
+
+// CloneLocationResult maps the IDs of the source location's zones and tables
+// to the IDs of their copies.
+type CloneLocationResult struct {
+	Location *Location         `json:"location"`
+	ZoneIDs  map[string]string `json:"zone_ids"`
+	TableIDs map[string]string `json:"table_ids"`
+}
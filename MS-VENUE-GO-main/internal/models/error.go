@@ -0,0 +1,23 @@
+package models
+
+// Error codes returned in ErrorResponse.Code. Clients should branch on the
+// code; the message is meant for people and may change.
+const (
+	ErrorCodeValidation           = "validation_failed"
+	ErrorCodeNotFound             = "not_found"
+	ErrorCodeDuplicateCode        = "duplicate_code"
+	ErrorCodeInvalidReference     = "invalid_reference"
+	ErrorCodeConflict             = "conflict"
+	ErrorCodeStillReferenced      = "still_referenced"
+	ErrorCodeVersionConflict      = "version_conflict"
+	ErrorCodePreconditionRequired = "precondition_required"
+	ErrorCodeUnauthorized         = "unauthorized"
+	ErrorCodeForbidden            = "forbidden"
+	ErrorCodeInternal             = "internal_error"
+)
+
+// ErrorResponse is the body of every error answered by the venue API.
+type ErrorResponse struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
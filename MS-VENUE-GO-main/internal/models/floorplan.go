@@ -0,0 +1,44 @@
+package models
+
+// Floor plan coordinates are canvas units. A table's PosX/PosY is its centre
+// and Rotation is in degrees clockwise around that centre; round tables use
+// Width as their diameter.
+
+type Canvas struct {
+	Width  float64 `json:"width" binding:"required,gt=0"`
+	Height float64 `json:"height" binding:"required,gt=0"`
+}
+
+// ZoneCanvas gives a zone its own drawing area. Tables in a zone without a
+// canvas are drawn on the location canvas.
+type ZoneCanvas struct {
+	ZoneID string `json:"zone_id" binding:"required"`
+	Canvas
+}
+
+type FloorPlan struct {
+	LocationID string       `json:"location_id"`
+	Canvas     Canvas       `json:"canvas"`
+	Zones      []ZoneCanvas `json:"zones"`
+	Tables     []Table      `json:"tables"`
+}
+
+type TablePlacement struct {
+	TableID  string  `json:"table_id" binding:"required"`
+	PosX     float64 `json:"pos_x"`
+	PosY     float64 `json:"pos_y"`
+	Rotation float64 `json:"rotation" binding:"gte=0,lt=360"`
+	Shape    string  `json:"shape" binding:"required,oneof=round square rectangle bar_stool"`
+	Width    float64 `json:"width" binding:"required,gt=0"`
+	Height   float64 `json:"height" binding:"required,gt=0"`
+}
+
+// SaveFloorPlanRequest replaces the layout of a location. Only the listed
+// zones and tables are changed; the ones left out keep their current canvas
+// and geometry, and must still fit the saved layout. Tables at the origin
+// are not placed on the plan.
+type SaveFloorPlanRequest struct {
+	Canvas Canvas           `json:"canvas" binding:"required"`
+	Zones  []ZoneCanvas     `json:"zones" binding:"dive"`
+	Tables []TablePlacement `json:"tables" binding:"dive"`
+}
@@ -5,24 +5,89 @@ import (
 )
 
 type Location struct {
-	ID        string    `json:"id" db:"id"`
-	Code      string    `json:"code" db:"code"`
-	Name      string    `json:"name" db:"name"`
-	Address   string    `json:"address" db:"address"`
-	IsActive  bool      `json:"is_active" db:"is_active"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID          string     `json:"id" db:"id"`
+	Code        string     `json:"code" db:"code"`
+	Name        string     `json:"name" db:"name"`
+	Address     string     `json:"address" db:"address"`
+	City        string     `json:"city" db:"city"`
+	Phone       string     `json:"phone" db:"phone"` // E.164, e.g. +573001234567
+	Email       string     `json:"email" db:"email"`
+	Latitude    *float64   `json:"latitude" db:"latitude"`
+	Longitude   *float64   `json:"longitude" db:"longitude"`
+	MaxCapacity *int       `json:"max_capacity" db:"max_capacity"`
+	ManagerID   *string    `json:"manager_id" db:"manager_id"`
+	IsActive    bool       `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at" db:"deleted_at"`
+	Version     int        `json:"version" db:"version"` // bumped on every write; sent as the ETag
+
+	// OpenNow is computed from the location's schedule; it is omitted when no
+	// opening hours are configured.
+	OpenNow *bool `json:"open_now,omitempty" db:"-"`
 }
 
 type CreateLocationRequest struct {
-	Code    string `json:"code" binding:"required"`
-	Name    string `json:"name" binding:"required"`
-	Address string `json:"address" binding:"required"`
+	Code        string   `json:"code" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	Address     string   `json:"address" binding:"required"`
+	City        string   `json:"city"`
+	Phone       string   `json:"phone"`
+	Email       string   `json:"email"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	MaxCapacity *int     `json:"max_capacity"`
+	ManagerID   *string  `json:"manager_id"`
 }
 
+// UpdateLocationRequest changes only the fields that are provided. An empty
+// string clears city, phone, email or manager_id. IsActive may only repeat the
+// current value; locations are deleted and restored through their own routes.
 type UpdateLocationRequest struct {
-	Code     string `json:"code"`
-	Name     string `json:"name"`
-	Address  string `json:"address"`
-	IsActive *bool  `json:"is_active"`
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	City        *string  `json:"city"`
+	Phone       *string  `json:"phone"`
+	Email       *string  `json:"email"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	MaxCapacity *int     `json:"max_capacity"`
+	ManagerID   *string  `json:"manager_id"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// Sort keys of location listings.
+const (
+	LocationSortCreatedAt = "created_at"
+	LocationSortName      = "name"
+	LocationSortCode      = "code"
+)
+
+// LocationFilter narrows and orders location listings. Deleted (inactive)
+// locations are left out unless IncludeInactive is set or IsActive is false.
+// An empty Sort lists the newest locations first; Limit 0 lists every match.
+type LocationFilter struct {
+	IncludeInactive bool
+	IsActive        *bool
+	CodePrefix      string
+	Sort            string // one of the LocationSort* keys
+	Descending      bool
+	Limit           int
+	After           *PageKey // resume after this location
+}
+
+// NearbyLocation is a location returned by a geosearch, with its distance
+// from the search point and how many tables can take guests right now.
+type NearbyLocation struct {
+	Location
+	DistanceKm      float64 `json:"distance_km"`
+	AvailableTables int     `json:"available_tables"`
+}
+
+type NearbyLocationsQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	OpenOnly  bool
 }
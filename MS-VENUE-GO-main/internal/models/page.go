@@ -0,0 +1,31 @@
+package models
+
+// PageRequest asks for one page of a listing. Sort names a sort key, with a
+// leading "-" for descending order, and Cursor is the next_cursor of the
+// previous page, which must have been listed with the same sort.
+type PageRequest struct {
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+// PageKey identifies the last row of a page by its sort key value and ID,
+// the tie-breaker of every listing order.
+type PageKey struct {
+	Value string
+	ID    string
+}
+
+// LocationPage is one page of a location listing. NextCursor is null on the
+// last page.
+type LocationPage struct {
+	Items      []Location `json:"items"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+// TablePage is one page of a table listing. NextCursor is null on the last
+// page.
+type TablePage struct {
+	Items      []Table `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
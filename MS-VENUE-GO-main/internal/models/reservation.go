@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReservationStatusBooked    = "booked"
+	ReservationStatusSeated    = "seated"
+	ReservationStatusNoShow    = "no_show"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusCompleted = "completed" // the seated party left the table
+)
+
+type Reservation struct {
+	ID         string    `json:"id" db:"id"`
+	LocationID string    `json:"location_id" db:"location_id"`
+	TableID    string    `json:"table_id" db:"table_id"`
+	PartyName  string    `json:"party_name" db:"party_name"`
+	Phone      string    `json:"phone" db:"phone"`
+	PartySize  int       `json:"party_size" db:"party_size"`
+	StartsAt   time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt     time.Time `json:"ends_at" db:"ends_at"`
+	Status     string    `json:"status" db:"status"` // booked, seated, no_show, cancelled, completed
+	Notes      string    `json:"notes" db:"notes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateReservationRequest struct {
+	LocationID string    `json:"location_id" binding:"required"`
+	TableID    string    `json:"table_id" binding:"required"`
+	PartyName  string    `json:"party_name" binding:"required"`
+	Phone      string    `json:"phone" binding:"required"`
+	PartySize  int       `json:"party_size" binding:"required,min=1"`
+	StartsAt   time.Time `json:"starts_at" binding:"required"`
+	EndsAt     time.Time `json:"ends_at" binding:"required"`
+	Notes      string    `json:"notes"`
+}
+
+type UpdateReservationRequest struct {
+	TableID   string     `json:"table_id"`
+	PartyName string     `json:"party_name"`
+	Phone     string     `json:"phone"`
+	PartySize int        `json:"party_size" binding:"omitempty,min=1"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	Notes     *string    `json:"notes"`
+}
+
+type UpdateReservationStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=booked seated no_show cancelled"`
+}
+
+// ReservationFilter narrows reservation listings; nil fields are ignored.
+type ReservationFilter struct {
+	LocationID *string
+	TableID    *string
+	Status     *string
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// OpeningHours is one weekly opening range of a location. Times are "HH:MM"
+// in the location's local time; a range whose closing time is not after its
+// opening time runs overnight into the next day (e.g. 18:00-03:00).
+type OpeningHours struct {
+	ID         string `json:"id" db:"id"`
+	LocationID string `json:"location_id" db:"location_id"`
+	DayOfWeek  int    `json:"day_of_week" db:"day_of_week"` // 0 = Sunday ... 6 = Saturday
+	OpensAt    string `json:"opens_at" db:"opens_at"`
+	ClosesAt   string `json:"closes_at" db:"closes_at"`
+}
+
+// ScheduleException overrides the weekly hours for every day between
+// StartDate and EndDate ("YYYY-MM-DD", inclusive): either the location is
+// closed (holidays, renovations) or it opens with special hours.
+type ScheduleException struct {
+	ID         string    `json:"id" db:"id"`
+	LocationID string    `json:"location_id" db:"location_id"`
+	StartDate  string    `json:"start_date" db:"start_date"`
+	EndDate    string    `json:"end_date" db:"end_date"`
+	IsClosed   bool      `json:"is_closed" db:"is_closed"`
+	OpensAt    *string   `json:"opens_at" db:"opens_at"`
+	ClosesAt   *string   `json:"closes_at" db:"closes_at"`
+	Reason     string    `json:"reason" db:"reason"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// LocationSchedule bundles the weekly hours and exceptions of a location.
+type LocationSchedule struct {
+	LocationID string              `json:"location_id"`
+	Hours      []OpeningHours      `json:"hours"`
+	Exceptions []ScheduleException `json:"exceptions"`
+}
+
+type OpeningHoursInput struct {
+	DayOfWeek *int   `json:"day_of_week" binding:"required,min=0,max=6"`
+	OpensAt   string `json:"opens_at" binding:"required"`
+	ClosesAt  string `json:"closes_at" binding:"required"`
+}
+
+// SetOpeningHoursRequest replaces every weekly range of the location; an
+// empty list clears the schedule.
+type SetOpeningHoursRequest struct {
+	Hours []OpeningHoursInput `json:"hours" binding:"dive"`
+}
+
+type CreateScheduleExceptionRequest struct {
+	StartDate string  `json:"start_date" binding:"required"`
+	EndDate   string  `json:"end_date"`
+	IsClosed  bool    `json:"is_closed"`
+	OpensAt   *string `json:"opens_at"`
+	ClosesAt  *string `json:"closes_at"`
+	Reason    string  `json:"reason"`
+}
+
+// OpenStatus reports whether a location is open at a given instant. ClosesAt
+// is set while open and NextOpensAt while closed, when known.
+type OpenStatus struct {
+	LocationID  string     `json:"location_id"`
+	At          time.Time  `json:"at"`
+	IsOpen      bool       `json:"is_open"`
+	ClosesAt    *time.Time `json:"closes_at,omitempty"`
+	NextOpensAt *time.Time `json:"next_opens_at,omitempty"`
+}
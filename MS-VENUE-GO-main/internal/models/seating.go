@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// SeatingSuggestionRequest asks for tables for a party. At defaults to now,
+// DurationMinutes to 120 and Limit to 5.
+type SeatingSuggestionRequest struct {
+	PartySize       int        `json:"party_size" binding:"required,min=1"`
+	ZoneID          *string    `json:"zone_id"`
+	At              *time.Time `json:"at"`
+	DurationMinutes int        `json:"duration_minutes" binding:"omitempty,min=15,max=720"`
+	Limit           int        `json:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+// SeatingSuggestion is one candidate for a party: a single table, an existing
+// table group, or a combination of tables that would have to be merged.
+// Lower scores rank first.
+type SeatingSuggestion struct {
+	TableIDs    []string `json:"table_ids"`
+	TableCodes  []string `json:"table_codes"`
+	GroupID     *string  `json:"group_id,omitempty"`
+	ZoneID      *string  `json:"zone_id"`
+	Seats       int      `json:"seats"`
+	WastedSeats int      `json:"wasted_seats"`
+	NeedsMerge  bool     `json:"needs_merge"`
+	Score       int      `json:"score"`
+}
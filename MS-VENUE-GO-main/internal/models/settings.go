@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// LocationSettings holds the business rules other services need to apply per
+// location. Rates are percentages (19 means 19%).
+type LocationSettings struct {
+	LocationID           string       `json:"location_id" db:"location_id"`
+	Timezone             string       `json:"timezone" db:"timezone"`
+	CurrencyCode         string       `json:"currency_code" db:"currency_code"`
+	TaxRate              float64      `json:"tax_rate" db:"tax_rate"`
+	TaxProfiles          []TaxProfile `json:"tax_profiles" db:"tax_profiles"`
+	ServiceChargePercent float64      `json:"service_charge_percent" db:"service_charge_percent"`
+	ReceiptHeader        string       `json:"receipt_header" db:"receipt_header"`
+	UpdatedAt            *time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// TaxProfile is a named tax rate that applies to some products instead of the
+// location's default rate (e.g. exempt or reduced-rate items).
+type TaxProfile struct {
+	Code string  `json:"code" binding:"required"`
+	Name string  `json:"name" binding:"required"`
+	Rate float64 `json:"rate" binding:"min=0,max=100"`
+}
+
+// UpdateLocationSettingsRequest changes only the fields that are provided.
+type UpdateLocationSettingsRequest struct {
+	Timezone             *string       `json:"timezone"`
+	CurrencyCode         *string       `json:"currency_code"`
+	TaxRate              *float64      `json:"tax_rate" binding:"omitempty,min=0,max=100"`
+	TaxProfiles          *[]TaxProfile `json:"tax_profiles" binding:"omitempty,dive"`
+	ServiceChargePercent *float64      `json:"service_charge_percent" binding:"omitempty,min=0,max=100"`
+	ReceiptHeader        *string       `json:"receipt_header" binding:"omitempty,max=500"`
+}
@@ -4,26 +4,102 @@ import (
 	"time"
 )
 
+const (
+	TableStatusAvailable     = "available"
+	TableStatusOccupied      = "occupied"
+	TableStatusNeedsCleaning = "needs_cleaning"
+	TableStatusReserved      = "reserved"
+)
+
+const (
+	TableShapeRound     = "round"
+	TableShapeSquare    = "square"
+	TableShapeRectangle = "rectangle"
+	TableShapeBarStool  = "bar_stool"
+)
+
 type Table struct {
-	ID         string    `json:"id" db:"id"`
-	LocationID string    `json:"location_id" db:"location_id"`
-	Code       string    `json:"code" db:"code"`
-	Seats      int       `json:"seats" db:"seats"`
-	Status     string    `json:"status" db:"status"` // free, occupied, reserved
-	IsActive   bool      `json:"is_active" db:"is_active"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID         string     `json:"id" db:"id"`
+	LocationID string     `json:"location_id" db:"location_id"`
+	ZoneID     *string    `json:"zone_id" db:"zone_id"`
+	GroupID    *string    `json:"group_id" db:"group_id"`
+	Code       string     `json:"code" db:"code"`
+	Seats      int        `json:"seats" db:"seats"`
+	Status     string     `json:"status" db:"status"` // available, occupied, needs_cleaning, reserved
+	PosX       float64    `json:"pos_x" db:"pos_x"`
+	PosY       float64    `json:"pos_y" db:"pos_y"`
+	Rotation   float64    `json:"rotation" db:"rotation"`
+	Shape      string     `json:"shape" db:"shape"` // round, square, rectangle, bar_stool
+	Width      float64    `json:"width" db:"width"`
+	Height     float64    `json:"height" db:"height"`
+	IsActive   bool       `json:"is_active" db:"is_active"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at" db:"deleted_at"`
+	Version    int        `json:"version" db:"version"` // bumped on every write; sent as the ETag
+
+	// QRToken is the signed token printed on the table's QR sticker. It is
+	// only handed out through the QR endpoints, never in table listings.
+	QRToken     *string    `json:"-" db:"qr_token"`
+	QRRotatedAt *time.Time `json:"qr_rotated_at" db:"qr_rotated_at"`
 }
 
 type CreateTableRequest struct {
-	LocationID string `json:"location_id" binding:"required"`
-	Code       string `json:"code" binding:"required"`
-	Seats      int    `json:"seats" binding:"required,min=1,max=20"`
+	LocationID string  `json:"location_id" binding:"required"`
+	ZoneID     *string `json:"zone_id"`
+	Code       string  `json:"code" binding:"required"`
+	Seats      int     `json:"seats" binding:"required,min=1,max=20"`
 }
 
 type UpdateTableRequest struct {
-	Code     string `json:"code"`
-	Seats    int    `json:"seats" binding:"min=1,max=20"`
-	Status   string `json:"status" binding:"omitempty,oneof=free occupied reserved"`
-	IsActive *bool  `json:"is_active"`
+	Code     string  `json:"code"`
+	ZoneID   *string `json:"zone_id"` // empty string removes the table from its zone
+	Seats    int     `json:"seats" binding:"min=1,max=20"`
+	IsActive *bool   `json:"is_active"` // may only repeat the current value; see DeleteTable and RestoreTable
+}
+
+// Sort keys of table listings.
+const (
+	TableSortCode      = "code"
+	TableSortSeats     = "seats"
+	TableSortCreatedAt = "created_at"
+)
+
+// TableFilter narrows and orders a location's table listing; nil fields are
+// ignored. Deleted (inactive) tables are left out unless IncludeInactive is
+// set or IsActive is false. Status matches the status the listing shows, with
+// reservations about to start taken into account; the service applies it and
+// narrows the repository read with StoredStatuses. An empty Sort lists tables
+// by code; Limit 0 lists every match.
+type TableFilter struct {
+	ZoneID          *string
+	IncludeInactive bool
+	IsActive        *bool
+	Status          *string
+	StoredStatuses  []string
+	SeatsMin        *int
+	SeatsMax        *int
+	CodePrefix      string
+	Sort            string // one of the TableSort* keys
+	Descending      bool
+	Limit           int
+	After           *PageKey // resume after this table
+}
+
+// TableTransitionRequest moves a table to a new status through the lifecycle
+// enforced by the service.
+type TableTransitionRequest struct {
+	Status string `json:"status" binding:"required,oneof=available occupied needs_cleaning reserved"`
+	Reason string `json:"reason"`
+}
+
+// TableStatusChange is one entry of a table's status history.
+type TableStatusChange struct {
+	ID         string    `json:"id" db:"id"`
+	TableID    string    `json:"table_id" db:"table_id"`
+	FromStatus string    `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	Reason     string    `json:"reason" db:"reason"`
+	ChangedBy  *string   `json:"changed_by" db:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at" db:"changed_at"`
 }
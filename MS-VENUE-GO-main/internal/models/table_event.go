@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	TableEventCreated       = "table_created"
+	TableEventUpdated       = "table_updated"
+	TableEventStatusChanged = "table_status_changed"
+	TableEventDeleted       = "table_deleted"
+
+	// TableEventResync tells subscribers that events may have been missed and
+	// they should reload the location's tables.
+	TableEventResync = "resync"
+)
+
+// TableChange is the notification the database sends when a table row is
+// written. An empty LocationID on a resync means every location.
+type TableChange struct {
+	Type           string `json:"type"`
+	LocationID     string `json:"location_id"`
+	TableID        string `json:"table_id"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+// TableEvent is what stream subscribers receive. Table is omitted on resync.
+type TableEvent struct {
+	Type           string    `json:"type"`
+	LocationID     string    `json:"location_id"`
+	Table          *Table    `json:"table,omitempty"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	At             time.Time `json:"at"`
+}
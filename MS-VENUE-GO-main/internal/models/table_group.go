@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// TableGroup is a temporary virtual table made of several tables of the same
+// location pushed together for a large party. Its seats are the sum of its
+// members and its status is the status all members share.
+type TableGroup struct {
+	ID          string     `json:"id" db:"id"`
+	LocationID  string     `json:"location_id" db:"location_id"`
+	Code        string     `json:"code" db:"code"`
+	Seats       int        `json:"seats"`
+	Status      string     `json:"status"`
+	Tables      []Table    `json:"tables"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	DissolvedAt *time.Time `json:"dissolved_at" db:"dissolved_at"`
+}
+
+type CreateTableGroupRequest struct {
+	LocationID string   `json:"location_id" binding:"required"`
+	Code       string   `json:"code"`
+	TableIDs   []string `json:"table_ids" binding:"required,min=2,dive,required"`
+}
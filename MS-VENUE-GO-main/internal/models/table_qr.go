@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// TableQRCode describes the current QR sticker of a table.
+type TableQRCode struct {
+	TableID   string    `json:"table_id"`
+	TableCode string    `json:"table_code"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	RotatedAt time.Time `json:"rotated_at"`
+}
+
+// TableResolution is what a guest scanning a sticker gets back. It carries
+// codes and names only, so internal IDs never leave the venue service.
+type TableResolution struct {
+	Token    string           `json:"token"`
+	Table    ResolvedTable    `json:"table"`
+	Location ResolvedLocation `json:"location"`
+}
+
+type ResolvedTable struct {
+	Code   string `json:"code"`
+	Seats  int    `json:"seats"`
+	Status string `json:"status"`
+}
+
+type ResolvedLocation struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	City         string `json:"city"`
+	Phone        string `json:"phone"`
+	CurrencyCode string `json:"currency_code"`
+	OpenNow      *bool  `json:"open_now,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusNotified  = "notified"
+	WaitlistStatusSeated    = "seated"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+// WaitlistEntry is a walk-in party queued at a location until a table frees up.
+type WaitlistEntry struct {
+	ID                string     `json:"id" db:"id"`
+	LocationID        string     `json:"location_id" db:"location_id"`
+	PartyName         string     `json:"party_name" db:"party_name"`
+	Phone             string     `json:"phone" db:"phone"`
+	PartySize         int        `json:"party_size" db:"party_size"`
+	Position          int        `json:"position" db:"position"`
+	QuotedWaitMinutes int        `json:"quoted_wait_minutes" db:"quoted_wait_minutes"`
+	Status            string     `json:"status" db:"status"` // waiting, notified, seated, cancelled
+	TableID           *string    `json:"table_id" db:"table_id"`
+	Notes             string     `json:"notes" db:"notes"`
+	JoinedAt          time.Time  `json:"joined_at" db:"joined_at"`
+	NotifiedAt        *time.Time `json:"notified_at" db:"notified_at"`
+	SeatedAt          *time.Time `json:"seated_at" db:"seated_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type CreateWaitlistEntryRequest struct {
+	LocationID string `json:"location_id" binding:"required"`
+	PartyName  string `json:"party_name" binding:"required"`
+	Phone      string `json:"phone" binding:"required"`
+	PartySize  int    `json:"party_size" binding:"required,min=1"`
+	Notes      string `json:"notes"`
+}
+
+// ReorderWaitlistRequest lists every waiting or notified entry of the
+// location in the new order.
+type ReorderWaitlistRequest struct {
+	EntryIDs []string `json:"entry_ids" binding:"required,min=1,dive,required"`
+}
+
+type SeatWaitlistEntryRequest struct {
+	TableID string `json:"table_id" binding:"required"`
+}
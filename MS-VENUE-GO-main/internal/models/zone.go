@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ZoneEnvironmentIndoor  = "indoor"
+	ZoneEnvironmentOutdoor = "outdoor"
+)
+
+// Zone is an area inside a location (terrace, main floor, VIP, bar counter)
+// that groups tables.
+type Zone struct {
+	ID          string    `json:"id" db:"id"`
+	LocationID  string    `json:"location_id" db:"location_id"`
+	Name        string    `json:"name" db:"name"`
+	IsSmoking   bool      `json:"is_smoking" db:"is_smoking"`
+	Environment string    `json:"environment" db:"environment"` // indoor, outdoor
+	SortOrder   int       `json:"sort_order" db:"sort_order"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateZoneRequest struct {
+	LocationID  string `json:"location_id" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	IsSmoking   bool   `json:"is_smoking"`
+	Environment string `json:"environment" binding:"required,oneof=indoor outdoor"`
+	SortOrder   int    `json:"sort_order" binding:"min=0"`
+}
+
+type UpdateZoneRequest struct {
+	Name        string `json:"name"`
+	IsSmoking   *bool  `json:"is_smoking"`
+	Environment string `json:"environment" binding:"omitempty,oneof=indoor outdoor"`
+	SortOrder   *int   `json:"sort_order" binding:"omitempty,min=0"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+// ZoneOccupancy summarises table statuses of one zone. Tables without a zone
+// are reported with an empty ZoneID.
+type ZoneOccupancy struct {
+	ZoneID        string `json:"zone_id"`
+	ZoneName      string `json:"zone_name"`
+	TotalTables   int    `json:"total_tables"`
+	TotalSeats    int    `json:"total_seats"`
+	Available     int    `json:"available"`
+	Occupied      int    `json:"occupied"`
+	NeedsCleaning int    `json:"needs_cleaning"`
+	Reserved      int    `json:"reserved"`
+	OccupiedSeats int    `json:"occupied_seats"`
+}
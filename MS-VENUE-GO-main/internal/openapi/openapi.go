@@ -0,0 +1,263 @@
+// Package openapi builds an OpenAPI 3 document from a list of routes. The
+// schemas of request and response bodies are generated from the Go types by
+// reflection, following their json tags and Gin binding rules, so the
+// document changes together with the models.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI version of the documents built here.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to the operations of a path.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query or header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// BearerAuth is the name of the JWT security scheme of the document.
+const BearerAuth = "bearerAuth"
+
+// Route describes one endpoint. Body and Response are values of the request
+// and response types, e.g. models.Table{} or []models.Zone{}; nil means
+// none.
+type Route struct {
+	Method       string
+	Path         string // Gin syntax, e.g. /api/venue/tables/:id
+	Tag          string
+	Summary      string
+	Description  string
+	Auth         bool // requires a bearer token
+	AuthOptional bool // accepts a bearer token, e.g. to unlock admin filters
+	Query        []Parameter
+	Headers      []Parameter
+
+	Body      interface{}
+	BodyTypes []string // media types of Body; application/json when empty
+
+	Status        int // success status; 200 when zero
+	Response      interface{}
+	ResponseTypes []string // media types of Response; application/json when empty
+	ETag          bool     // the success response carries the version as ETag
+
+	// Errors lists the statuses answered with the error envelope besides
+	// the ones implied by the route: 400, 401/403 with Auth and 404 with
+	// path parameters.
+	Errors []int
+}
+
+// Builder accumulates routes into a document.
+type Builder struct {
+	doc     *Document
+	schemas *schemaGenerator
+}
+
+// New starts a document. errorType is a value of the error envelope type
+// answered by failing routes.
+func New(info Info, errorType interface{}) *Builder {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	b := &Builder{doc: doc, schemas: newSchemaGenerator(doc.Components.Schemas)}
+	b.schemas.errorSchema = b.schemas.of(errorType)
+	return b
+}
+
+// Tag adds a tag description.
+func (b *Builder) Tag(name, description string) {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+}
+
+var ginParam = regexp.MustCompile(`[:*](\w+)`)
+
+// Path converts a Gin route path to OpenAPI syntax: /tables/:id becomes
+// /tables/{id}.
+func Path(ginPath string) string {
+	return ginParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+// Add documents a route.
+func (b *Builder) Add(route Route) {
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		OperationID: operationID(route.Method, route.Path),
+		Responses:   make(map[string]Response),
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	errors := map[int]bool{http.StatusBadRequest: true, http.StatusInternalServerError: true}
+	for _, match := range ginParam.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		errors[http.StatusNotFound] = true
+	}
+	op.Parameters = append(op.Parameters, route.Query...)
+	op.Parameters = append(op.Parameters, route.Headers...)
+	switch {
+	case route.Auth:
+		op.Security = []map[string][]string{{BearerAuth: {}}}
+		errors[http.StatusUnauthorized] = true
+		errors[http.StatusForbidden] = true
+	case route.AuthOptional:
+		op.Security = []map[string][]string{{BearerAuth: {}}, {}}
+	}
+	for _, status := range route.Errors {
+		errors[status] = true
+	}
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: b.content(route.Body, route.BodyTypes)}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		success.Content = b.content(route.Response, route.ResponseTypes)
+	}
+	if route.ETag {
+		success.Headers = map[string]Header{"ETag": {Description: "Current version, to send back in If-Match", Schema: &Schema{Type: "string"}}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	for status := range errors {
+		op.Responses[strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{"application/json": {Schema: b.schemas.errorSchema}},
+		}
+	}
+
+	path := Path(route.Path)
+	item, ok := b.doc.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		b.doc.Paths[path] = item
+	}
+	item[strings.ToLower(route.Method)] = op
+}
+
+// Document returns the built document.
+func (b *Builder) Document() *Document {
+	sort.Slice(b.doc.Tags, func(i, j int) bool { return b.doc.Tags[i].Name < b.doc.Tags[j].Name })
+	return b.doc
+}
+
+// content describes a body of the given value in each media type. JSON
+// bodies get the schema of the value's type; others are plain strings, or
+// binary data for images.
+func (b *Builder) content(value interface{}, mediaTypes []string) map[string]MediaType {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	content := make(map[string]MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		switch {
+		case mediaType == "application/json":
+			content[mediaType] = MediaType{Schema: b.schemas.of(value)}
+		case strings.HasPrefix(mediaType, "image/") && mediaType != "image/svg+xml":
+			content[mediaType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		default:
+			content[mediaType] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+	}
+	return content
+}
+
+// operationID derives a stable ID from the method and path, e.g.
+// GET /tables/:id becomes get_tables_id.
+func operationID(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, ":*")
+		segment = strings.NewReplacer("-", "_", ".", "_").Replace(segment)
+		if segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+	return strings.Join(parts, "_")
+}
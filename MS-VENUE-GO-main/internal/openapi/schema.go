@@ -0,0 +1,201 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of the OpenAPI schema object used for Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaGenerator turns Go types into schemas. Named struct types become
+// component schemas referenced by name.
+type schemaGenerator struct {
+	components  map[string]*Schema
+	errorSchema *Schema
+}
+
+func newSchemaGenerator(components map[string]*Schema) *schemaGenerator {
+	return &schemaGenerator{components: components}
+}
+
+func (g *schemaGenerator) of(value interface{}) *Schema {
+	return g.schema(reflect.TypeOf(value))
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := g.schema(t.Elem())
+		if elem.Ref != "" {
+			return &Schema{AllOf: []*Schema{elem}, Nullable: true}
+		}
+		elem.Nullable = true
+		return elem
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := componentName(t)
+		if _, ok := g.components[name]; !ok {
+			// Register before filling it in so recursive types terminate
+			g.components[name] = &Schema{}
+			*g.components[name] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &Schema{}
+	}
+}
+
+// object describes a struct by its JSON fields. Embedded structs without a
+// json name are flattened into it, as encoding/json does.
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := g.object(indirect(field.Type))
+			for property, schema := range embedded.Properties {
+				s.Properties[property] = schema
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schema(field.Type)
+		if applyBinding(property, field.Tag.Get("binding")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+	return s
+}
+
+// applyBinding copies the Gin validation rules of a field that have an
+// OpenAPI equivalent into its schema and reports whether the field is
+// required. Rules after dive apply to elements and are left out.
+func applyBinding(s *Schema, binding string) bool {
+	if binding == "" {
+		return false
+	}
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			break
+		}
+		if s.Ref != "" || len(s.AllOf) > 0 {
+			required = required || name == "required"
+			continue
+		}
+		n, err := strconv.ParseFloat(arg, 64)
+		hasNumber := err == nil
+		switch {
+		case name == "required":
+			required = true
+		case name == "oneof":
+			s.Enum = strings.Fields(arg)
+		case hasNumber && (name == "min" || name == "gte" || name == "gt"):
+			s.setLowerBound(n, name == "gt")
+		case hasNumber && (name == "max" || name == "lte" || name == "lt"):
+			s.setUpperBound(n, name == "lt")
+		}
+	}
+	return required
+}
+
+func (s *Schema) setLowerBound(n float64, exclusive bool) {
+	switch s.Type {
+	case "string":
+		length := int(n)
+		s.MinLength = &length
+	case "array":
+		items := int(n)
+		s.MinItems = &items
+	default:
+		s.Minimum, s.ExclusiveMinimum = &n, exclusive
+	}
+}
+
+func (s *Schema) setUpperBound(n float64, exclusive bool) {
+	switch s.Type {
+	case "string":
+		length := int(n)
+		s.MaxLength = &length
+	case "array":
+		items := int(n)
+		s.MaxItems = &items
+	default:
+		s.Maximum, s.ExclusiveMaximum = &n, exclusive
+	}
+}
+
+// componentName names the schema of a named type; unexported names are
+// capitalized.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,258 @@
+package qrcode
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Format information for level M by mask, from table C.1 of ISO/IEC 18004.
+var formatInfoM = [8]int{0x5412, 0x5125, 0x5E7C, 0x5B4B, 0x45F9, 0x40CE, 0x4F97, 0x4AA0}
+
+// Positions of format bit i next to the top-left finder, and of its copy
+// split between the other two finders.
+func formatPositions(size int) (first, second [15][2]int) {
+	for i := 0; i < 15; i++ {
+		switch {
+		case i < 6:
+			first[i] = [2]int{8, i}
+		case i < 8:
+			first[i] = [2]int{8, i + 1}
+		case i == 8:
+			first[i] = [2]int{7, 8}
+		default:
+			first[i] = [2]int{14 - i, 8}
+		}
+		if i < 8 {
+			second[i] = [2]int{size - 1 - i, 8}
+		} else {
+			second[i] = [2]int{8, size - 15 + i}
+		}
+	}
+	return first, second
+}
+
+func readBits(modules [][]bool, positions [15][2]int) int {
+	bits := 0
+	for i, p := range positions {
+		if modules[p[1]][p[0]] {
+			bits |= 1 << i
+		}
+	}
+	return bits
+}
+
+func TestFormatBits(t *testing.T) {
+	code := newCode(1)
+	first, second := formatPositions(code.Size)
+	for mask, want := range formatInfoM {
+		code.drawFormatBits(mask)
+		if got := readBits(code.Modules, first); got != want {
+			t.Errorf("mask %d: format bits %#x, want %#x", mask, got, want)
+		}
+		if got := readBits(code.Modules, second); got != want {
+			t.Errorf("mask %d: second copy %#x, want %#x", mask, got, want)
+		}
+		if !code.Modules[code.Size-8][8] {
+			t.Errorf("mask %d: dark module is light", mask)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	// Table D.1 of ISO/IEC 18004
+	want := map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}
+
+	for version := 1; version < len(versions); version++ {
+		code := newCode(version)
+		code.drawVersion()
+
+		var topRight, bottomLeft int
+		for i := 0; i < 18; i++ {
+			a, b := code.Size-11+i%3, i/3
+			if code.Modules[b][a] {
+				topRight |= 1 << i
+			}
+			if code.Modules[a][b] {
+				bottomLeft |= 1 << i
+			}
+		}
+		if topRight != want[version] || bottomLeft != want[version] {
+			t.Errorf("version %d: version bits %#x and %#x, want %#x", version, topRight, bottomLeft, want[version])
+		}
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// "01234567" at 1-M, the worked example of annex I of ISO/IEC 18004
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	want := []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(versions[1].ecPerBlock))
+	if !bytes.Equal(got, want) {
+		t.Errorf("error correction % X, want % X", got, want)
+	}
+	if codewords := addErrorCorrection(1, data); !bytes.Equal(codewords, append(data, want...)) {
+		t.Errorf("1-M codewords % X", codewords)
+	}
+}
+
+func TestEncodeData(t *testing.T) {
+	// Byte mode 0100, count 00000101, "hello", terminator, then pad codewords
+	want := []byte{0x40, 0x56, 0x86, 0x56, 0xC6, 0xC6, 0xF0, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC}
+	if got := encodeData(1, []byte("hello")); !bytes.Equal(got, want) {
+		t.Errorf("data codewords % X, want % X", got, want)
+	}
+}
+
+func TestEncodeChoosesSmallestVersion(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{length: 1, version: 1},
+		{length: 14, version: 1},
+		{length: 15, version: 2},
+		{length: 122, version: 7},
+		{length: 213, version: 10},
+	}
+
+	for _, tt := range tests {
+		code, err := Encode(bytes.Repeat([]byte("a"), tt.length))
+		if err != nil {
+			t.Fatalf("encode %d bytes: %v", tt.length, err)
+		}
+		if code.Version != tt.version || code.Size != tt.version*4+17 {
+			t.Errorf("%d bytes: version %d size %d, want version %d", tt.length, code.Version, code.Size, tt.version)
+		}
+	}
+
+	if _, err := Encode(bytes.Repeat([]byte("a"), 214)); err == nil {
+		t.Error("214 bytes should not fit")
+	}
+}
+
+// The reference symbols in testdata were produced by
+// github.com/skip2/go-qrcode at level M with the version forced and the quiet
+// zone disabled: the first line is the payload and the rest the modules, #
+// for dark. Encode may pick another mask, so the symbol is rebuilt with the
+// reference's mask, read from its format information.
+func TestMatchesReferenceEncoder(t *testing.T) {
+	for _, version := range []int{1, 7, 10} {
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			payload, reference := readReference(t, fmt.Sprintf("testdata/v%d_m.txt", version))
+			if len(reference) != version*4+17 {
+				t.Fatalf("reference is %d modules wide", len(reference))
+			}
+
+			first, _ := formatPositions(len(reference))
+			format := readBits(reference, first)
+			mask := -1
+			for m, bits := range formatInfoM {
+				if bits == format {
+					mask = m
+				}
+			}
+			if mask < 0 {
+				t.Fatalf("reference format bits %#x are not level M", format)
+			}
+
+			encoded, err := Encode([]byte(payload))
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			if encoded.Version != version {
+				t.Fatalf("encoded as version %d", encoded.Version)
+			}
+
+			code := newCode(version)
+			code.drawFunctionPatterns()
+			code.drawCodewords(addErrorCorrection(version, encodeData(version, []byte(payload))))
+			code.applyMask(mask)
+			code.drawFormatBits(mask)
+
+			for y := range reference {
+				for x := range reference[y] {
+					if code.Modules[y][x] != reference[y][x] {
+						t.Fatalf("module (%d, %d) differs from the reference with mask %d", x, y, mask)
+					}
+				}
+			}
+		})
+	}
+}
+
+func readReference(t *testing.T, path string) (string, [][]bool) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open reference: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	payload := scanner.Text()
+
+	var modules [][]bool
+	for scanner.Scan() {
+		row := make([]bool, 0, len(scanner.Text()))
+		for _, c := range scanner.Text() {
+			row = append(row, c == '#')
+		}
+		modules = append(modules, row)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read reference: %v", err)
+	}
+	return payload, modules
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode([]byte("hello"))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	const scale = 3
+	data, err := code.PNG(scale)
+	if err != nil {
+		t.Fatalf("render png: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	side := (code.Size + 2*QuietZone) * scale
+	if b := img.Bounds(); b.Dx() != side || b.Dy() != side {
+		t.Fatalf("png is %dx%d, want %dx%d", b.Dx(), b.Dy(), side, side)
+	}
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			r, _, _, _ := img.At((x+QuietZone)*scale+1, (y+QuietZone)*scale+1).RGBA()
+			if dark := r == 0; dark != code.Modules[y][x] {
+				t.Fatalf("png module (%d, %d) dark = %v", x, y, dark)
+			}
+		}
+	}
+
+	svg := code.SVG(200)
+	dark := 0
+	for _, row := range code.Modules {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	if got := strings.Count(svg, "h1v1h-1z"); got != dark {
+		t.Errorf("svg draws %d modules, want %d", got, dark)
+	}
+	if !strings.Contains(svg, `width="200"`) || !strings.Contains(svg, fmt.Sprintf(`viewBox="0 0 %d %d"`, code.Size+8, code.Size+8)) {
+		t.Errorf("svg header: %s", svg[:200])
+	}
+}
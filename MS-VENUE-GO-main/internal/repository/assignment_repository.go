@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const waiterAssignmentColumns = `id, location_id, waiter_id, zone_id, shift_start, shift_end,
+		assigned_by, created_at, removed_at`
+
+// Waiter assignment operations
+
+// CreateWaiterAssignment inserts the assignment and its tables in one
+// transaction.
+func (r *venueRepository) CreateWaiterAssignment(ctx context.Context, assignment *models.WaiterAssignment) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bar_system.waiter_assignments (id, location_id, waiter_id, zone_id,
+			shift_start, shift_end, assigned_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`, assignment.ID, assignment.LocationID, assignment.WaiterID, assignment.ZoneID,
+		assignment.ShiftStart, assignment.ShiftEnd, assignment.AssignedBy).Scan(&assignment.CreatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+
+	for _, tableID := range assignment.TableIDs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO bar_system.waiter_assignment_tables (assignment_id, table_id)
+			VALUES ($1, $2)
+		`, assignment.ID, tableID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *venueRepository) GetWaiterAssignments(ctx context.Context, filter *models.WaiterAssignmentFilter) ([]models.WaiterAssignment, error) {
+	conditions := []string{"removed_at IS NULL"}
+	var args []interface{}
+	argIndex := 1
+
+	if filter.LocationID != nil {
+		conditions = append(conditions, fmt.Sprintf("location_id = $%d", argIndex))
+		args = append(args, *filter.LocationID)
+		argIndex++
+	}
+
+	if filter.WaiterID != nil {
+		conditions = append(conditions, fmt.Sprintf("waiter_id = $%d", argIndex))
+		args = append(args, *filter.WaiterID)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("shift_end > $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("shift_start < $%d", argIndex))
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.waiter_assignments
+		WHERE %s
+		ORDER BY shift_start, created_at
+	`, waiterAssignmentColumns, strings.Join(conditions, " AND "))
+
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]models.WaiterAssignment, 0)
+	for rows.Next() {
+		var assignment models.WaiterAssignment
+		if err := scanWaiterAssignment(rows, &assignment); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadWaiterAssignmentTables(ctx, assignments); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (r *venueRepository) GetWaiterAssignmentByID(ctx context.Context, id string) (*models.WaiterAssignment, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.waiter_assignments
+		WHERE id = $1
+	`, waiterAssignmentColumns)
+
+	assignment := &models.WaiterAssignment{}
+	if err := scanWaiterAssignment(r.q.QueryRowContext(ctx, query, id), assignment); err != nil {
+		return nil, err
+	}
+
+	assignments := []models.WaiterAssignment{*assignment}
+	if err := r.loadWaiterAssignmentTables(ctx, assignments); err != nil {
+		return nil, err
+	}
+
+	return &assignments[0], nil
+}
+
+// RemoveWaiterAssignment ends an assignment; removed assignments are kept
+// for the record but no longer cover any table.
+func (r *venueRepository) RemoveWaiterAssignment(ctx context.Context, id string) error {
+	result, err := r.q.ExecContext(ctx, `
+		UPDATE bar_system.waiter_assignments
+		SET removed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND removed_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetUserVenueID returns the location a staff member belongs to, or nil when
+// the user is not tied to one.
+func (r *venueRepository) GetUserVenueID(ctx context.Context, userID string) (*string, error) {
+	var venueID *string
+	err := r.q.QueryRowContext(ctx, `
+		SELECT venue_id
+		FROM bar_system.users
+		WHERE id = $1 AND is_active = true
+	`, userID).Scan(&venueID)
+	if err != nil {
+		return nil, err
+	}
+	return venueID, nil
+}
+
+func (r *venueRepository) loadWaiterAssignmentTables(ctx context.Context, assignments []models.WaiterAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(assignments))
+	byID := make(map[string]*models.WaiterAssignment, len(assignments))
+	for i := range assignments {
+		assignments[i].TableIDs = make([]string, 0)
+		ids[i] = assignments[i].ID
+		byID[assignments[i].ID] = &assignments[i]
+	}
+
+	rows, err := r.q.QueryContext(ctx, `
+		SELECT assignment_id, table_id
+		FROM bar_system.waiter_assignment_tables
+		WHERE assignment_id::text = ANY($1)
+		ORDER BY assignment_id, table_id
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var assignmentID, tableID string
+		if err := rows.Scan(&assignmentID, &tableID); err != nil {
+			return err
+		}
+		assignment := byID[assignmentID]
+		assignment.TableIDs = append(assignment.TableIDs, tableID)
+	}
+
+	return rows.Err()
+}
+
+func scanWaiterAssignment(row rowScanner, assignment *models.WaiterAssignment) error {
+	return row.Scan(&assignment.ID, &assignment.LocationID, &assignment.WaiterID, &assignment.ZoneID,
+		&assignment.ShiftStart, &assignment.ShiftEnd, &assignment.AssignedBy, &assignment.CreatedAt,
+		&assignment.RemovedAt)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+)
+
+// Bulk operations
+func (r *venueRepository) GetLocationByCode(ctx context.Context, code string) (*models.Location, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.locations
+		WHERE code = $1
+	`, locationColumns)
+
+	location := &models.Location{}
+	err := scanLocation(r.q.QueryRowContext(ctx, query, code), location)
+	if err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// ImportLocations creates or updates, by code, every location in one
+// transaction. Updated locations are reactivated.
+func (r *venueRepository) ImportLocations(ctx context.Context, locations []models.Location) (created, updated int, err error) {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	for _, location := range locations {
+		var inserted bool
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.locations (id, code, name, address, city, phone, email,
+				latitude, longitude, max_capacity, is_active)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10, true)
+			ON CONFLICT (code) DO UPDATE
+			SET name = EXCLUDED.name, address = EXCLUDED.address, city = EXCLUDED.city,
+			    phone = EXCLUDED.phone, email = EXCLUDED.email, latitude = EXCLUDED.latitude,
+			    longitude = EXCLUDED.longitude, max_capacity = EXCLUDED.max_capacity,
+			    is_active = true, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+			RETURNING (xmax = 0)
+		`, location.ID, location.Code, location.Name, location.Address, location.City, location.Phone,
+			location.Email, location.Latitude, location.Longitude, location.MaxCapacity).Scan(&inserted)
+		if err != nil {
+			return 0, 0, fmt.Errorf("location %s: %w", location.Code, err)
+		}
+		if inserted {
+			created++
+		} else {
+			updated++
+		}
+	}
+
+	return created, updated, tx.Commit()
+}
+
+// ImportTables creates the missing zones and then creates or updates, by
+// location and code, every table in one transaction. The status and QR token
+// of existing tables are kept.
+func (r *venueRepository) ImportTables(ctx context.Context, zones []models.Zone, tables []models.Table) (created, updated int, err error) {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	// A deactivated zone with the same name is revived instead, under its own ID
+	zoneIDs := make(map[string]string, len(zones))
+	for _, zone := range zones {
+		var id string
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.zones (id, location_id, name, is_smoking, environment, sort_order, is_active)
+			VALUES ($1, $2, $3, $4, $5, $6, true)
+			ON CONFLICT (location_id, name) DO UPDATE
+			SET is_active = true, updated_at = CURRENT_TIMESTAMP
+			RETURNING id
+		`, zone.ID, zone.LocationID, zone.Name, zone.IsSmoking, zone.Environment, zone.SortOrder).Scan(&id)
+		if err != nil {
+			return 0, 0, fmt.Errorf("zone %s: %w", zone.Name, err)
+		}
+		zoneIDs[zone.ID] = id
+	}
+
+	for _, table := range tables {
+		zoneID := table.ZoneID
+		if zoneID != nil {
+			if id, ok := zoneIDs[*zoneID]; ok {
+				zoneID = &id
+			}
+		}
+
+		var inserted bool
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.tables (id, location_id, zone_id, code, seats, status,
+				pos_x, pos_y, rotation, shape, width, height, is_active, qr_token, qr_rotated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, true, $13, $14)
+			ON CONFLICT (location_id, code) DO UPDATE
+			SET zone_id = EXCLUDED.zone_id, seats = EXCLUDED.seats, pos_x = EXCLUDED.pos_x,
+			    pos_y = EXCLUDED.pos_y, rotation = EXCLUDED.rotation, shape = EXCLUDED.shape,
+			    width = EXCLUDED.width, height = EXCLUDED.height, is_active = true,
+			    deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+			RETURNING (xmax = 0)
+		`, table.ID, table.LocationID, zoneID, table.Code, table.Seats, table.Status, table.PosX,
+			table.PosY, table.Rotation, table.Shape, table.Width, table.Height, table.QRToken,
+			table.QRRotatedAt).Scan(&inserted)
+		if err != nil {
+			return 0, 0, fmt.Errorf("table %s: %w", table.Code, err)
+		}
+		if inserted {
+			created++
+		} else {
+			updated++
+		}
+	}
+
+	return created, updated, tx.Commit()
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"ms-venue-go/internal/models"
+)
+
+// Clone operations
+
+// CloneLocation creates the location and all its copied zones, tables,
+// settings and opening hours in one transaction.
+func (r *venueRepository) CloneLocation(ctx context.Context, clone *models.LocationClone) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	location := clone.Location
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bar_system.locations (id, code, name, address, city, phone, email,
+			latitude, longitude, max_capacity, manager_id, is_active, canvas_width, canvas_height)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10, $11, $12, $13, $14)
+		RETURNING created_at, updated_at, version
+	`, location.ID, location.Code, location.Name, location.Address, location.City, location.Phone,
+		location.Email, location.Latitude, location.Longitude, location.MaxCapacity, location.ManagerID,
+		location.IsActive, clone.Canvas.Width, clone.Canvas.Height).Scan(&location.CreatedAt, &location.UpdatedAt,
+		&location.Version)
+	if err != nil {
+		return translateError(err)
+	}
+
+	for i := range clone.Zones {
+		zone := &clone.Zones[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.zones (id, location_id, name, is_smoking, environment, sort_order, is_active)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING created_at, updated_at
+		`, zone.ID, zone.LocationID, zone.Name, zone.IsSmoking, zone.Environment, zone.SortOrder,
+			zone.IsActive).Scan(&zone.CreatedAt, &zone.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, zone := range clone.ZoneCanvases {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE bar_system.zones
+			SET canvas_width = $1, canvas_height = $2
+			WHERE id = $3
+		`, zone.Width, zone.Height, zone.ZoneID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range clone.Tables {
+		table := &clone.Tables[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.tables (id, location_id, zone_id, code, seats, status,
+				pos_x, pos_y, rotation, shape, width, height, is_active, qr_token, qr_rotated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			RETURNING created_at, updated_at
+		`, table.ID, table.LocationID, table.ZoneID, table.Code, table.Seats, table.Status, table.PosX,
+			table.PosY, table.Rotation, table.Shape, table.Width, table.Height, table.IsActive,
+			table.QRToken, table.QRRotatedAt).Scan(&table.CreatedAt, &table.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings := clone.Settings; settings != nil {
+		taxProfiles, err := json.Marshal(settings.TaxProfiles)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO bar_system.location_settings (location_id, timezone, currency_code, tax_rate,
+				tax_profiles, service_charge_percent, receipt_header)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, settings.LocationID, settings.Timezone, settings.CurrencyCode, settings.TaxRate,
+			taxProfiles, settings.ServiceChargePercent, settings.ReceiptHeader)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, h := range clone.Hours {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO bar_system.location_hours (id, location_id, day_of_week, opens_at, closes_at)
+			VALUES ($1, $2, $3, $4::time, $5::time)
+		`, h.ID, h.LocationID, h.DayOfWeek, h.OpensAt, h.ClosesAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicate is returned when a write would give a second row the same
+// unique key, e.g. two locations with one code.
+var ErrDuplicate = errors.New("duplicate key")
+
+// ErrInvalidReference is returned when a write points at a row that does
+// not exist, e.g. a table in an unknown location.
+var ErrInvalidReference = errors.New("invalid reference")
+
+// ErrConflict is returned when a write no longer applies to the current
+// state of its rows, e.g. grouping a table that is already grouped.
+var ErrConflict = errors.New("conflicting state")
+
+// Postgres error codes the repository translates.
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqInvalidText         = "22P02"
+)
+
+// IsNotFound reports whether a lookup failed because the row does not
+// exist. A malformed ID cannot match any row and counts as not found.
+func IsNotFound(err error) bool {
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqInvalidText
+}
+
+// translateError maps constraint violations on writes to ErrDuplicate and
+// ErrInvalidReference so callers need not know Postgres error codes.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return fmt.Errorf("%w: %s", ErrDuplicate, pqErr.Constraint)
+	case pqForeignKeyViolation, pqInvalidText:
+		return fmt.Errorf("%w: %s", ErrInvalidReference, pqErr.Message)
+	}
+	return err
+}
+
+// insertError is translateError for inserts that use ON CONFLICT DO NOTHING
+// RETURNING, where a conflict shows up as sql.ErrNoRows.
+func insertError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDuplicate
+	}
+	return translateError(err)
+}
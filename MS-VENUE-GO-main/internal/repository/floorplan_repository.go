@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+)
+
+// Floor plan operations
+func (r *venueRepository) GetFloorPlan(ctx context.Context, locationID string) (*models.FloorPlan, error) {
+	plan := &models.FloorPlan{
+		LocationID: locationID,
+		Zones:      make([]models.ZoneCanvas, 0),
+	}
+
+	err := r.q.QueryRowContext(ctx, `
+		SELECT canvas_width, canvas_height
+		FROM bar_system.locations
+		WHERE id = $1
+	`, locationID).Scan(&plan.Canvas.Width, &plan.Canvas.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.q.QueryContext(ctx, `
+		SELECT id, canvas_width, canvas_height
+		FROM bar_system.zones
+		WHERE location_id = $1 AND is_active = true
+		  AND canvas_width IS NOT NULL AND canvas_height IS NOT NULL
+		ORDER BY sort_order, name
+	`, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var zone models.ZoneCanvas
+		if err := rows.Scan(&zone.ZoneID, &zone.Width, &zone.Height); err != nil {
+			return nil, err
+		}
+		plan.Zones = append(plan.Zones, zone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	plan.Tables, err = r.GetTablesByLocation(ctx, locationID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// SaveFloorPlan stores the canvases and the geometry of every table in the
+// plan in a single transaction, so a layout is never half applied.
+func (r *venueRepository) SaveFloorPlan(ctx context.Context, plan *models.FloorPlan) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.locations
+		SET canvas_width = $1, canvas_height = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, plan.Canvas.Width, plan.Canvas.Height, plan.LocationID)
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range plan.Zones {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE bar_system.zones
+			SET canvas_width = $1, canvas_height = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $3 AND location_id = $4
+		`, zone.Width, zone.Height, zone.ZoneID, plan.LocationID)
+		if err != nil {
+			return err
+		}
+		if err := expectOneRow(result, "zone", zone.ZoneID); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range plan.Tables {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE bar_system.tables
+			SET pos_x = $1, pos_y = $2, rotation = $3, shape = $4, width = $5, height = $6,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = $7 AND location_id = $8
+		`, table.PosX, table.PosY, table.Rotation, table.Shape, table.Width, table.Height,
+			table.ID, plan.LocationID)
+		if err != nil {
+			return err
+		}
+		if err := expectOneRow(result, "table", table.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func expectOneRow(result sql.Result, entity, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("%s %s not found in location", entity, id)
+	}
+	return nil
+}
+
+// expectUpdated turns an update that matched no row into sql.ErrNoRows.
+func expectUpdated(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,2129 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+// MemoryVenueRepository is a VenueRepository kept in memory, for tests and
+// local runs without Postgres. It follows the Postgres repository closely:
+// codes are unique per location (location codes globally), deletes are soft,
+// listings leave out inactive rows, every write to a location or table bumps
+// its version, references to missing rows fail with ErrInvalidReference and
+// lookups of missing rows with sql.ErrNoRows.
+//
+// Transactions hold the store lock until they end, so a WithTx callback must
+// only use the repository it is given.
+type MemoryVenueRepository struct {
+	store *memoryStore
+	tx    *memoryData // working copy when bound to a transaction by WithTx
+}
+
+// MemoryOrder is the part of an order, owned by the order service, that the
+// venue repository reads.
+type MemoryOrder struct {
+	ID         string
+	LocationID string
+	TableID    string
+	Status     string
+	CreatedAt  time.Time
+	ClosedAt   *time.Time
+}
+
+var _ VenueRepository = (*MemoryVenueRepository)(nil)
+
+type memoryStore struct {
+	mu        sync.Mutex
+	data      memoryData
+	listeners map[chan models.TableChange]struct{}
+}
+
+type memoryLocation struct {
+	models.Location
+	canvas models.Canvas
+	seq    int
+}
+
+type memoryZone struct {
+	models.Zone
+	canvas *models.Canvas
+}
+
+type memoryTableGroup struct {
+	models.TableGroup
+	seq int
+}
+
+type memoryAssignment struct {
+	models.WaiterAssignment
+	seq int
+}
+
+type memoryUser struct {
+	venueID *string
+}
+
+// memoryData holds every row. Rows are stored by value so copying the maps
+// is enough to snapshot the store.
+type memoryData struct {
+	seq          int
+	locations    map[string]memoryLocation
+	tables       map[string]models.Table
+	zones        map[string]memoryZone
+	groups       map[string]memoryTableGroup
+	history      []models.TableStatusChange
+	reservations map[string]models.Reservation
+	waitlist     map[string]models.WaitlistEntry
+	assignments  map[string]memoryAssignment
+	settings     map[string]models.LocationSettings
+	hours        []models.OpeningHours
+	exceptions   map[string]models.ScheduleException
+	users        map[string]memoryUser
+	orders       []MemoryOrder
+	// pending holds the table changes announced when the write commits
+	pending []models.TableChange
+}
+
+func NewMemoryVenueRepository() *MemoryVenueRepository {
+	return &MemoryVenueRepository{
+		store: &memoryStore{
+			data: memoryData{
+				locations:    make(map[string]memoryLocation),
+				tables:       make(map[string]models.Table),
+				zones:        make(map[string]memoryZone),
+				groups:       make(map[string]memoryTableGroup),
+				reservations: make(map[string]models.Reservation),
+				waitlist:     make(map[string]models.WaitlistEntry),
+				assignments:  make(map[string]memoryAssignment),
+				settings:     make(map[string]models.LocationSettings),
+				exceptions:   make(map[string]models.ScheduleException),
+				users:        make(map[string]memoryUser),
+			},
+			listeners: make(map[chan models.TableChange]struct{}),
+		},
+	}
+}
+
+// AddUser stores a staff member owned by the auth service, so assignments,
+// status changes and purges can refer to it. venueID may be nil.
+func (r *MemoryVenueRepository) AddUser(id string, venueID *string) {
+	r.write(func(d *memoryData) error {
+		d.users[id] = memoryUser{venueID: venueID}
+		return nil
+	})
+}
+
+// AddOrder stores an order owned by the order service.
+func (r *MemoryVenueRepository) AddOrder(order MemoryOrder) {
+	r.write(func(d *memoryData) error {
+		d.orders = append(d.orders, order)
+		return nil
+	})
+}
+
+func (d *memoryData) clone() memoryData {
+	c := *d
+	c.locations = cloneMap(d.locations)
+	c.tables = cloneMap(d.tables)
+	c.zones = cloneMap(d.zones)
+	c.groups = cloneMap(d.groups)
+	c.history = append([]models.TableStatusChange(nil), d.history...)
+	c.reservations = cloneMap(d.reservations)
+	c.waitlist = cloneMap(d.waitlist)
+	c.assignments = cloneMap(d.assignments)
+	c.settings = cloneMap(d.settings)
+	c.hours = append([]models.OpeningHours(nil), d.hours...)
+	c.exceptions = cloneMap(d.exceptions)
+	c.users = cloneMap(d.users)
+	c.orders = append([]MemoryOrder(nil), d.orders...)
+	c.pending = append([]models.TableChange(nil), d.pending...)
+	return c
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// read runs fn against the transaction's copy, or against the store under
+// its lock.
+func (r *MemoryVenueRepository) read(fn func(d *memoryData) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return fn(&r.store.data)
+}
+
+// write applies fn atomically: outside a transaction it works on a copy that
+// replaces the store only when fn succeeds, like a single statement or a
+// repository-level transaction in Postgres.
+func (r *MemoryVenueRepository) write(fn func(d *memoryData) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	return r.store.commit(fn)
+}
+
+// commit runs fn on a copy of the data under the store lock and keeps the
+// copy when fn succeeds.
+func (s *memoryStore) commit(fn func(d *memoryData) error) error {
+	var changes []models.TableChange
+	err := func() error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		work := s.data.clone()
+		if err := fn(&work); err != nil {
+			return err
+		}
+		changes, work.pending = work.pending, nil
+		s.data = work
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	s.announce(changes)
+	return nil
+}
+
+// announce hands committed table changes to the listeners, as NOTIFY does on
+// commit.
+func (s *memoryStore) announce(changes []models.TableChange) {
+	if len(changes) == 0 {
+		return
+	}
+	s.mu.Lock()
+	listeners := make([]chan models.TableChange, 0, len(s.listeners))
+	for listener := range s.listeners {
+		listeners = append(listeners, listener)
+	}
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		for _, change := range changes {
+			select {
+			case listener <- change:
+			default:
+				// A listener that fell behind resyncs, like after a reconnect
+				select {
+				case listener <- models.TableChange{Type: models.TableEventResync}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// now is CURRENT_TIMESTAMP at the precision Postgres stores.
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func (d *memoryData) nextSeq() int {
+	d.seq++
+	return d.seq
+}
+
+func invalidReference(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidReference, fmt.Sprintf(format, args...))
+}
+
+func duplicateKey(constraint string) error {
+	return fmt.Errorf("%w: %s", ErrDuplicate, constraint)
+}
+
+// Transactions
+
+// WithTx runs fn with a repository bound to a copy of the store, which
+// replaces the store when fn returns nil. Calls on a repository that is
+// already bound join its transaction.
+func (r *MemoryVenueRepository) WithTx(ctx context.Context, fn func(tx VenueRepository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	return r.store.commit(func(d *memoryData) error {
+		return fn(&MemoryVenueRepository{store: r.store, tx: d})
+	})
+}
+
+// Location operations
+func (r *MemoryVenueRepository) CreateLocation(ctx context.Context, location *models.Location) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locationByCode(location.Code); ok {
+			return ErrDuplicate
+		}
+		if err := d.checkUser(location.ManagerID); err != nil {
+			return err
+		}
+
+		t := now()
+		location.CreatedAt, location.UpdatedAt = t, t
+		location.DeletedAt = nil
+		location.Version = 1
+		d.locations[location.ID] = memoryLocation{
+			Location: *location,
+			canvas:   models.Canvas{Width: 1000, Height: 800},
+			seq:      d.nextSeq(),
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) LockLocation(ctx context.Context, id string) error {
+	return r.read(func(d *memoryData) error {
+		if _, ok := d.locations[id]; !ok {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetAllLocations(ctx context.Context, filter *models.LocationFilter) ([]models.Location, error) {
+	if filter == nil {
+		filter = &models.LocationFilter{}
+	}
+
+	locations := make([]models.Location, 0)
+	r.read(func(d *memoryData) error {
+		for _, location := range d.locations {
+			if filter.IsActive != nil {
+				if location.IsActive != *filter.IsActive {
+					continue
+				}
+			} else if !location.IsActive && !filter.IncludeInactive {
+				continue
+			}
+			if !strings.HasPrefix(location.Code, filter.CodePrefix) {
+				continue
+			}
+			locations = append(locations, location.Location)
+		}
+		return nil
+	})
+
+	sortKey, descending := filter.Sort, filter.Descending
+	if sortKey == "" {
+		sortKey, descending = models.LocationSortCreatedAt, true
+	}
+	var key func(models.Location) memorySortKey
+	switch sortKey {
+	case models.LocationSortCreatedAt:
+		key = func(l models.Location) memorySortKey { return memorySortKey{number: l.CreatedAt.UnixNano()} }
+	case models.LocationSortName:
+		key = func(l models.Location) memorySortKey { return memorySortKey{text: l.Name} }
+	case models.LocationSortCode:
+		key = func(l models.Location) memorySortKey { return memorySortKey{text: l.Code} }
+	default:
+		return nil, fmt.Errorf("unknown location sort %q", sortKey)
+	}
+	return paginate(locations, func(l models.Location) (memorySortKey, string) { return key(l), l.ID },
+		sortKey == models.LocationSortCreatedAt, descending, filter.After, filter.Limit)
+}
+
+func (r *MemoryVenueRepository) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	var location models.Location
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.locations[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		location = row.Location
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *MemoryVenueRepository) UpdateLocation(ctx context.Context, id string, location *models.Location) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.locations[id]
+		if !ok || row.Version != location.Version {
+			return sql.ErrNoRows
+		}
+		if other, ok := d.locationByCode(location.Code); ok && other.ID != id {
+			return duplicateKey("locations_code_key")
+		}
+		if err := d.checkUser(location.ManagerID); err != nil {
+			return err
+		}
+
+		row.Code, row.Name, row.Address, row.City = location.Code, location.Name, location.Address, location.City
+		row.Phone, row.Email = location.Phone, location.Email
+		row.Latitude, row.Longitude, row.MaxCapacity = location.Latitude, location.Longitude, location.MaxCapacity
+		row.ManagerID, row.IsActive = location.ManagerID, location.IsActive
+		d.touchLocation(&row, now())
+		d.locations[id] = row
+
+		location.UpdatedAt, location.Version = row.UpdatedAt, row.Version
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) DeleteLocation(ctx context.Context, id string, version int) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.locations[id]
+		if !ok || !row.IsActive || row.Version != version {
+			return sql.ErrNoRows
+		}
+
+		t := now()
+		row.IsActive = false
+		row.DeletedAt = &t
+		d.touchLocation(&row, t)
+		d.locations[id] = row
+
+		for groupID, group := range d.groups {
+			if group.LocationID == id && group.DissolvedAt == nil {
+				group.DissolvedAt = &t
+				d.groups[groupID] = group
+			}
+		}
+
+		for _, table := range d.tables {
+			if table.LocationID == id && table.IsActive {
+				updated := table
+				updated.IsActive = false
+				updated.GroupID = nil
+				updated.DeletedAt = &t
+				d.updateTable(table, updated, t)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) RestoreLocation(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.locations[id]
+		if !ok {
+			return nil
+		}
+
+		t := now()
+		for _, table := range d.tables {
+			if table.LocationID == id && !table.IsActive && sameTime(table.DeletedAt, row.DeletedAt) {
+				updated := table
+				updated.IsActive = true
+				updated.DeletedAt = nil
+				d.updateTable(table, updated, t)
+			}
+		}
+
+		row.IsActive = true
+		row.DeletedAt = nil
+		d.touchLocation(&row, t)
+		d.locations[id] = row
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) PurgeLocation(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		delete(d.locations, id)
+		for tableID, table := range d.tables {
+			if table.LocationID == id {
+				d.deleteTable(tableID)
+			}
+		}
+		for zoneID, zone := range d.zones {
+			if zone.LocationID == id {
+				delete(d.zones, zoneID)
+			}
+		}
+		for groupID, group := range d.groups {
+			if group.LocationID == id {
+				delete(d.groups, groupID)
+			}
+		}
+		for entryID, entry := range d.waitlist {
+			if entry.LocationID == id {
+				delete(d.waitlist, entryID)
+			}
+		}
+		for reservationID, reservation := range d.reservations {
+			if reservation.LocationID == id {
+				delete(d.reservations, reservationID)
+			}
+		}
+		for assignmentID, assignment := range d.assignments {
+			if assignment.LocationID == id {
+				delete(d.assignments, assignmentID)
+			}
+		}
+		for exceptionID, exception := range d.exceptions {
+			if exception.LocationID == id {
+				delete(d.exceptions, exceptionID)
+			}
+		}
+		delete(d.settings, id)
+		d.hours = filterHours(d.hours, id)
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) CountLocationReferences(ctx context.Context, id string) (orders, staff int, err error) {
+	r.read(func(d *memoryData) error {
+		for _, order := range d.orders {
+			if order.LocationID == id {
+				orders++
+			}
+		}
+		for _, user := range d.users {
+			if user.venueID != nil && *user.venueID == id {
+				staff++
+			}
+		}
+		return nil
+	})
+	return orders, staff, nil
+}
+
+// Table operations
+func (r *MemoryVenueRepository) CreateTable(ctx context.Context, table *models.Table) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.tableByCode(table.LocationID, table.Code); ok {
+			return ErrDuplicate
+		}
+		return d.insertTable(table, now())
+	})
+}
+
+func (r *MemoryVenueRepository) GetTablesByLocation(ctx context.Context, locationID string, filter *models.TableFilter) ([]models.Table, error) {
+	if filter == nil {
+		filter = &models.TableFilter{}
+	}
+
+	tables := make([]models.Table, 0)
+	r.read(func(d *memoryData) error {
+		for _, table := range d.tables {
+			if table.LocationID != locationID {
+				continue
+			}
+			if filter.IsActive != nil {
+				if table.IsActive != *filter.IsActive {
+					continue
+				}
+			} else if !table.IsActive && !filter.IncludeInactive {
+				continue
+			}
+			if filter.ZoneID != nil && !equalID(table.ZoneID, *filter.ZoneID) {
+				continue
+			}
+			if len(filter.StoredStatuses) > 0 && !slices.Contains(filter.StoredStatuses, table.Status) {
+				continue
+			}
+			if (filter.SeatsMin != nil && table.Seats < *filter.SeatsMin) || (filter.SeatsMax != nil && table.Seats > *filter.SeatsMax) {
+				continue
+			}
+			if !strings.HasPrefix(table.Code, filter.CodePrefix) {
+				continue
+			}
+			tables = append(tables, table)
+		}
+		return nil
+	})
+
+	sortKey := filter.Sort
+	if sortKey == "" {
+		sortKey = models.TableSortCode
+	}
+	var key func(models.Table) memorySortKey
+	switch sortKey {
+	case models.TableSortCode:
+		key = func(t models.Table) memorySortKey { return memorySortKey{text: t.Code} }
+	case models.TableSortSeats:
+		key = func(t models.Table) memorySortKey { return memorySortKey{number: int64(t.Seats)} }
+	case models.TableSortCreatedAt:
+		key = func(t models.Table) memorySortKey { return memorySortKey{number: t.CreatedAt.UnixNano()} }
+	default:
+		return nil, fmt.Errorf("unknown table sort %q", sortKey)
+	}
+	return paginate(tables, func(t models.Table) (memorySortKey, string) { return key(t), t.ID },
+		sortKey != models.TableSortCode, filter.Descending, filter.After, filter.Limit)
+}
+
+func (r *MemoryVenueRepository) LockTable(ctx context.Context, id string) error {
+	return r.read(func(d *memoryData) error {
+		if _, ok := d.tables[id]; !ok {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetTableByID(ctx context.Context, id string) (*models.Table, error) {
+	var table models.Table
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.tables[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		table = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *MemoryVenueRepository) UpdateTable(ctx context.Context, id string, table *models.Table) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.tables[id]
+		if !ok || row.Version != table.Version {
+			return sql.ErrNoRows
+		}
+		if other, ok := d.tableByCode(row.LocationID, table.Code); ok && other.ID != id {
+			return duplicateKey("tables_location_id_code_key")
+		}
+		if err := d.checkZone(table.ZoneID); err != nil {
+			return err
+		}
+
+		updated := row
+		updated.Code, updated.ZoneID, updated.Seats, updated.IsActive = table.Code, table.ZoneID, table.Seats, table.IsActive
+		updated = d.updateTable(row, updated, now())
+
+		table.UpdatedAt, table.Version = updated.UpdatedAt, updated.Version
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) DeleteTable(ctx context.Context, id string, version int) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.tables[id]
+		if !ok || row.Version != version {
+			return sql.ErrNoRows
+		}
+
+		t := now()
+		updated := row
+		updated.IsActive = false
+		updated.DeletedAt = &t
+		d.updateTable(row, updated, t)
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) RestoreTable(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.tables[id]
+		if !ok {
+			return nil
+		}
+
+		updated := row
+		updated.IsActive = true
+		updated.DeletedAt = nil
+		d.updateTable(row, updated, now())
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) PurgeTable(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		d.deleteTable(id)
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) CountTableOrders(ctx context.Context, id string) (int, error) {
+	var count int
+	r.read(func(d *memoryData) error {
+		for _, order := range d.orders {
+			if order.TableID == id {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, nil
+}
+
+// Table event operations
+
+// ListenTableEvents calls notify for every committed table write until ctx
+// is done.
+func (r *MemoryVenueRepository) ListenTableEvents(ctx context.Context, notify func(models.TableChange)) error {
+	changes := make(chan models.TableChange, 64)
+
+	r.store.mu.Lock()
+	r.store.listeners[changes] = struct{}{}
+	r.store.mu.Unlock()
+
+	defer func() {
+		r.store.mu.Lock()
+		delete(r.store.listeners, changes)
+		r.store.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change := <-changes:
+			notify(change)
+		}
+	}
+}
+
+// Table QR operations
+func (r *MemoryVenueRepository) SetTableQRToken(ctx context.Context, tableID, token string) (time.Time, error) {
+	var rotatedAt time.Time
+	err := r.write(func(d *memoryData) error {
+		row, ok := d.tables[tableID]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		if other, ok := d.tableByQRToken(token); ok && other.ID != tableID {
+			return duplicateKey("idx_tables_qr_token")
+		}
+
+		rotatedAt = now()
+		updated := row
+		updated.QRToken = &token
+		updated.QRRotatedAt = &rotatedAt
+		d.updateTable(row, updated, rotatedAt)
+		return nil
+	})
+	return rotatedAt, err
+}
+
+func (r *MemoryVenueRepository) GetTableByQRToken(ctx context.Context, token string) (*models.Table, error) {
+	var table models.Table
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.tableByQRToken(token)
+		if !ok {
+			return sql.ErrNoRows
+		}
+		table = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// Bulk operations
+func (r *MemoryVenueRepository) GetLocationByCode(ctx context.Context, code string) (*models.Location, error) {
+	var location models.Location
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.locationByCode(code)
+		if !ok {
+			return sql.ErrNoRows
+		}
+		location = row.Location
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *MemoryVenueRepository) ImportLocations(ctx context.Context, locations []models.Location) (created, updated int, err error) {
+	err = r.write(func(d *memoryData) error {
+		t := now()
+		for _, location := range locations {
+			row, ok := d.locationByCode(location.Code)
+			if !ok {
+				location.IsActive = true
+				location.ManagerID = nil
+				location.CreatedAt, location.UpdatedAt = t, t
+				location.DeletedAt = nil
+				location.Version = 1
+				d.locations[location.ID] = memoryLocation{
+					Location: location,
+					canvas:   models.Canvas{Width: 1000, Height: 800},
+					seq:      d.nextSeq(),
+				}
+				created++
+				continue
+			}
+
+			row.Name, row.Address, row.City = location.Name, location.Address, location.City
+			row.Phone, row.Email = location.Phone, location.Email
+			row.Latitude, row.Longitude, row.MaxCapacity = location.Latitude, location.Longitude, location.MaxCapacity
+			row.IsActive = true
+			row.DeletedAt = nil
+			d.touchLocation(&row, t)
+			d.locations[row.ID] = row
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
+
+func (r *MemoryVenueRepository) ImportTables(ctx context.Context, zones []models.Zone, tables []models.Table) (created, updated int, err error) {
+	err = r.write(func(d *memoryData) error {
+		t := now()
+
+		// A deactivated zone with the same name is revived instead, under its own ID
+		zoneIDs := make(map[string]string, len(zones))
+		for _, zone := range zones {
+			if row, ok := d.zoneByName(zone.LocationID, zone.Name); ok {
+				row.IsActive = true
+				row.UpdatedAt = t
+				d.zones[row.ID] = row
+				zoneIDs[zone.ID] = row.ID
+				continue
+			}
+			zone.IsActive = true
+			if err := d.insertZone(&zone, t); err != nil {
+				return fmt.Errorf("zone %s: %w", zone.Name, err)
+			}
+			zoneIDs[zone.ID] = zone.ID
+		}
+
+		for _, table := range tables {
+			if table.ZoneID != nil {
+				if id, ok := zoneIDs[*table.ZoneID]; ok {
+					table.ZoneID = &id
+				}
+			}
+
+			row, ok := d.tableByCode(table.LocationID, table.Code)
+			if !ok {
+				table.IsActive = true
+				if err := d.insertTable(&table, t); err != nil {
+					return fmt.Errorf("table %s: %w", table.Code, err)
+				}
+				created++
+				continue
+			}
+
+			if err := d.checkZone(table.ZoneID); err != nil {
+				return fmt.Errorf("table %s: %w", table.Code, err)
+			}
+			next := row
+			next.ZoneID, next.Seats = table.ZoneID, table.Seats
+			next.PosX, next.PosY, next.Rotation = table.PosX, table.PosY, table.Rotation
+			next.Shape, next.Width, next.Height = table.Shape, table.Width, table.Height
+			next.IsActive = true
+			next.DeletedAt = nil
+			d.updateTable(row, next, t)
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
+
+// Clone operations
+func (r *MemoryVenueRepository) CloneLocation(ctx context.Context, clone *models.LocationClone) error {
+	return r.write(func(d *memoryData) error {
+		t := now()
+
+		location := clone.Location
+		if _, ok := d.locationByCode(location.Code); ok {
+			return duplicateKey("locations_code_key")
+		}
+		if err := d.checkUser(location.ManagerID); err != nil {
+			return err
+		}
+		location.CreatedAt, location.UpdatedAt = t, t
+		location.DeletedAt = nil
+		location.Version = 1
+		d.locations[location.ID] = memoryLocation{Location: *location, canvas: clone.Canvas, seq: d.nextSeq()}
+
+		for i := range clone.Zones {
+			if err := d.insertZone(&clone.Zones[i], t); err != nil {
+				return err
+			}
+		}
+
+		for _, canvas := range clone.ZoneCanvases {
+			if zone, ok := d.zones[canvas.ZoneID]; ok {
+				zoneCanvas := canvas.Canvas
+				zone.canvas = &zoneCanvas
+				d.zones[zone.ID] = zone
+			}
+		}
+
+		for i := range clone.Tables {
+			if err := d.insertTable(&clone.Tables[i], t); err != nil {
+				return err
+			}
+		}
+
+		if settings := clone.Settings; settings != nil {
+			if _, ok := d.settings[settings.LocationID]; ok {
+				return duplicateKey("location_settings_pkey")
+			}
+			if err := d.saveSettings(settings, t); err != nil {
+				return err
+			}
+		}
+
+		for _, h := range clone.Hours {
+			if err := d.insertHours(h, h.LocationID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Settings operations
+func (r *MemoryVenueRepository) GetLocationSettings(ctx context.Context, locationIDs []string) (map[string]*models.LocationSettings, error) {
+	settingsByLocation := make(map[string]*models.LocationSettings, len(locationIDs))
+	r.read(func(d *memoryData) error {
+		for _, id := range locationIDs {
+			if settings, ok := d.settings[id]; ok {
+				settings.TaxProfiles = cloneTaxProfiles(settings.TaxProfiles)
+				settingsByLocation[id] = &settings
+			}
+		}
+		return nil
+	})
+	return settingsByLocation, nil
+}
+
+func (r *MemoryVenueRepository) SaveLocationSettings(ctx context.Context, settings *models.LocationSettings) error {
+	return r.write(func(d *memoryData) error {
+		return d.saveSettings(settings, now())
+	})
+}
+
+// Schedule operations
+func (r *MemoryVenueRepository) GetLocationSchedules(ctx context.Context, locationIDs []string, fromDate string) (map[string]*models.LocationSchedule, error) {
+	schedules := make(map[string]*models.LocationSchedule, len(locationIDs))
+	for _, id := range locationIDs {
+		schedules[id] = &models.LocationSchedule{
+			LocationID: id,
+			Hours:      make([]models.OpeningHours, 0),
+			Exceptions: make([]models.ScheduleException, 0),
+		}
+	}
+
+	r.read(func(d *memoryData) error {
+		for _, hours := range d.hours {
+			if schedule, ok := schedules[hours.LocationID]; ok {
+				schedule.Hours = append(schedule.Hours, hours)
+			}
+		}
+		for _, exception := range d.exceptions {
+			if schedule, ok := schedules[exception.LocationID]; ok && exception.EndDate >= fromDate {
+				schedule.Exceptions = append(schedule.Exceptions, exception)
+			}
+		}
+		return nil
+	})
+
+	for _, schedule := range schedules {
+		hours := schedule.Hours
+		sort.SliceStable(hours, func(i, j int) bool {
+			if hours[i].DayOfWeek != hours[j].DayOfWeek {
+				return hours[i].DayOfWeek < hours[j].DayOfWeek
+			}
+			return hours[i].OpensAt < hours[j].OpensAt
+		})
+		exceptions := schedule.Exceptions
+		sort.Slice(exceptions, func(i, j int) bool {
+			if exceptions[i].StartDate != exceptions[j].StartDate {
+				return exceptions[i].StartDate < exceptions[j].StartDate
+			}
+			return exceptions[i].ID < exceptions[j].ID
+		})
+	}
+
+	return schedules, nil
+}
+
+func (r *MemoryVenueRepository) ReplaceOpeningHours(ctx context.Context, locationID string, hours []models.OpeningHours) error {
+	return r.write(func(d *memoryData) error {
+		d.hours = filterHours(d.hours, locationID)
+		for _, h := range hours {
+			if err := d.insertHours(h, locationID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) CreateScheduleException(ctx context.Context, exception *models.ScheduleException) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locations[exception.LocationID]; !ok {
+			return invalidReference("location %s does not exist", exception.LocationID)
+		}
+		if _, ok := d.exceptions[exception.ID]; ok {
+			return duplicateKey("location_schedule_exceptions_pkey")
+		}
+
+		exception.OpensAt = normalizeClockPtr(exception.OpensAt)
+		exception.ClosesAt = normalizeClockPtr(exception.ClosesAt)
+		exception.CreatedAt = now()
+		d.exceptions[exception.ID] = *exception
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetScheduleExceptionByID(ctx context.Context, id string) (*models.ScheduleException, error) {
+	var exception models.ScheduleException
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.exceptions[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		exception = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
+func (r *MemoryVenueRepository) DeleteScheduleException(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.exceptions[id]; !ok {
+			return fmt.Errorf("schedule exception %s not found in location", id)
+		}
+		delete(d.exceptions, id)
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) HasOverlappingScheduleException(ctx context.Context, locationID, startDate, endDate string) (bool, error) {
+	var exists bool
+	r.read(func(d *memoryData) error {
+		for _, exception := range d.exceptions {
+			if exception.LocationID == locationID && exception.StartDate <= endDate && exception.EndDate >= startDate {
+				exists = true
+			}
+		}
+		return nil
+	})
+	return exists, nil
+}
+
+// Zone operations
+func (r *MemoryVenueRepository) CreateZone(ctx context.Context, zone *models.Zone) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.zoneByName(zone.LocationID, zone.Name); ok {
+			return ErrDuplicate
+		}
+		return d.insertZone(zone, now())
+	})
+}
+
+func (r *MemoryVenueRepository) GetZonesByLocation(ctx context.Context, locationID string) ([]models.Zone, error) {
+	var rows []memoryZone
+	r.read(func(d *memoryData) error {
+		for _, zone := range d.zones {
+			if zone.LocationID == locationID && zone.IsActive {
+				rows = append(rows, zone)
+			}
+		}
+		return nil
+	})
+
+	sortZones(rows)
+	zones := make([]models.Zone, 0, len(rows))
+	for _, row := range rows {
+		zones = append(zones, row.Zone)
+	}
+	return zones, nil
+}
+
+func (r *MemoryVenueRepository) GetZoneByID(ctx context.Context, id string) (*models.Zone, error) {
+	var zone models.Zone
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.zones[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		zone = row.Zone
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+func (r *MemoryVenueRepository) UpdateZone(ctx context.Context, id string, zone *models.Zone) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.zones[id]
+		if !ok {
+			return nil
+		}
+		if other, ok := d.zoneByName(row.LocationID, zone.Name); ok && other.ID != id {
+			return duplicateKey("zones_location_id_name_key")
+		}
+
+		row.Name, row.IsSmoking, row.Environment = zone.Name, zone.IsSmoking, zone.Environment
+		row.SortOrder, row.IsActive = zone.SortOrder, zone.IsActive
+		row.UpdatedAt = now()
+		d.zones[id] = row
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) DeleteZone(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		t := now()
+		if row, ok := d.zones[id]; ok {
+			row.IsActive = false
+			row.UpdatedAt = t
+			d.zones[id] = row
+		}
+
+		for _, table := range d.tables {
+			if equalID(table.ZoneID, id) {
+				updated := table
+				updated.ZoneID = nil
+				d.updateTable(table, updated, t)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetOccupancyByZone(ctx context.Context, locationID string) ([]models.ZoneOccupancy, error) {
+	type zoneTotals struct {
+		models.ZoneOccupancy
+		sortOrder *int
+	}
+
+	byZone := make(map[string]*zoneTotals)
+	r.read(func(d *memoryData) error {
+		for _, table := range d.tables {
+			if table.LocationID != locationID || !table.IsActive {
+				continue
+			}
+
+			key := ""
+			if table.ZoneID != nil {
+				key = *table.ZoneID
+			}
+			totals, ok := byZone[key]
+			if !ok {
+				totals = &zoneTotals{}
+				if zone, ok := d.zones[key]; ok {
+					sortOrder := zone.SortOrder
+					totals.ZoneID, totals.ZoneName, totals.sortOrder = zone.ID, zone.Name, &sortOrder
+				}
+				byZone[key] = totals
+			}
+
+			totals.TotalTables++
+			totals.TotalSeats += table.Seats
+			switch table.Status {
+			case models.TableStatusAvailable:
+				totals.Available++
+			case models.TableStatusOccupied:
+				totals.Occupied++
+				totals.OccupiedSeats += table.Seats
+			case models.TableStatusNeedsCleaning:
+				totals.NeedsCleaning++
+			case models.TableStatusReserved:
+				totals.Reserved++
+			}
+		}
+		return nil
+	})
+
+	rows := make([]*zoneTotals, 0, len(byZone))
+	for _, totals := range byZone {
+		rows = append(rows, totals)
+	}
+	// sort_order NULLS LAST, name
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if (a.sortOrder == nil) != (b.sortOrder == nil) {
+			return b.sortOrder == nil
+		}
+		if a.sortOrder != nil && *a.sortOrder != *b.sortOrder {
+			return *a.sortOrder < *b.sortOrder
+		}
+		return a.ZoneName < b.ZoneName
+	})
+
+	occupancy := make([]models.ZoneOccupancy, 0, len(rows))
+	for _, totals := range rows {
+		occupancy = append(occupancy, totals.ZoneOccupancy)
+	}
+	return occupancy, nil
+}
+
+// Table group operations
+func (r *MemoryVenueRepository) CreateTableGroup(ctx context.Context, group *models.TableGroup, tableIDs []string, status string) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locations[group.LocationID]; !ok {
+			return invalidReference("location %s does not exist", group.LocationID)
+		}
+
+		t := now()
+		group.CreatedAt = t
+		group.DissolvedAt = nil
+		d.groups[group.ID] = memoryTableGroup{TableGroup: models.TableGroup{
+			ID:         group.ID,
+			LocationID: group.LocationID,
+			Code:       group.Code,
+			CreatedAt:  group.CreatedAt,
+		}, seq: d.nextSeq()}
+
+		// Like ANY($2), a table listed twice is only attached once
+		attached := 0
+		seen := make(map[string]bool, len(tableIDs))
+		for _, id := range tableIDs {
+			table, ok := d.tables[id]
+			if seen[id] || !ok || table.LocationID != group.LocationID || !table.IsActive || table.GroupID != nil || table.Status != status {
+				continue
+			}
+			seen[id] = true
+			updated := table
+			updated.GroupID = &group.ID
+			d.updateTable(table, updated, t)
+			attached++
+		}
+		if attached != len(tableIDs) {
+			return fmt.Errorf("%w: some tables are inactive, in another location, already grouped or no longer %s", ErrConflict, status)
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetTableGroupsByLocation(ctx context.Context, locationID string) ([]models.TableGroup, error) {
+	var rows []memoryTableGroup
+	r.read(func(d *memoryData) error {
+		for _, group := range d.groups {
+			if group.LocationID == locationID && group.DissolvedAt == nil {
+				group.Tables = d.groupMembers(group.ID)
+				rows = append(rows, group)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+	groups := make([]models.TableGroup, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, row.TableGroup)
+	}
+	return groups, nil
+}
+
+func (r *MemoryVenueRepository) GetTableGroupByID(ctx context.Context, id string) (*models.TableGroup, error) {
+	var group models.TableGroup
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.groups[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		group = row.TableGroup
+		group.Tables = d.groupMembers(id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *MemoryVenueRepository) DissolveTableGroup(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		group, ok := d.groups[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		if group.DissolvedAt != nil {
+			return fmt.Errorf("%w: table group is already dissolved", ErrConflict)
+		}
+
+		t := now()
+		group.DissolvedAt = &t
+		d.groups[id] = group
+
+		for _, table := range d.tables {
+			if equalID(table.GroupID, id) {
+				updated := table
+				updated.GroupID = nil
+				d.updateTable(table, updated, t)
+			}
+		}
+		return nil
+	})
+}
+
+// Waiter assignment operations
+func (r *MemoryVenueRepository) CreateWaiterAssignment(ctx context.Context, assignment *models.WaiterAssignment) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locations[assignment.LocationID]; !ok {
+			return invalidReference("location %s does not exist", assignment.LocationID)
+		}
+		if err := d.checkUser(&assignment.WaiterID); err != nil {
+			return err
+		}
+		if err := d.checkUser(assignment.AssignedBy); err != nil {
+			return err
+		}
+		if err := d.checkZone(assignment.ZoneID); err != nil {
+			return err
+		}
+
+		tableIDs := make([]string, 0, len(assignment.TableIDs))
+		seen := make(map[string]bool, len(assignment.TableIDs))
+		for _, tableID := range assignment.TableIDs {
+			if _, ok := d.tables[tableID]; !ok {
+				return invalidReference("table %s does not exist", tableID)
+			}
+			if seen[tableID] {
+				return duplicateKey("waiter_assignment_tables_pkey")
+			}
+			seen[tableID] = true
+			tableIDs = append(tableIDs, tableID)
+		}
+		sort.Strings(tableIDs)
+
+		assignment.CreatedAt = now()
+		row := *assignment
+		row.TableIDs = tableIDs
+		d.assignments[assignment.ID] = memoryAssignment{WaiterAssignment: row, seq: d.nextSeq()}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetWaiterAssignments(ctx context.Context, filter *models.WaiterAssignmentFilter) ([]models.WaiterAssignment, error) {
+	var rows []memoryAssignment
+	r.read(func(d *memoryData) error {
+		for _, assignment := range d.assignments {
+			switch {
+			case assignment.RemovedAt != nil:
+			case filter.LocationID != nil && assignment.LocationID != *filter.LocationID:
+			case filter.WaiterID != nil && assignment.WaiterID != *filter.WaiterID:
+			case filter.From != nil && !assignment.ShiftEnd.After(*filter.From):
+			case filter.To != nil && !assignment.ShiftStart.Before(*filter.To):
+			default:
+				rows = append(rows, assignment)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].ShiftStart.Equal(rows[j].ShiftStart) {
+			return rows[i].ShiftStart.Before(rows[j].ShiftStart)
+		}
+		return rows[i].seq < rows[j].seq
+	})
+
+	assignments := make([]models.WaiterAssignment, 0, len(rows))
+	for _, row := range rows {
+		assignment := row.WaiterAssignment
+		assignment.TableIDs = append(make([]string, 0, len(row.TableIDs)), row.TableIDs...)
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+func (r *MemoryVenueRepository) GetWaiterAssignmentByID(ctx context.Context, id string) (*models.WaiterAssignment, error) {
+	var assignment models.WaiterAssignment
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.assignments[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		assignment = row.WaiterAssignment
+		assignment.TableIDs = append(make([]string, 0, len(row.TableIDs)), row.TableIDs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *MemoryVenueRepository) RemoveWaiterAssignment(ctx context.Context, id string) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.assignments[id]
+		if !ok || row.RemovedAt != nil {
+			return sql.ErrNoRows
+		}
+		t := now()
+		row.RemovedAt = &t
+		d.assignments[id] = row
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetUserVenueID(ctx context.Context, userID string) (*string, error) {
+	var venueID *string
+	err := r.read(func(d *memoryData) error {
+		user, ok := d.users[userID]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		venueID = user.venueID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return venueID, nil
+}
+
+// Waitlist operations
+func (r *MemoryVenueRepository) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locations[entry.LocationID]; !ok {
+			return invalidReference("location %s does not exist", entry.LocationID)
+		}
+
+		position := 0
+		for _, other := range d.waitlist {
+			if other.LocationID == entry.LocationID && isQueued(other.Status) && other.Position > position {
+				position = other.Position
+			}
+		}
+
+		t := now()
+		entry.Position = position + 1
+		entry.JoinedAt, entry.UpdatedAt = t, t
+		d.waitlist[entry.ID] = *entry
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetWaitlistByLocation(ctx context.Context, locationID string) ([]models.WaitlistEntry, error) {
+	entries := make([]models.WaitlistEntry, 0)
+	r.read(func(d *memoryData) error {
+		for _, entry := range d.waitlist {
+			if entry.LocationID == locationID && isQueued(entry.Status) {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Position != entries[j].Position {
+			return entries[i].Position < entries[j].Position
+		}
+		return entries[i].JoinedAt.Before(entries[j].JoinedAt)
+	})
+	return entries, nil
+}
+
+func (r *MemoryVenueRepository) GetWaitlistEntryByID(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.waitlist[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		entry = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *MemoryVenueRepository) UpdateWaitlistEntry(ctx context.Context, id string, entry *models.WaitlistEntry) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.waitlist[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row.Status, row.NotifiedAt, row.Notes = entry.Status, entry.NotifiedAt, entry.Notes
+		row.UpdatedAt = now()
+		d.waitlist[id] = row
+
+		entry.UpdatedAt = row.UpdatedAt
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) ReorderWaitlist(ctx context.Context, locationID string, entryIDs []string) error {
+	return r.write(func(d *memoryData) error {
+		t := now()
+		for i, id := range entryIDs {
+			entry, ok := d.waitlist[id]
+			if !ok || entry.LocationID != locationID || !isQueued(entry.Status) {
+				return fmt.Errorf("waitlist entry %s not found in location", id)
+			}
+			entry.Position = i + 1
+			entry.UpdatedAt = t
+			d.waitlist[id] = entry
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) SeatWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry, change *models.TableStatusChange) error {
+	return r.write(func(d *memoryData) error {
+		if err := d.applyTableStatusChanges(change); err != nil {
+			return err
+		}
+
+		row, ok := d.waitlist[entry.ID]
+		if !ok || !isQueued(row.Status) {
+			return fmt.Errorf("waitlist entry %s not found in location", entry.ID)
+		}
+		tableID, seatedAt := change.TableID, change.ChangedAt
+		row.Status = models.WaitlistStatusSeated
+		row.TableID = &tableID
+		row.SeatedAt = &seatedAt
+		row.UpdatedAt = now()
+		d.waitlist[entry.ID] = row
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetAverageTurnMinutes(ctx context.Context, locationID string, minSeats int, since time.Time) (float64, error) {
+	var total float64
+	var count int
+	r.read(func(d *memoryData) error {
+		for _, order := range d.orders {
+			if order.LocationID != locationID || order.Status != "closed" || order.ClosedAt == nil {
+				continue
+			}
+			if order.CreatedAt.Before(since) {
+				continue
+			}
+			if table, ok := d.tables[order.TableID]; !ok || table.Seats < minSeats {
+				continue
+			}
+			total += order.ClosedAt.Sub(order.CreatedAt).Minutes()
+			count++
+		}
+		return nil
+	})
+
+	if count == 0 {
+		return 0, nil
+	}
+	return total / float64(count), nil
+}
+
+func (r *MemoryVenueRepository) GetOpenOrderStartTimes(ctx context.Context, locationID string) (map[string]time.Time, error) {
+	startTimes := make(map[string]time.Time)
+	r.read(func(d *memoryData) error {
+		for _, order := range d.orders {
+			if order.LocationID != locationID || order.Status == "closed" || order.Status == "cancelled" {
+				continue
+			}
+			if startedAt, ok := startTimes[order.TableID]; !ok || order.CreatedAt.Before(startedAt) {
+				startTimes[order.TableID] = order.CreatedAt
+			}
+		}
+		return nil
+	})
+	return startTimes, nil
+}
+
+// Floor plan operations
+func (r *MemoryVenueRepository) GetFloorPlan(ctx context.Context, locationID string) (*models.FloorPlan, error) {
+	plan := &models.FloorPlan{
+		LocationID: locationID,
+		Zones:      make([]models.ZoneCanvas, 0),
+	}
+
+	err := r.read(func(d *memoryData) error {
+		location, ok := d.locations[locationID]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		plan.Canvas = location.canvas
+
+		var zones []memoryZone
+		for _, zone := range d.zones {
+			if zone.LocationID == locationID && zone.IsActive && zone.canvas != nil {
+				zones = append(zones, zone)
+			}
+		}
+		sortZones(zones)
+		for _, zone := range zones {
+			plan.Zones = append(plan.Zones, models.ZoneCanvas{ZoneID: zone.ID, Canvas: *zone.canvas})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Tables, err = r.GetTablesByLocation(ctx, locationID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *MemoryVenueRepository) SaveFloorPlan(ctx context.Context, plan *models.FloorPlan) error {
+	return r.write(func(d *memoryData) error {
+		t := now()
+		if location, ok := d.locations[plan.LocationID]; ok {
+			location.canvas = plan.Canvas
+			d.touchLocation(&location, t)
+			d.locations[plan.LocationID] = location
+		}
+
+		for _, canvas := range plan.Zones {
+			zone, ok := d.zones[canvas.ZoneID]
+			if !ok || zone.LocationID != plan.LocationID {
+				return fmt.Errorf("zone %s not found in location", canvas.ZoneID)
+			}
+			zoneCanvas := canvas.Canvas
+			zone.canvas = &zoneCanvas
+			zone.UpdatedAt = t
+			d.zones[zone.ID] = zone
+		}
+
+		for _, placement := range plan.Tables {
+			table, ok := d.tables[placement.ID]
+			if !ok || table.LocationID != plan.LocationID {
+				return fmt.Errorf("table %s not found in location", placement.ID)
+			}
+			updated := table
+			updated.PosX, updated.PosY, updated.Rotation = placement.PosX, placement.PosY, placement.Rotation
+			updated.Shape, updated.Width, updated.Height = placement.Shape, placement.Width, placement.Height
+			d.updateTable(table, updated, t)
+		}
+		return nil
+	})
+}
+
+// Table status operations
+func (r *MemoryVenueRepository) TransitionTableStatus(ctx context.Context, changes ...*models.TableStatusChange) error {
+	return r.write(func(d *memoryData) error {
+		return d.applyTableStatusChanges(changes...)
+	})
+}
+
+func (r *MemoryVenueRepository) GetTableStatusHistory(ctx context.Context, tableID string) ([]models.TableStatusChange, error) {
+	history := make([]models.TableStatusChange, 0)
+	r.read(func(d *memoryData) error {
+		// Newest first; history is kept in insertion order
+		for i := len(d.history) - 1; i >= 0; i-- {
+			if d.history[i].TableID == tableID {
+				history = append(history, d.history[i])
+			}
+		}
+		return nil
+	})
+	return history, nil
+}
+
+// Reservation operations
+func (r *MemoryVenueRepository) CreateReservation(ctx context.Context, reservation *models.Reservation) error {
+	return r.write(func(d *memoryData) error {
+		if _, ok := d.locations[reservation.LocationID]; !ok {
+			return invalidReference("location %s does not exist", reservation.LocationID)
+		}
+		if _, ok := d.tables[reservation.TableID]; !ok {
+			return invalidReference("table %s does not exist", reservation.TableID)
+		}
+
+		t := now()
+		reservation.CreatedAt, reservation.UpdatedAt = t, t
+		d.reservations[reservation.ID] = *reservation
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) GetReservations(ctx context.Context, filter *models.ReservationFilter) ([]models.Reservation, error) {
+	return r.queryReservations(func(reservation models.Reservation) bool {
+		switch {
+		case filter.LocationID != nil && reservation.LocationID != *filter.LocationID:
+			return false
+		case filter.TableID != nil && reservation.TableID != *filter.TableID:
+			return false
+		case filter.Status != nil && reservation.Status != *filter.Status:
+			return false
+		case filter.From != nil && !reservation.EndsAt.After(*filter.From):
+			return false
+		case filter.To != nil && !reservation.StartsAt.Before(*filter.To):
+			return false
+		}
+		return true
+	}), nil
+}
+
+func (r *MemoryVenueRepository) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	var reservation models.Reservation
+	err := r.read(func(d *memoryData) error {
+		row, ok := d.reservations[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		reservation = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *MemoryVenueRepository) UpdateReservation(ctx context.Context, id string, reservation *models.Reservation) error {
+	return r.write(func(d *memoryData) error {
+		row, ok := d.reservations[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		if _, ok := d.tables[reservation.TableID]; !ok {
+			return invalidReference("table %s does not exist", reservation.TableID)
+		}
+
+		row.TableID, row.PartyName, row.Phone = reservation.TableID, reservation.PartyName, reservation.Phone
+		row.PartySize, row.StartsAt, row.EndsAt = reservation.PartySize, reservation.StartsAt, reservation.EndsAt
+		row.Status, row.Notes = reservation.Status, reservation.Notes
+		row.UpdatedAt = now()
+		d.reservations[id] = row
+
+		reservation.UpdatedAt = row.UpdatedAt
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) CompleteSeatedReservations(ctx context.Context, tableIDs []string) error {
+	return r.write(func(d *memoryData) error {
+		for id, row := range d.reservations {
+			if row.Status == models.ReservationStatusSeated && slices.Contains(tableIDs, row.TableID) {
+				row.Status = models.ReservationStatusCompleted
+				row.UpdatedAt = now()
+				d.reservations[id] = row
+			}
+		}
+		return nil
+	})
+}
+
+func (r *MemoryVenueRepository) HasOverlappingReservation(ctx context.Context, tableID string, startsAt, endsAt time.Time, excludeID string) (bool, error) {
+	overlapping := r.queryReservations(func(reservation models.Reservation) bool {
+		return reservation.TableID == tableID && reservation.ID != excludeID &&
+			isActiveReservation(reservation, startsAt, endsAt)
+	})
+	return len(overlapping) > 0, nil
+}
+
+func (r *MemoryVenueRepository) GetActiveReservationsByTable(ctx context.Context, tableID string, from, to time.Time) ([]models.Reservation, error) {
+	return r.queryReservations(func(reservation models.Reservation) bool {
+		return reservation.TableID == tableID && isActiveReservation(reservation, from, to)
+	}), nil
+}
+
+func (r *MemoryVenueRepository) GetActiveReservationsByLocation(ctx context.Context, locationID string, from, to time.Time) ([]models.Reservation, error) {
+	return r.queryReservations(func(reservation models.Reservation) bool {
+		return reservation.LocationID == locationID && isActiveReservation(reservation, from, to)
+	}), nil
+}
+
+func (r *MemoryVenueRepository) queryReservations(match func(models.Reservation) bool) []models.Reservation {
+	reservations := make([]models.Reservation, 0)
+	r.read(func(d *memoryData) error {
+		for _, reservation := range d.reservations {
+			if match(reservation) {
+				reservations = append(reservations, reservation)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(reservations, func(i, j int) bool {
+		if !reservations[i].StartsAt.Equal(reservations[j].StartsAt) {
+			return reservations[i].StartsAt.Before(reservations[j].StartsAt)
+		}
+		return reservations[i].ID < reservations[j].ID
+	})
+	return reservations
+}
+
+// isActiveReservation reports whether the reservation is booked or seated
+// and intersects [from, to).
+func isActiveReservation(reservation models.Reservation, from, to time.Time) bool {
+	if reservation.Status != models.ReservationStatusBooked && reservation.Status != models.ReservationStatusSeated {
+		return false
+	}
+	return reservation.StartsAt.Before(to) && reservation.EndsAt.After(from)
+}
+
+// Row helpers
+
+func (d *memoryData) locationByCode(code string) (memoryLocation, bool) {
+	for _, location := range d.locations {
+		if location.Code == code {
+			return location, true
+		}
+	}
+	return memoryLocation{}, false
+}
+
+func (d *memoryData) tableByCode(locationID, code string) (models.Table, bool) {
+	for _, table := range d.tables {
+		if table.LocationID == locationID && table.Code == code {
+			return table, true
+		}
+	}
+	return models.Table{}, false
+}
+
+func (d *memoryData) tableByQRToken(token string) (models.Table, bool) {
+	for _, table := range d.tables {
+		if table.QRToken != nil && *table.QRToken == token {
+			return table, true
+		}
+	}
+	return models.Table{}, false
+}
+
+func (d *memoryData) zoneByName(locationID, name string) (memoryZone, bool) {
+	for _, zone := range d.zones {
+		if zone.LocationID == locationID && zone.Name == name {
+			return zone, true
+		}
+	}
+	return memoryZone{}, false
+}
+
+func (d *memoryData) checkUser(id *string) error {
+	if id == nil {
+		return nil
+	}
+	if _, ok := d.users[*id]; !ok {
+		return invalidReference("user %s does not exist", *id)
+	}
+	return nil
+}
+
+func (d *memoryData) checkZone(id *string) error {
+	if id == nil {
+		return nil
+	}
+	if _, ok := d.zones[*id]; !ok {
+		return invalidReference("zone %s does not exist", *id)
+	}
+	return nil
+}
+
+// touchLocation is the UPDATE trigger of locations.
+func (d *memoryData) touchLocation(location *memoryLocation, t time.Time) {
+	location.UpdatedAt = t
+	location.Version++
+}
+
+func (d *memoryData) insertZone(zone *models.Zone, t time.Time) error {
+	if _, ok := d.locations[zone.LocationID]; !ok {
+		return invalidReference("location %s does not exist", zone.LocationID)
+	}
+	if _, ok := d.zones[zone.ID]; ok {
+		return duplicateKey("zones_pkey")
+	}
+	if _, ok := d.zoneByName(zone.LocationID, zone.Name); ok {
+		return duplicateKey("zones_location_id_name_key")
+	}
+
+	zone.CreatedAt, zone.UpdatedAt = t, t
+	d.zones[zone.ID] = memoryZone{Zone: *zone}
+	return nil
+}
+
+func (d *memoryData) insertTable(table *models.Table, t time.Time) error {
+	if _, ok := d.locations[table.LocationID]; !ok {
+		return invalidReference("location %s does not exist", table.LocationID)
+	}
+	if err := d.checkZone(table.ZoneID); err != nil {
+		return err
+	}
+	if _, ok := d.tables[table.ID]; ok {
+		return duplicateKey("tables_pkey")
+	}
+	if _, ok := d.tableByCode(table.LocationID, table.Code); ok {
+		return duplicateKey("tables_location_id_code_key")
+	}
+	if table.QRToken != nil {
+		if _, ok := d.tableByQRToken(*table.QRToken); ok {
+			return duplicateKey("idx_tables_qr_token")
+		}
+	}
+
+	table.GroupID = nil
+	table.CreatedAt, table.UpdatedAt = t, t
+	table.DeletedAt = nil
+	table.Version = 1
+	d.tables[table.ID] = *table
+	d.pending = append(d.pending, models.TableChange{
+		Type:       models.TableEventCreated,
+		LocationID: table.LocationID,
+		TableID:    table.ID,
+	})
+	return nil
+}
+
+// updateTable stores next in place of prev the way the tables triggers do:
+// it bumps updated_at and the version and announces the change.
+func (d *memoryData) updateTable(prev, next models.Table, t time.Time) models.Table {
+	next.UpdatedAt = t
+	next.Version = prev.Version + 1
+	d.tables[next.ID] = next
+
+	change := models.TableChange{Type: models.TableEventUpdated, LocationID: next.LocationID, TableID: next.ID}
+	switch {
+	case prev.IsActive && !next.IsActive:
+		change.Type = models.TableEventDeleted
+	case prev.Status != next.Status:
+		change.Type = models.TableEventStatusChanged
+		change.PreviousStatus = prev.Status
+	}
+	d.pending = append(d.pending, change)
+	return next
+}
+
+// deleteTable removes the table and what cascades from it.
+func (d *memoryData) deleteTable(id string) {
+	delete(d.tables, id)
+
+	history := d.history[:0:0]
+	for _, change := range d.history {
+		if change.TableID != id {
+			history = append(history, change)
+		}
+	}
+	d.history = history
+
+	for reservationID, reservation := range d.reservations {
+		if reservation.TableID == id {
+			delete(d.reservations, reservationID)
+		}
+	}
+	for entryID, entry := range d.waitlist {
+		if equalID(entry.TableID, id) {
+			entry.TableID = nil
+			d.waitlist[entryID] = entry
+		}
+	}
+	for assignmentID, assignment := range d.assignments {
+		tableIDs := make([]string, 0, len(assignment.TableIDs))
+		for _, tableID := range assignment.TableIDs {
+			if tableID != id {
+				tableIDs = append(tableIDs, tableID)
+			}
+		}
+		assignment.TableIDs = tableIDs
+		d.assignments[assignmentID] = assignment
+	}
+}
+
+func (d *memoryData) applyTableStatusChanges(changes ...*models.TableStatusChange) error {
+	t := now()
+	for _, change := range changes {
+		table, ok := d.tables[change.TableID]
+		if !ok || table.Status != change.FromStatus {
+			return fmt.Errorf("%w: table %s status is no longer %s", ErrConflict, change.TableID, change.FromStatus)
+		}
+		if err := d.checkUser(change.ChangedBy); err != nil {
+			return err
+		}
+
+		updated := table
+		updated.Status = change.ToStatus
+		d.updateTable(table, updated, t)
+
+		change.ChangedAt = t
+		d.history = append(d.history, *change)
+	}
+	return nil
+}
+
+func (d *memoryData) groupMembers(groupID string) []models.Table {
+	tables := make([]models.Table, 0)
+	for _, table := range d.tables {
+		if equalID(table.GroupID, groupID) {
+			tables = append(tables, table)
+		}
+	}
+	sortTablesByCode(tables)
+	return tables
+}
+
+func (d *memoryData) saveSettings(settings *models.LocationSettings, t time.Time) error {
+	if _, ok := d.locations[settings.LocationID]; !ok {
+		return invalidReference("location %s does not exist", settings.LocationID)
+	}
+
+	settings.UpdatedAt = &t
+	row := *settings
+	row.TaxProfiles = cloneTaxProfiles(settings.TaxProfiles)
+	d.settings[settings.LocationID] = row
+	return nil
+}
+
+func (d *memoryData) insertHours(h models.OpeningHours, locationID string) error {
+	if _, ok := d.locations[locationID]; !ok {
+		return invalidReference("location %s does not exist", locationID)
+	}
+
+	h.LocationID = locationID
+	h.OpensAt = normalizeClock(h.OpensAt)
+	h.ClosesAt = normalizeClock(h.ClosesAt)
+	d.hours = append(d.hours, h)
+	return nil
+}
+
+func filterHours(hours []models.OpeningHours, locationID string) []models.OpeningHours {
+	kept := make([]models.OpeningHours, 0, len(hours))
+	for _, h := range hours {
+		if h.LocationID != locationID {
+			kept = append(kept, h)
+		}
+	}
+	return kept
+}
+
+// normalizeClock reads a time of day back as to_char(..., 'HH24:MI') does.
+func normalizeClock(clock string) string {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return t.Format("15:04")
+		}
+	}
+	return clock
+}
+
+func normalizeClockPtr(clock *string) *string {
+	if clock == nil {
+		return nil
+	}
+	normalized := normalizeClock(*clock)
+	return &normalized
+}
+
+// cloneTaxProfiles copies the profiles, keeping nil and empty apart as the
+// JSON column does.
+func cloneTaxProfiles(profiles []models.TaxProfile) []models.TaxProfile {
+	if profiles == nil {
+		return nil
+	}
+	return append(make([]models.TaxProfile, 0, len(profiles)), profiles...)
+}
+
+func isQueued(status string) bool {
+	return status == models.WaitlistStatusWaiting || status == models.WaitlistStatusNotified
+}
+
+func equalID(id *string, value string) bool {
+	return id != nil && *id == value
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a != nil && b != nil && a.Equal(*b)
+}
+
+// memorySortKey is the value a listing is sorted by: text for text
+// columns, number for numbers and timestamps (as Unix nanoseconds).
+type memorySortKey struct {
+	text   string
+	number int64
+}
+
+func (k memorySortKey) compare(other memorySortKey) int {
+	if c := strings.Compare(k.text, other.text); c != 0 {
+		return c
+	}
+	return cmp.Compare(k.number, other.number)
+}
+
+// parseMemorySortKey reads a page key value the way Postgres casts it for
+// the column: numeric keys are integers or RFC 3339 timestamps.
+func parseMemorySortKey(value string, numeric bool) (memorySortKey, error) {
+	if !numeric {
+		return memorySortKey{text: value}, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return memorySortKey{number: n}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return memorySortKey{}, fmt.Errorf("invalid page key %q", value)
+	}
+	return memorySortKey{number: t.UnixNano()}, nil
+}
+
+// paginate orders rows by their sort key and then ID, like the keyset
+// queries of the Postgres repository, skips the rows up to after and keeps
+// at most limit of them (0 keeps every row).
+func paginate[T any](rows []T, key func(T) (memorySortKey, string), numeric, descending bool, after *models.PageKey, limit int) ([]T, error) {
+	compare := func(a, b T) int {
+		aKey, aID := key(a)
+		bKey, bID := key(b)
+		c := aKey.compare(bKey)
+		if c == 0 {
+			c = strings.Compare(aID, bID)
+		}
+		if descending {
+			return -c
+		}
+		return c
+	}
+	sort.Slice(rows, func(i, j int) bool { return compare(rows[i], rows[j]) < 0 })
+
+	if after != nil {
+		afterKey, err := parseMemorySortKey(after.Value, numeric)
+		if err != nil {
+			return nil, err
+		}
+		start := sort.Search(len(rows), func(i int) bool {
+			rowKey, rowID := key(rows[i])
+			c := rowKey.compare(afterKey)
+			if c == 0 {
+				c = strings.Compare(rowID, after.ID)
+			}
+			if descending {
+				c = -c
+			}
+			return c > 0
+		})
+		rows = rows[start:]
+	}
+
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+func sortTablesByCode(tables []models.Table) {
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Code < tables[j].Code })
+}
+
+func sortZones(zones []memoryZone) {
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].SortOrder != zones[j].SortOrder {
+			return zones[i].SortOrder < zones[j].SortOrder
+		}
+		return zones[i].Name < zones[j].Name
+	})
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func newMemoryLocation(t *testing.T, repo VenueRepository, code string) *models.Location {
+	t.Helper()
+	location := &models.Location{ID: uuid.NewString(), Code: code, Name: code, Address: "Calle 1", IsActive: true}
+	if err := repo.CreateLocation(context.Background(), location); err != nil {
+		t.Fatalf("create location %s: %v", code, err)
+	}
+	return location
+}
+
+func TestMemoryWithTxRollsBack(t *testing.T) {
+	repo := NewMemoryVenueRepository()
+	ctx := context.Background()
+	failure := errors.New("abort")
+
+	var created *models.Location
+	err := repo.WithTx(ctx, func(tx VenueRepository) error {
+		created = newMemoryLocation(t, tx, "TX")
+		if _, err := tx.GetLocationByID(ctx, created.ID); err != nil {
+			t.Errorf("location should be visible inside the transaction: %v", err)
+		}
+		return failure
+	})
+	if err != failure {
+		t.Fatalf("WithTx error = %v, want %v", err, failure)
+	}
+
+	if _, err := repo.GetLocationByID(ctx, created.ID); !IsNotFound(err) {
+		t.Errorf("rolled back location is still stored (err %v)", err)
+	}
+}
+
+func TestMemoryTableCodes(t *testing.T) {
+	repo := NewMemoryVenueRepository()
+	ctx := context.Background()
+	main := newMemoryLocation(t, repo, "MAIN")
+	other := newMemoryLocation(t, repo, "OTHER")
+
+	tests := []struct {
+		name       string
+		locationID string
+		code       string
+		err        error
+	}{
+		{name: "new code", locationID: main.ID, code: "T1"},
+		{name: "same code in another location", locationID: other.ID, code: "T1"},
+		{name: "same code in the same location", locationID: main.ID, code: "T1", err: ErrDuplicate},
+		{name: "unknown location", locationID: uuid.NewString(), code: "T2", err: ErrInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &models.Table{ID: uuid.NewString(), LocationID: tt.locationID, Code: tt.code, Seats: 4, Status: models.TableStatusAvailable, IsActive: true}
+			err := repo.CreateTable(ctx, table)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("CreateTable error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMemoryDeleteTableChecksVersion(t *testing.T) {
+	repo := NewMemoryVenueRepository()
+	ctx := context.Background()
+	location := newMemoryLocation(t, repo, "MAIN")
+	table := &models.Table{ID: uuid.NewString(), LocationID: location.ID, Code: "T1", Seats: 4, Status: models.TableStatusAvailable, IsActive: true}
+	if err := repo.CreateTable(ctx, table); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	if err := repo.DeleteTable(ctx, table.ID, table.Version+1); err != sql.ErrNoRows {
+		t.Fatalf("stale delete error = %v, want sql.ErrNoRows", err)
+	}
+	if err := repo.DeleteTable(ctx, table.ID, table.Version); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+
+	active, err := repo.GetTablesByLocation(ctx, location.ID, nil)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("deleted table is still listed: %+v", active)
+	}
+	all, err := repo.GetTablesByLocation(ctx, location.ID, &models.TableFilter{IncludeInactive: true})
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(all) != 1 || all[0].IsActive || all[0].Version != table.Version+1 {
+		t.Errorf("include_inactive listing = %+v", all)
+	}
+}
+
+func TestMemoryTableGroupErrors(t *testing.T) {
+	repo := NewMemoryVenueRepository()
+	ctx := context.Background()
+	location := newMemoryLocation(t, repo, "MAIN")
+
+	var tableIDs []string
+	for _, code := range []string{"T1", "T2"} {
+		table := &models.Table{ID: uuid.NewString(), LocationID: location.ID, Code: code, Seats: 4, Status: models.TableStatusAvailable, IsActive: true}
+		if err := repo.CreateTable(ctx, table); err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		tableIDs = append(tableIDs, table.ID)
+	}
+
+	moved := &models.TableGroup{ID: uuid.NewString(), LocationID: location.ID, Code: "moved"}
+	if err := repo.CreateTableGroup(ctx, moved, tableIDs, models.TableStatusOccupied); !errors.Is(err, ErrConflict) {
+		t.Errorf("grouping tables no longer in the status error = %v, want ErrConflict", err)
+	}
+	group := &models.TableGroup{ID: uuid.NewString(), LocationID: location.ID, Code: "T1+T2"}
+	if err := repo.CreateTableGroup(ctx, group, tableIDs, models.TableStatusAvailable); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	again := &models.TableGroup{ID: uuid.NewString(), LocationID: location.ID, Code: "again"}
+	if err := repo.CreateTableGroup(ctx, again, tableIDs, models.TableStatusAvailable); !errors.Is(err, ErrConflict) {
+		t.Errorf("grouping grouped tables error = %v, want ErrConflict", err)
+	}
+
+	if err := repo.DissolveTableGroup(ctx, group.ID); err != nil {
+		t.Fatalf("dissolve group: %v", err)
+	}
+	if err := repo.DissolveTableGroup(ctx, group.ID); !errors.Is(err, ErrConflict) {
+		t.Errorf("dissolving twice error = %v, want ErrConflict", err)
+	}
+	if err := repo.DissolveTableGroup(ctx, uuid.NewString()); !IsNotFound(err) {
+		t.Errorf("dissolving an unknown group error = %v, want not found", err)
+	}
+}
+
+func TestMemoryTransitionTableStatusConflict(t *testing.T) {
+	repo := NewMemoryVenueRepository()
+	ctx := context.Background()
+	location := newMemoryLocation(t, repo, "MAIN")
+	table := &models.Table{ID: uuid.NewString(), LocationID: location.ID, Code: "T1", Seats: 4, Status: models.TableStatusAvailable, IsActive: true}
+	if err := repo.CreateTable(ctx, table); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	change := func(from, to string) *models.TableStatusChange {
+		return &models.TableStatusChange{ID: uuid.NewString(), TableID: table.ID, FromStatus: from, ToStatus: to}
+	}
+	if err := repo.TransitionTableStatus(ctx, change(models.TableStatusAvailable, models.TableStatusOccupied)); err != nil {
+		t.Fatalf("transition: %v", err)
+	}
+	// A second request that read the table before the first one moved it
+	err := repo.TransitionTableStatus(ctx, change(models.TableStatusAvailable, models.TableStatusOccupied))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("stale transition error = %v, want ErrConflict", err)
+	}
+	if history, _ := repo.GetTableStatusHistory(ctx, table.ID); len(history) != 1 {
+		t.Errorf("history has %d entries, want 1", len(history))
+	}
+}
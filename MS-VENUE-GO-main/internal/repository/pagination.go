@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"ms-venue-go/internal/models"
+)
+
+// sortColumn is the column behind a listing sort key and the type a page
+// key value is cast to when compared with it.
+type sortColumn struct {
+	name string
+	cast string
+}
+
+var locationSortColumns = map[string]sortColumn{
+	models.LocationSortCreatedAt: {name: "created_at", cast: "timestamptz"},
+	models.LocationSortName:      {name: "name", cast: "text"},
+	models.LocationSortCode:      {name: "code", cast: "text"},
+}
+
+var tableSortColumns = map[string]sortColumn{
+	models.TableSortCode:      {name: "code", cast: "text"},
+	models.TableSortSeats:     {name: "seats", cast: "integer"},
+	models.TableSortCreatedAt: {name: "created_at", cast: "timestamptz"},
+}
+
+// keyset orders a listing by column and then id, so that every row has a
+// distinct position, and when after is set returns the condition that skips
+// to the rows following it. The condition uses placeholders argIndex and
+// argIndex+1.
+func keyset(column sortColumn, descending bool, after *models.PageKey, argIndex int) (condition, orderBy string, args []interface{}) {
+	direction, comparison := "ASC", ">"
+	if descending {
+		direction, comparison = "DESC", "<"
+	}
+	orderBy = fmt.Sprintf("%s %s, id %s", column.name, direction, direction)
+	if after == nil {
+		return "", orderBy, nil
+	}
+	condition = fmt.Sprintf("(%s, id) %s ($%d::%s, $%d::uuid)", column.name, comparison, argIndex, column.cast, argIndex+1)
+	return condition, orderBy, []interface{}{after.Value, after.ID}
+}
+
+// limitClause returns the LIMIT of a listing; limit 0 lists every row.
+func limitClause(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+// likePrefix returns a LIKE pattern matching values that start with prefix.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const reservationColumns = `id, location_id, table_id, party_name, phone, party_size,
+		starts_at, ends_at, status, notes, created_at, updated_at`
+
+// Reservation operations
+func (r *venueRepository) CreateReservation(ctx context.Context, reservation *models.Reservation) error {
+	query := `
+		INSERT INTO bar_system.reservations (id, location_id, table_id, party_name, phone,
+			party_size, starts_at, ends_at, status, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, reservation.ID, reservation.LocationID, reservation.TableID,
+		reservation.PartyName, reservation.Phone, reservation.PartySize, reservation.StartsAt,
+		reservation.EndsAt, reservation.Status, reservation.Notes).Scan(&reservation.CreatedAt, &reservation.UpdatedAt)
+
+	return translateError(err)
+}
+
+func (r *venueRepository) GetReservations(ctx context.Context, filter *models.ReservationFilter) ([]models.Reservation, error) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.LocationID != nil {
+		conditions = append(conditions, fmt.Sprintf("location_id = $%d", argIndex))
+		args = append(args, *filter.LocationID)
+		argIndex++
+	}
+
+	if filter.TableID != nil {
+		conditions = append(conditions, fmt.Sprintf("table_id = $%d", argIndex))
+		args = append(args, *filter.TableID)
+		argIndex++
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("ends_at > $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("starts_at < $%d", argIndex))
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.reservations
+		%s
+		ORDER BY starts_at
+	`, reservationColumns, whereClause)
+
+	return r.queryReservations(ctx, query, args...)
+}
+
+func (r *venueRepository) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.reservations
+		WHERE id = $1
+	`, reservationColumns)
+
+	reservation := &models.Reservation{}
+	err := scanReservation(r.q.QueryRowContext(ctx, query, id), reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (r *venueRepository) UpdateReservation(ctx context.Context, id string, reservation *models.Reservation) error {
+	query := `
+		UPDATE bar_system.reservations
+		SET table_id = $1, party_name = $2, phone = $3, party_size = $4, starts_at = $5,
+		    ends_at = $6, status = $7, notes = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $9
+		RETURNING updated_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, reservation.TableID, reservation.PartyName, reservation.Phone,
+		reservation.PartySize, reservation.StartsAt, reservation.EndsAt, reservation.Status,
+		reservation.Notes, id).Scan(&reservation.UpdatedAt)
+
+	return translateError(err)
+}
+
+// CompleteSeatedReservations marks the seated reservations of the tables as
+// completed, once their parties have left.
+func (r *venueRepository) CompleteSeatedReservations(ctx context.Context, tableIDs []string) error {
+	_, err := r.q.ExecContext(ctx, `
+		UPDATE bar_system.reservations
+		SET status = 'completed', updated_at = CURRENT_TIMESTAMP
+		WHERE table_id::text = ANY($1) AND status = 'seated'
+	`, pq.Array(tableIDs))
+	return err
+}
+
+// HasOverlappingReservation reports whether the table already holds a booked or
+// seated reservation intersecting [startsAt, endsAt), ignoring excludeID.
+func (r *venueRepository) HasOverlappingReservation(ctx context.Context, tableID string, startsAt, endsAt time.Time, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM bar_system.reservations
+			WHERE table_id = $1
+			  AND status IN ('booked', 'seated')
+			  AND starts_at < $3 AND ends_at > $2
+			  AND id::text <> $4
+		)
+	`
+
+	var exists bool
+	err := r.q.QueryRowContext(ctx, query, tableID, startsAt, endsAt, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *venueRepository) GetActiveReservationsByTable(ctx context.Context, tableID string, from, to time.Time) ([]models.Reservation, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.reservations
+		WHERE table_id = $1
+		  AND status IN ('booked', 'seated')
+		  AND starts_at < $3 AND ends_at > $2
+		ORDER BY starts_at
+	`, reservationColumns)
+
+	return r.queryReservations(ctx, query, tableID, from, to)
+}
+
+func (r *venueRepository) GetActiveReservationsByLocation(ctx context.Context, locationID string, from, to time.Time) ([]models.Reservation, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.reservations
+		WHERE location_id = $1
+		  AND status IN ('booked', 'seated')
+		  AND starts_at < $3 AND ends_at > $2
+		ORDER BY starts_at
+	`, reservationColumns)
+
+	return r.queryReservations(ctx, query, locationID, from, to)
+}
+
+func (r *venueRepository) queryReservations(ctx context.Context, query string, args ...interface{}) ([]models.Reservation, error) {
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := make([]models.Reservation, 0)
+	for rows.Next() {
+		var reservation models.Reservation
+		if err := scanReservation(rows, &reservation); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+func scanReservation(row rowScanner, reservation *models.Reservation) error {
+	return row.Scan(&reservation.ID, &reservation.LocationID, &reservation.TableID,
+		&reservation.PartyName, &reservation.Phone, &reservation.PartySize, &reservation.StartsAt,
+		&reservation.EndsAt, &reservation.Status, &reservation.Notes, &reservation.CreatedAt,
+		&reservation.UpdatedAt)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const scheduleExceptionColumns = `id, location_id, to_char(start_date, 'YYYY-MM-DD'), to_char(end_date, 'YYYY-MM-DD'),
+		is_closed, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI'), reason, created_at`
+
+// Schedule operations
+
+// GetLocationSchedules loads the weekly hours of the given locations and the
+// exceptions that have not ended before fromDate ("YYYY-MM-DD"), keyed by
+// location. Every requested location gets an entry, even without a schedule.
+func (r *venueRepository) GetLocationSchedules(ctx context.Context, locationIDs []string, fromDate string) (map[string]*models.LocationSchedule, error) {
+	schedules := make(map[string]*models.LocationSchedule, len(locationIDs))
+	for _, id := range locationIDs {
+		schedules[id] = &models.LocationSchedule{
+			LocationID: id,
+			Hours:      make([]models.OpeningHours, 0),
+			Exceptions: make([]models.ScheduleException, 0),
+		}
+	}
+
+	rows, err := r.q.QueryContext(ctx, `
+		SELECT id, location_id, day_of_week, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		FROM bar_system.location_hours
+		WHERE location_id::text = ANY($1)
+		ORDER BY location_id, day_of_week, opens_at
+	`, pq.Array(locationIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hours models.OpeningHours
+		if err := rows.Scan(&hours.ID, &hours.LocationID, &hours.DayOfWeek, &hours.OpensAt, &hours.ClosesAt); err != nil {
+			return nil, err
+		}
+		schedule := schedules[hours.LocationID]
+		schedule.Hours = append(schedule.Hours, hours)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	exceptionRows, err := r.q.QueryContext(ctx, `
+		SELECT `+scheduleExceptionColumns+`
+		FROM bar_system.location_schedule_exceptions
+		WHERE location_id::text = ANY($1) AND end_date >= $2::date
+		ORDER BY location_id, start_date
+	`, pq.Array(locationIDs), fromDate)
+	if err != nil {
+		return nil, err
+	}
+	defer exceptionRows.Close()
+
+	for exceptionRows.Next() {
+		var exception models.ScheduleException
+		if err := scanScheduleException(exceptionRows, &exception); err != nil {
+			return nil, err
+		}
+		schedule := schedules[exception.LocationID]
+		schedule.Exceptions = append(schedule.Exceptions, exception)
+	}
+
+	return schedules, exceptionRows.Err()
+}
+
+// ReplaceOpeningHours swaps the weekly hours of a location in one transaction.
+func (r *venueRepository) ReplaceOpeningHours(ctx context.Context, locationID string, hours []models.OpeningHours) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM bar_system.location_hours WHERE location_id = $1`, locationID)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range hours {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO bar_system.location_hours (id, location_id, day_of_week, opens_at, closes_at)
+			VALUES ($1, $2, $3, $4::time, $5::time)
+		`, h.ID, locationID, h.DayOfWeek, h.OpensAt, h.ClosesAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *venueRepository) CreateScheduleException(ctx context.Context, exception *models.ScheduleException) error {
+	query := `
+		INSERT INTO bar_system.location_schedule_exceptions (id, location_id, start_date, end_date,
+			is_closed, opens_at, closes_at, reason)
+		VALUES ($1, $2, $3::date, $4::date, $5, $6::time, $7::time, $8)
+		RETURNING created_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, exception.ID, exception.LocationID, exception.StartDate, exception.EndDate,
+		exception.IsClosed, exception.OpensAt, exception.ClosesAt, exception.Reason).Scan(&exception.CreatedAt)
+
+	return translateError(err)
+}
+
+func (r *venueRepository) GetScheduleExceptionByID(ctx context.Context, id string) (*models.ScheduleException, error) {
+	query := `
+		SELECT ` + scheduleExceptionColumns + `
+		FROM bar_system.location_schedule_exceptions
+		WHERE id = $1
+	`
+
+	exception := &models.ScheduleException{}
+	err := scanScheduleException(r.q.QueryRowContext(ctx, query, id), exception)
+	if err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+func (r *venueRepository) DeleteScheduleException(ctx context.Context, id string) error {
+	result, err := r.q.ExecContext(ctx, `DELETE FROM bar_system.location_schedule_exceptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(result, "schedule exception", id)
+}
+
+// HasOverlappingScheduleException reports whether an exception of the
+// location already covers any day between startDate and endDate.
+func (r *venueRepository) HasOverlappingScheduleException(ctx context.Context, locationID, startDate, endDate string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM bar_system.location_schedule_exceptions
+			WHERE location_id = $1 AND start_date <= $3::date AND end_date >= $2::date
+		)
+	`
+
+	var exists bool
+	err := r.q.QueryRowContext(ctx, query, locationID, startDate, endDate).Scan(&exists)
+	return exists, err
+}
+
+func scanScheduleException(row rowScanner, exception *models.ScheduleException) error {
+	return row.Scan(&exception.ID, &exception.LocationID, &exception.StartDate, &exception.EndDate,
+		&exception.IsClosed, &exception.OpensAt, &exception.ClosesAt, &exception.Reason, &exception.CreatedAt)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Settings operations
+
+// GetLocationSettings returns the stored settings of the given locations,
+// keyed by location. Locations that never saved settings are absent.
+func (r *venueRepository) GetLocationSettings(ctx context.Context, locationIDs []string) (map[string]*models.LocationSettings, error) {
+	query := `
+		SELECT location_id, timezone, currency_code, tax_rate, tax_profiles,
+		       service_charge_percent, receipt_header, updated_at
+		FROM bar_system.location_settings
+		WHERE location_id::text = ANY($1)
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, pq.Array(locationIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settingsByLocation := make(map[string]*models.LocationSettings, len(locationIDs))
+	for rows.Next() {
+		settings := &models.LocationSettings{}
+		var taxProfiles []byte
+		err := rows.Scan(&settings.LocationID, &settings.Timezone, &settings.CurrencyCode,
+			&settings.TaxRate, &taxProfiles, &settings.ServiceChargePercent,
+			&settings.ReceiptHeader, &settings.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(taxProfiles, &settings.TaxProfiles); err != nil {
+			return nil, err
+		}
+		settingsByLocation[settings.LocationID] = settings
+	}
+
+	return settingsByLocation, rows.Err()
+}
+
+// SaveLocationSettings creates or replaces the settings of a location.
+func (r *venueRepository) SaveLocationSettings(ctx context.Context, settings *models.LocationSettings) error {
+	taxProfiles, err := json.Marshal(settings.TaxProfiles)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO bar_system.location_settings (location_id, timezone, currency_code, tax_rate,
+			tax_profiles, service_charge_percent, receipt_header)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (location_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone,
+		    currency_code = EXCLUDED.currency_code,
+		    tax_rate = EXCLUDED.tax_rate,
+		    tax_profiles = EXCLUDED.tax_profiles,
+		    service_charge_percent = EXCLUDED.service_charge_percent,
+		    receipt_header = EXCLUDED.receipt_header,
+		    updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at
+	`
+
+	return r.q.QueryRowContext(ctx, query, settings.LocationID, settings.Timezone, settings.CurrencyCode,
+		settings.TaxRate, taxProfiles, settings.ServiceChargePercent,
+		settings.ReceiptHeader).Scan(&settings.UpdatedAt)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// tableEventsChannel is the NOTIFY channel written by the
+// trg_tables_notify trigger (see migration 0002_venue_features).
+const tableEventsChannel = "venue_table_events"
+
+// Table event operations
+
+// ListenTableEvents calls notify for every table write announced by the
+// database, from any service replica, until ctx is done. The listener
+// reconnects on its own; after a reconnect a resync change is delivered
+// because notifications sent while disconnected are lost. A LISTEN the server
+// rejects is retried with exponential backoff, up to a minute apart.
+func (r *venueRepository) ListenTableEvents(ctx context.Context, notify func(models.TableChange)) error {
+	backoff := time.Second
+	for retry := false; ; retry = true {
+		err := r.listenTableEvents(ctx, notify, retry)
+		if err == nil {
+			return nil
+		}
+		log.Printf("Table event listener: %v; retrying in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, time.Minute)
+	}
+}
+
+// listenTableEvents runs one listener until ctx is done. It only fails when
+// LISTEN does; resync announces that notifications may have been missed
+// before it started.
+func (r *venueRepository) listenTableEvents(ctx context.Context, notify func(models.TableChange), resync bool) error {
+	listener := pq.NewListener(r.dbURL, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Table event listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(tableEventsChannel); err != nil {
+		return err
+	}
+	if resync {
+		notify(models.TableChange{Type: models.TableEventResync})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			if n == nil {
+				notify(models.TableChange{Type: models.TableEventResync})
+				continue
+			}
+			var change models.TableChange
+			if err := json.Unmarshal([]byte(n.Extra), &change); err != nil {
+				log.Printf("Table event listener: invalid payload %q: %v", n.Extra, err)
+				continue
+			}
+			notify(change)
+		case <-time.After(90 * time.Second):
+			// Detect dead connections that would otherwise go unnoticed
+			go listener.Ping()
+		}
+	}
+}
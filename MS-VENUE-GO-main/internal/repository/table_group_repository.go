@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Table group operations
+
+// CreateTableGroup inserts the group and attaches the tables to it in one
+// transaction. It fails with ErrConflict unless every table is an active,
+// ungrouped table of the group's location that is still in status.
+func (r *venueRepository) CreateTableGroup(ctx context.Context, group *models.TableGroup, tableIDs []string, status string) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bar_system.table_groups (id, location_id, code)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, group.ID, group.LocationID, group.Code).Scan(&group.CreatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE bar_system.tables
+		SET group_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id::text = ANY($2) AND location_id = $3 AND is_active = true AND group_id IS NULL AND status = $4
+	`, group.ID, pq.Array(tableIDs), group.LocationID, status)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != int64(len(tableIDs)) {
+		return fmt.Errorf("%w: some tables are inactive, in another location, already grouped or no longer %s", ErrConflict, status)
+	}
+
+	return tx.Commit()
+}
+
+func (r *venueRepository) GetTableGroupsByLocation(ctx context.Context, locationID string) ([]models.TableGroup, error) {
+	query := `
+		SELECT id, location_id, code, created_at, dissolved_at
+		FROM bar_system.table_groups
+		WHERE location_id = $1 AND dissolved_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]models.TableGroup, 0)
+	for rows.Next() {
+		var group models.TableGroup
+		err := rows.Scan(&group.ID, &group.LocationID, &group.Code, &group.CreatedAt, &group.DissolvedAt)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		groups[i].Tables, err = r.getTableGroupMembers(ctx, groups[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+func (r *venueRepository) GetTableGroupByID(ctx context.Context, id string) (*models.TableGroup, error) {
+	query := `
+		SELECT id, location_id, code, created_at, dissolved_at
+		FROM bar_system.table_groups
+		WHERE id = $1
+	`
+
+	group := &models.TableGroup{}
+	err := r.q.QueryRowContext(ctx, query, id).Scan(&group.ID, &group.LocationID, &group.Code,
+		&group.CreatedAt, &group.DissolvedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Tables, err = r.getTableGroupMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// DissolveTableGroup marks the group as dissolved and releases its tables,
+// which keep the status they had as part of the group. It fails with
+// sql.ErrNoRows when the group does not exist and with ErrConflict when it is
+// already dissolved.
+func (r *venueRepository) DissolveTableGroup(ctx context.Context, id string) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE bar_system.table_groups
+		SET dissolved_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND dissolved_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var found string
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM bar_system.table_groups WHERE id = $1
+		`, id).Scan(&found)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: table group is already dissolved", ErrConflict)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.tables
+		SET group_id = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE group_id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *venueRepository) getTableGroupMembers(ctx context.Context, groupID string) ([]models.Table, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.tables
+		WHERE group_id = $1
+		ORDER BY code
+	`, tableColumns)
+
+	rows, err := r.q.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]models.Table, 0)
+	for rows.Next() {
+		var table models.Table
+		if err := scanTable(rows, &table); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, rows.Err()
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+// Table QR operations
+
+// SetTableQRToken stores a new sticker token for the table, replacing (and
+// thereby invalidating) the previous one.
+func (r *venueRepository) SetTableQRToken(ctx context.Context, tableID, token string) (time.Time, error) {
+	query := `
+		UPDATE bar_system.tables
+		SET qr_token = $1, qr_rotated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+		RETURNING qr_rotated_at
+	`
+
+	var rotatedAt time.Time
+	err := r.q.QueryRowContext(ctx, query, token, tableID).Scan(&rotatedAt)
+	return rotatedAt, err
+}
+
+func (r *venueRepository) GetTableByQRToken(ctx context.Context, token string) (*models.Table, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.tables
+		WHERE qr_token = $1
+	`, tableColumns)
+
+	table := &models.Table{}
+	err := scanTable(r.q.QueryRowContext(ctx, query, token), table)
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
@@ -1,74 +1,330 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"ms-venue-go/internal/models"
+	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type VenueRepository interface {
+	// Transactions
+	WithTx(ctx context.Context, fn func(tx VenueRepository) error) error
+
 	// Location operations
-	CreateLocation(location *models.Location) error
-	GetAllLocations() ([]models.Location, error)
-	GetLocationByID(id string) (*models.Location, error)
-	UpdateLocation(id string, location *models.Location) error
-	DeleteLocation(id string) error
+	CreateLocation(ctx context.Context, location *models.Location) error
+	GetAllLocations(ctx context.Context, filter *models.LocationFilter) ([]models.Location, error)
+	GetLocationByID(ctx context.Context, id string) (*models.Location, error)
+	LockLocation(ctx context.Context, id string) error
+	UpdateLocation(ctx context.Context, id string, location *models.Location) error
+	DeleteLocation(ctx context.Context, id string, version int) error
+	RestoreLocation(ctx context.Context, id string) error
+	PurgeLocation(ctx context.Context, id string) error
+	CountLocationReferences(ctx context.Context, id string) (orders, staff int, err error)
 
 	// Table operations
-	CreateTable(table *models.Table) error
-	GetTablesByLocation(locationID string) ([]models.Table, error)
-	GetTableByID(id string) (*models.Table, error)
-	UpdateTable(id string, table *models.Table) error
-	DeleteTable(id string) error
+	CreateTable(ctx context.Context, table *models.Table) error
+	GetTablesByLocation(ctx context.Context, locationID string, filter *models.TableFilter) ([]models.Table, error)
+	GetTableByID(ctx context.Context, id string) (*models.Table, error)
+	LockTable(ctx context.Context, id string) error
+	UpdateTable(ctx context.Context, id string, table *models.Table) error
+	DeleteTable(ctx context.Context, id string, version int) error
+	RestoreTable(ctx context.Context, id string) error
+	PurgeTable(ctx context.Context, id string) error
+	CountTableOrders(ctx context.Context, id string) (int, error)
+
+	// Table event operations
+	ListenTableEvents(ctx context.Context, notify func(models.TableChange)) error
+
+	// Table QR operations
+	SetTableQRToken(ctx context.Context, tableID, token string) (time.Time, error)
+	GetTableByQRToken(ctx context.Context, token string) (*models.Table, error)
+
+	// Bulk operations
+	GetLocationByCode(ctx context.Context, code string) (*models.Location, error)
+	ImportLocations(ctx context.Context, locations []models.Location) (created, updated int, err error)
+	ImportTables(ctx context.Context, zones []models.Zone, tables []models.Table) (created, updated int, err error)
+
+	// Clone operations
+	CloneLocation(ctx context.Context, clone *models.LocationClone) error
+
+	// Settings operations
+	GetLocationSettings(ctx context.Context, locationIDs []string) (map[string]*models.LocationSettings, error)
+	SaveLocationSettings(ctx context.Context, settings *models.LocationSettings) error
+
+	// Schedule operations
+	GetLocationSchedules(ctx context.Context, locationIDs []string, fromDate string) (map[string]*models.LocationSchedule, error)
+	ReplaceOpeningHours(ctx context.Context, locationID string, hours []models.OpeningHours) error
+	CreateScheduleException(ctx context.Context, exception *models.ScheduleException) error
+	GetScheduleExceptionByID(ctx context.Context, id string) (*models.ScheduleException, error)
+	DeleteScheduleException(ctx context.Context, id string) error
+	HasOverlappingScheduleException(ctx context.Context, locationID, startDate, endDate string) (bool, error)
+
+	// Zone operations
+	CreateZone(ctx context.Context, zone *models.Zone) error
+	GetZonesByLocation(ctx context.Context, locationID string) ([]models.Zone, error)
+	GetZoneByID(ctx context.Context, id string) (*models.Zone, error)
+	UpdateZone(ctx context.Context, id string, zone *models.Zone) error
+	DeleteZone(ctx context.Context, id string) error
+	GetOccupancyByZone(ctx context.Context, locationID string) ([]models.ZoneOccupancy, error)
+
+	// Table group operations
+	CreateTableGroup(ctx context.Context, group *models.TableGroup, tableIDs []string, status string) error
+	GetTableGroupsByLocation(ctx context.Context, locationID string) ([]models.TableGroup, error)
+	GetTableGroupByID(ctx context.Context, id string) (*models.TableGroup, error)
+	DissolveTableGroup(ctx context.Context, id string) error
+
+	// Waiter assignment operations
+	CreateWaiterAssignment(ctx context.Context, assignment *models.WaiterAssignment) error
+	GetWaiterAssignments(ctx context.Context, filter *models.WaiterAssignmentFilter) ([]models.WaiterAssignment, error)
+	GetWaiterAssignmentByID(ctx context.Context, id string) (*models.WaiterAssignment, error)
+	RemoveWaiterAssignment(ctx context.Context, id string) error
+	GetUserVenueID(ctx context.Context, userID string) (*string, error)
+
+	// Waitlist operations
+	CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error
+	GetWaitlistByLocation(ctx context.Context, locationID string) ([]models.WaitlistEntry, error)
+	GetWaitlistEntryByID(ctx context.Context, id string) (*models.WaitlistEntry, error)
+	UpdateWaitlistEntry(ctx context.Context, id string, entry *models.WaitlistEntry) error
+	ReorderWaitlist(ctx context.Context, locationID string, entryIDs []string) error
+	SeatWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry, change *models.TableStatusChange) error
+	GetAverageTurnMinutes(ctx context.Context, locationID string, minSeats int, since time.Time) (float64, error)
+	GetOpenOrderStartTimes(ctx context.Context, locationID string) (map[string]time.Time, error)
+
+	// Floor plan operations
+	GetFloorPlan(ctx context.Context, locationID string) (*models.FloorPlan, error)
+	SaveFloorPlan(ctx context.Context, plan *models.FloorPlan) error
+
+	// Table status operations
+	TransitionTableStatus(ctx context.Context, changes ...*models.TableStatusChange) error
+	GetTableStatusHistory(ctx context.Context, tableID string) ([]models.TableStatusChange, error)
+
+	// Reservation operations
+	CreateReservation(ctx context.Context, reservation *models.Reservation) error
+	GetReservations(ctx context.Context, filter *models.ReservationFilter) ([]models.Reservation, error)
+	GetReservationByID(ctx context.Context, id string) (*models.Reservation, error)
+	UpdateReservation(ctx context.Context, id string, reservation *models.Reservation) error
+	CompleteSeatedReservations(ctx context.Context, tableIDs []string) error
+	HasOverlappingReservation(ctx context.Context, tableID string, startsAt, endsAt time.Time, excludeID string) (bool, error)
+	GetActiveReservationsByTable(ctx context.Context, tableID string, from, to time.Time) ([]models.Reservation, error)
+	GetActiveReservationsByLocation(ctx context.Context, locationID string, from, to time.Time) ([]models.Reservation, error)
+}
+
+// Config holds the database connection settings. Zero values keep the
+// database/sql defaults.
+type Config struct {
+	URL             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout makes Postgres cancel any statement running longer.
+	StatementTimeout time.Duration
+	// ConnectTimeout bounds the initial ping.
+	ConnectTimeout time.Duration
+}
+
+// querier is what *sql.DB and *sql.Tx have in common.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
 type venueRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	q     querier // db, or tx when bound to a transaction by WithTx
+	tx    *sql.Tx
+	dbURL string
 }
 
-func NewVenueRepository(dbURL string) (VenueRepository, error) {
-	db, err := sql.Open("postgres", dbURL)
+func NewVenueRepository(config Config) (VenueRepository, error) {
+	db, err := sql.Open("postgres", withStatementTimeout(config.URL, config.StatementTimeout))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+
+	ctx := context.Background()
+	if config.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.ConnectTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	repo := &venueRepository{
-		db: db,
+		db:    db,
+		q:     db,
+		dbURL: config.URL,
 	}
 
 	return repo, nil
 }
 
+// withStatementTimeout adds the statement_timeout run-time parameter to a
+// URL or key=value connection string.
+func withStatementTimeout(dbURL string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dbURL
+	}
+	param := fmt.Sprintf("statement_timeout=%d", timeout.Milliseconds())
+	switch {
+	case !strings.Contains(dbURL, "://"):
+		return dbURL + " " + param
+	case strings.Contains(dbURL, "?"):
+		return dbURL + "&" + param
+	default:
+		return dbURL + "?" + param
+	}
+}
+
+// Transactions
+
+// WithTx runs fn with a repository bound to a single transaction, which is
+// committed when fn returns nil and rolled back otherwise. Calls on a
+// repository that is already bound join its transaction.
+func (r *venueRepository) WithTx(ctx context.Context, fn func(tx VenueRepository) error) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&venueRepository{db: r.db, q: tx.Tx, tx: tx.Tx, dbURL: r.dbURL}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// txHandle is a transaction that may be shared with an enclosing WithTx, in
+// which case only the owner commits or rolls it back.
+type txHandle struct {
+	*sql.Tx
+	joined bool
+}
+
+func (t *txHandle) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txHandle) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// beginTx starts a transaction for a multi-statement write, or joins the one
+// the repository is bound to.
+func (r *venueRepository) beginTx(ctx context.Context) (*txHandle, error) {
+	if r.tx != nil {
+		return &txHandle{Tx: r.tx, joined: true}, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &txHandle{Tx: tx}, nil
+}
+
 // Location operations
-func (r *venueRepository) CreateLocation(location *models.Location) error {
+// Optional profile columns are read as empty strings when unset.
+const locationColumns = `id, code, name, COALESCE(address, ''), COALESCE(city, ''), COALESCE(phone, ''),
+		COALESCE(email, ''), latitude, longitude, max_capacity, manager_id, is_active, created_at, updated_at,
+		deleted_at, version`
+
+func (r *venueRepository) CreateLocation(ctx context.Context, location *models.Location) error {
 	query := `
-		INSERT INTO bar_system.locations (id, code, name, address, is_active)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO bar_system.locations (id, code, name, address, city, phone, email,
+			latitude, longitude, max_capacity, manager_id, is_active)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10, $11, $12)
 		ON CONFLICT (code) DO NOTHING
-		RETURNING created_at, updated_at
+		RETURNING created_at, updated_at, version
 	`
 
-	err := r.db.QueryRow(query, location.ID, location.Code, location.Name,
-		location.Address, location.IsActive).Scan(&location.CreatedAt, &location.UpdatedAt)
+	err := r.q.QueryRowContext(ctx, query, location.ID, location.Code, location.Name, location.Address,
+		location.City, location.Phone, location.Email, location.Latitude, location.Longitude,
+		location.MaxCapacity, location.ManagerID, location.IsActive).Scan(&location.CreatedAt, &location.UpdatedAt,
+		&location.Version)
 
-	return err
+	return insertError(err)
 }
 
-func (r *venueRepository) GetAllLocations() ([]models.Location, error) {
-	query := `
-		SELECT id, code, name, address, is_active, created_at, updated_at
+// LockLocation locks the location row until the end of the transaction
+// bound by WithTx, serializing writes that span the location's records.
+func (r *venueRepository) LockLocation(ctx context.Context, id string) error {
+	var locked string
+	return r.q.QueryRowContext(ctx, `
+		SELECT id FROM bar_system.locations WHERE id = $1 FOR UPDATE
+	`, id).Scan(&locked)
+}
+
+func (r *venueRepository) GetAllLocations(ctx context.Context, filter *models.LocationFilter) ([]models.Location, error) {
+	if filter == nil {
+		filter = &models.LocationFilter{}
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	} else if !filter.IncludeInactive {
+		conditions = append(conditions, "is_active = true")
+	}
+
+	if filter.CodePrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("code LIKE $%d", argIndex))
+		args = append(args, likePrefix(filter.CodePrefix))
+		argIndex++
+	}
+
+	sortKey, descending := filter.Sort, filter.Descending
+	if sortKey == "" {
+		sortKey, descending = models.LocationSortCreatedAt, true
+	}
+	column, ok := locationSortColumns[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("unknown location sort %q", sortKey)
+	}
+	condition, orderBy, keyArgs := keyset(column, descending, filter.After, argIndex)
+	if condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, keyArgs...)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM bar_system.locations 
-		WHERE is_active = true
-		ORDER BY created_at DESC
-	`
+		%s
+		ORDER BY %s
+		%s
+	`, locationColumns, whereClause, orderBy, limitClause(filter.Limit))
 
-	rows, err := r.db.Query(query)
+	rows, err := r.q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -77,27 +333,24 @@ func (r *venueRepository) GetAllLocations() ([]models.Location, error) {
 	locations := make([]models.Location, 0)
 	for rows.Next() {
 		var location models.Location
-		err := rows.Scan(&location.ID, &location.Code, &location.Name,
-			&location.Address, &location.IsActive, &location.CreatedAt, &location.UpdatedAt)
-		if err != nil {
+		if err := scanLocation(rows, &location); err != nil {
 			return nil, err
 		}
 		locations = append(locations, location)
 	}
 
-	return locations, nil
+	return locations, rows.Err()
 }
 
-func (r *venueRepository) GetLocationByID(id string) (*models.Location, error) {
-	query := `
-		SELECT id, code, name, address, is_active, created_at, updated_at
+func (r *venueRepository) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM bar_system.locations 
 		WHERE id = $1
-	`
+	`, locationColumns)
 
 	location := &models.Location{}
-	err := r.db.QueryRow(query, id).Scan(&location.ID, &location.Code, &location.Name,
-		&location.Address, &location.IsActive, &location.CreatedAt, &location.UpdatedAt)
+	err := scanLocation(r.q.QueryRowContext(ctx, query, id), location)
 
 	if err != nil {
 		return nil, err
@@ -106,52 +359,213 @@ func (r *venueRepository) GetLocationByID(id string) (*models.Location, error) {
 	return location, nil
 }
 
-func (r *venueRepository) UpdateLocation(id string, location *models.Location) error {
+// UpdateLocation writes the location only if it is still at
+// location.Version, then stores the new version in it. It returns
+// sql.ErrNoRows when the location changed in the meantime.
+func (r *venueRepository) UpdateLocation(ctx context.Context, id string, location *models.Location) error {
 	query := `
 		UPDATE bar_system.locations 
-		SET code = $1, name = $2, address = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $5
+		SET code = $1, name = $2, address = $3, city = NULLIF($4, ''), phone = NULLIF($5, ''),
+		    email = NULLIF($6, ''), latitude = $7, longitude = $8, max_capacity = $9,
+		    manager_id = $10, is_active = $11, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $12 AND version = $13
+		RETURNING updated_at, version
 	`
 
-	_, err := r.db.Exec(query, location.Code, location.Name, location.Address, location.IsActive, id)
-	return err
+	err := r.q.QueryRowContext(ctx, query, location.Code, location.Name, location.Address, location.City,
+		location.Phone, location.Email, location.Latitude, location.Longitude, location.MaxCapacity,
+		location.ManagerID, location.IsActive, id, location.Version).Scan(&location.UpdatedAt, &location.Version)
+	return translateError(err)
 }
 
-func (r *venueRepository) DeleteLocation(id string) error {
-	query := `
-		UPDATE bar_system.locations 
-		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+// DeleteLocation deactivates the location together with its active tables,
+// stamping them with the same deleted_at so RestoreLocation brings back
+// exactly those tables. Its table groups are dissolved. It returns
+// sql.ErrNoRows unless the location is active and still at version.
+func (r *venueRepository) DeleteLocation(ctx context.Context, id string, version int) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// CURRENT_TIMESTAMP is fixed for the whole transaction
+	result, err := tx.ExecContext(ctx, `
+		UPDATE bar_system.locations
+		SET is_active = false, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND is_active = true AND version = $2
+	`, id, version)
+	if err != nil {
+		return err
+	}
+	if err := expectUpdated(result); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.table_groups
+		SET dissolved_at = CURRENT_TIMESTAMP
+		WHERE location_id = $1 AND dissolved_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.tables
+		SET is_active = false, group_id = NULL, deleted_at = CURRENT_TIMESTAMP,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE location_id = $1 AND is_active = true
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RestoreLocation reactivates the location and the tables that were
+// deactivated along with it. Tables deleted on their own stay deleted.
+func (r *venueRepository) RestoreLocation(ctx context.Context, id string) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.tables t
+		SET is_active = true, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+		FROM bar_system.locations l
+		WHERE t.location_id = l.id AND l.id = $1
+		  AND t.is_active = false AND t.deleted_at = l.deleted_at
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.locations
+		SET is_active = true, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
-	`
+	`, id)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.Exec(query, id)
+	return tx.Commit()
+}
+
+// PurgeLocation permanently removes the location; its tables, zones and
+// other venue data go with it through ON DELETE CASCADE.
+func (r *venueRepository) PurgeLocation(ctx context.Context, id string) error {
+	_, err := r.q.ExecContext(ctx, `DELETE FROM bar_system.locations WHERE id = $1`, id)
 	return err
 }
 
+// CountLocationReferences counts the orders and staff members that still
+// point at the location and would block a purge.
+func (r *venueRepository) CountLocationReferences(ctx context.Context, id string) (orders, staff int, err error) {
+	err = r.q.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM bar_system.orders WHERE location_id = $1),
+			(SELECT COUNT(*) FROM bar_system.users WHERE venue_id = $1)
+	`, id).Scan(&orders, &staff)
+	return orders, staff, err
+}
+
 // Table operations
-func (r *venueRepository) CreateTable(table *models.Table) error {
+const tableColumns = `id, location_id, zone_id, group_id, code, seats, status, pos_x, pos_y,
+		rotation, shape, width, height, is_active, created_at, updated_at, qr_token, qr_rotated_at,
+		deleted_at, version`
+
+func (r *venueRepository) CreateTable(ctx context.Context, table *models.Table) error {
 	query := `
-		INSERT INTO bar_system.tables (id, location_id, code, seats, status, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO bar_system.tables (id, location_id, zone_id, code, seats, status,
+			pos_x, pos_y, rotation, shape, width, height, is_active, qr_token, qr_rotated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (location_id, code) DO NOTHING
-		RETURNING created_at, updated_at
+		RETURNING created_at, updated_at, version
 	`
 
-	err := r.db.QueryRow(query, table.ID, table.LocationID, table.Code,
-		table.Seats, table.Status, table.IsActive).Scan(&table.CreatedAt, &table.UpdatedAt)
+	err := r.q.QueryRowContext(ctx, query, table.ID, table.LocationID, table.ZoneID, table.Code,
+		table.Seats, table.Status, table.PosX, table.PosY, table.Rotation, table.Shape,
+		table.Width, table.Height, table.IsActive, table.QRToken,
+		table.QRRotatedAt).Scan(&table.CreatedAt, &table.UpdatedAt, &table.Version)
 
-	return err
+	return insertError(err)
 }
 
-func (r *venueRepository) GetTablesByLocation(locationID string) ([]models.Table, error) {
-	query := `
-		SELECT id, location_id, code, seats, status, is_active, created_at, updated_at
+func (r *venueRepository) GetTablesByLocation(ctx context.Context, locationID string, filter *models.TableFilter) ([]models.Table, error) {
+	if filter == nil {
+		filter = &models.TableFilter{}
+	}
+
+	conditions := []string{"location_id = $1"}
+	args := []interface{}{locationID}
+	argIndex := 2
+
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	} else if !filter.IncludeInactive {
+		conditions = append(conditions, "is_active = true")
+	}
+
+	if filter.ZoneID != nil {
+		conditions = append(conditions, fmt.Sprintf("zone_id = $%d", argIndex))
+		args = append(args, *filter.ZoneID)
+		argIndex++
+	}
+
+	if len(filter.StoredStatuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf("status::text = ANY($%d)", argIndex))
+		args = append(args, pq.Array(filter.StoredStatuses))
+		argIndex++
+	}
+
+	if filter.SeatsMin != nil {
+		conditions = append(conditions, fmt.Sprintf("seats >= $%d", argIndex))
+		args = append(args, *filter.SeatsMin)
+		argIndex++
+	}
+
+	if filter.SeatsMax != nil {
+		conditions = append(conditions, fmt.Sprintf("seats <= $%d", argIndex))
+		args = append(args, *filter.SeatsMax)
+		argIndex++
+	}
+
+	if filter.CodePrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("code LIKE $%d", argIndex))
+		args = append(args, likePrefix(filter.CodePrefix))
+		argIndex++
+	}
+
+	sortKey := filter.Sort
+	if sortKey == "" {
+		sortKey = models.TableSortCode
+	}
+	column, ok := tableSortColumns[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("unknown table sort %q", sortKey)
+	}
+	condition, orderBy, keyArgs := keyset(column, filter.Descending, filter.After, argIndex)
+	if condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, keyArgs...)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM bar_system.tables 
-		WHERE location_id = $1 AND is_active = true
-		ORDER BY code
-	`
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, tableColumns, strings.Join(conditions, " AND "), orderBy, limitClause(filter.Limit))
 
-	rows, err := r.db.Query(query, locationID)
+	rows, err := r.q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -160,28 +574,34 @@ func (r *venueRepository) GetTablesByLocation(locationID string) ([]models.Table
 	tables := make([]models.Table, 0)
 	for rows.Next() {
 		var table models.Table
-		err := rows.Scan(&table.ID, &table.LocationID, &table.Code,
-			&table.Seats, &table.Status, &table.IsActive, &table.CreatedAt, &table.UpdatedAt)
-		if err != nil {
+		if err := scanTable(rows, &table); err != nil {
 			return nil, err
 		}
 		tables = append(tables, table)
 	}
 
-	return tables, nil
+	return tables, rows.Err()
 }
 
-func (r *venueRepository) GetTableByID(id string) (*models.Table, error) {
-	query := `
-		SELECT id, location_id, code, seats, status, is_active, created_at, updated_at
+// LockTable locks the table row until the end of the transaction bound by
+// WithTx, serializing writes that must check the table's other records first,
+// e.g. overlapping bookings.
+func (r *venueRepository) LockTable(ctx context.Context, id string) error {
+	var locked string
+	return r.q.QueryRowContext(ctx, `
+		SELECT id FROM bar_system.tables WHERE id = $1 FOR UPDATE
+	`, id).Scan(&locked)
+}
+
+func (r *venueRepository) GetTableByID(ctx context.Context, id string) (*models.Table, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM bar_system.tables 
 		WHERE id = $1
-	`
+	`, tableColumns)
 
 	table := &models.Table{}
-	err := r.db.QueryRow(query, id).Scan(&table.ID, &table.LocationID, &table.Code,
-		&table.Seats, &table.Status, &table.IsActive, &table.CreatedAt, &table.UpdatedAt)
-
+	err := scanTable(r.q.QueryRowContext(ctx, query, id), table)
 	if err != nil {
 		return nil, err
 	}
@@ -189,24 +609,155 @@ func (r *venueRepository) GetTableByID(id string) (*models.Table, error) {
 	return table, nil
 }
 
-func (r *venueRepository) UpdateTable(id string, table *models.Table) error {
+// UpdateTable writes the table only if it is still at table.Version, then
+// stores the new version in it. It returns sql.ErrNoRows when the table
+// changed in the meantime.
+func (r *venueRepository) UpdateTable(ctx context.Context, id string, table *models.Table) error {
 	query := `
 		UPDATE bar_system.tables 
-		SET code = $1, seats = $2, status = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $5
+		SET code = $1, zone_id = $2, seats = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5 AND version = $6
+		RETURNING updated_at, version
 	`
 
-	_, err := r.db.Exec(query, table.Code, table.Seats, table.Status, table.IsActive, id)
-	return err
+	err := r.q.QueryRowContext(ctx, query, table.Code, table.ZoneID, table.Seats, table.IsActive, id,
+		table.Version).Scan(&table.UpdatedAt, &table.Version)
+	return translateError(err)
 }
 
-func (r *venueRepository) DeleteTable(id string) error {
+// DeleteTable returns sql.ErrNoRows unless the table is still at version.
+func (r *venueRepository) DeleteTable(ctx context.Context, id string, version int) error {
 	query := `
 		UPDATE bar_system.tables 
-		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+		SET is_active = false, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND version = $2
+	`
+
+	result, err := r.q.ExecContext(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	return expectUpdated(result)
+}
+
+func (r *venueRepository) RestoreTable(ctx context.Context, id string) error {
+	query := `
+		UPDATE bar_system.tables
+		SET is_active = true, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
-	_, err := r.db.Exec(query, id)
+	_, err := r.q.ExecContext(ctx, query, id)
+	return err
+}
+
+// PurgeTable permanently removes the table with its reservations, status
+// history and QR token.
+func (r *venueRepository) PurgeTable(ctx context.Context, id string) error {
+	_, err := r.q.ExecContext(ctx, `DELETE FROM bar_system.tables WHERE id = $1`, id)
 	return err
 }
+
+func (r *venueRepository) CountTableOrders(ctx context.Context, id string) (int, error) {
+	var count int
+	err := r.q.QueryRowContext(ctx, `SELECT COUNT(*) FROM bar_system.orders WHERE table_id = $1`, id).Scan(&count)
+	return count, err
+}
+
+// Table status operations
+
+// TransitionTableStatus applies every change and appends it to the table's
+// history in one transaction, so tables that move together (such as the
+// members of a group) never end up out of step. It fails with ErrConflict if
+// any table is no longer in its FromStatus.
+func (r *venueRepository) TransitionTableStatus(ctx context.Context, changes ...*models.TableStatusChange) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := applyTableStatusChanges(ctx, tx, changes...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func applyTableStatusChanges(ctx context.Context, tx querier, changes ...*models.TableStatusChange) error {
+	for _, change := range changes {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE bar_system.tables 
+			SET status = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND status = $3
+		`, change.ToStatus, change.TableID, change.FromStatus)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: table %s status is no longer %s", ErrConflict, change.TableID, change.FromStatus)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO bar_system.table_status_history (id, table_id, from_status, to_status, reason, changed_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING changed_at
+		`, change.ID, change.TableID, change.FromStatus, change.ToStatus, change.Reason, change.ChangedBy).Scan(&change.ChangedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *venueRepository) GetTableStatusHistory(ctx context.Context, tableID string) ([]models.TableStatusChange, error) {
+	query := `
+		SELECT id, table_id, from_status, to_status, reason, changed_by, changed_at
+		FROM bar_system.table_status_history
+		WHERE table_id = $1
+		ORDER BY changed_at DESC
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, tableID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]models.TableStatusChange, 0)
+	for rows.Next() {
+		var change models.TableStatusChange
+		err := rows.Scan(&change.ID, &change.TableID, &change.FromStatus, &change.ToStatus,
+			&change.Reason, &change.ChangedBy, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLocation(row rowScanner, location *models.Location) error {
+	return row.Scan(&location.ID, &location.Code, &location.Name, &location.Address, &location.City,
+		&location.Phone, &location.Email, &location.Latitude, &location.Longitude, &location.MaxCapacity,
+		&location.ManagerID, &location.IsActive, &location.CreatedAt, &location.UpdatedAt, &location.DeletedAt,
+		&location.Version)
+}
+
+func scanTable(row rowScanner, table *models.Table) error {
+	return row.Scan(&table.ID, &table.LocationID, &table.ZoneID, &table.GroupID, &table.Code, &table.Seats,
+		&table.Status, &table.PosX, &table.PosY, &table.Rotation, &table.Shape, &table.Width,
+		&table.Height, &table.IsActive, &table.CreatedAt, &table.UpdatedAt, &table.QRToken, &table.QRRotatedAt,
+		&table.DeletedAt, &table.Version)
+}
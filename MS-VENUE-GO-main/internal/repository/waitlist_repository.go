@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+const waitlistColumns = `id, location_id, party_name, phone, party_size, position, quoted_wait_minutes,
+		status, table_id, notes, joined_at, notified_at, seated_at, updated_at`
+
+// Waitlist operations
+
+// CreateWaitlistEntry appends the entry at the end of the location's queue.
+func (r *venueRepository) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error {
+	query := `
+		INSERT INTO bar_system.waitlist_entries (id, location_id, party_name, phone, party_size,
+			position, quoted_wait_minutes, status, notes)
+		VALUES ($1, $2, $3, $4, $5,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM bar_system.waitlist_entries
+			 WHERE location_id = $2 AND status IN ('waiting', 'notified')),
+			$6, $7, $8)
+		RETURNING position, joined_at, updated_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, entry.ID, entry.LocationID, entry.PartyName, entry.Phone,
+		entry.PartySize, entry.QuotedWaitMinutes, entry.Status, entry.Notes).Scan(
+		&entry.Position, &entry.JoinedAt, &entry.UpdatedAt)
+
+	return translateError(err)
+}
+
+// GetWaitlistByLocation returns the parties still waiting, in queue order.
+func (r *venueRepository) GetWaitlistByLocation(ctx context.Context, locationID string) ([]models.WaitlistEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.waitlist_entries
+		WHERE location_id = $1 AND status IN ('waiting', 'notified')
+		ORDER BY position, joined_at
+	`, waitlistColumns)
+
+	rows, err := r.q.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.WaitlistEntry, 0)
+	for rows.Next() {
+		var entry models.WaitlistEntry
+		if err := scanWaitlistEntry(rows, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *venueRepository) GetWaitlistEntryByID(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bar_system.waitlist_entries
+		WHERE id = $1
+	`, waitlistColumns)
+
+	entry := &models.WaitlistEntry{}
+	err := scanWaitlistEntry(r.q.QueryRowContext(ctx, query, id), entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *venueRepository) UpdateWaitlistEntry(ctx context.Context, id string, entry *models.WaitlistEntry) error {
+	query := `
+		UPDATE bar_system.waitlist_entries
+		SET status = $1, notified_at = $2, notes = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING updated_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, entry.Status, entry.NotifiedAt, entry.Notes, id).Scan(&entry.UpdatedAt)
+	return err
+}
+
+// ReorderWaitlist renumbers the given entries 1..n in one transaction.
+func (r *venueRepository) ReorderWaitlist(ctx context.Context, locationID string, entryIDs []string) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, id := range entryIDs {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE bar_system.waitlist_entries
+			SET position = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND location_id = $3 AND status IN ('waiting', 'notified')
+		`, i+1, id, locationID)
+		if err != nil {
+			return err
+		}
+		if err := expectOneRow(result, "waitlist entry", id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SeatWaitlistEntry marks the party as seated and moves its table to occupied
+// in the same transaction, so a table is never handed to two parties.
+func (r *venueRepository) SeatWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry, change *models.TableStatusChange) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := applyTableStatusChanges(ctx, tx, change); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE bar_system.waitlist_entries
+		SET status = 'seated', table_id = $1, seated_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND status IN ('waiting', 'notified')
+	`, change.TableID, change.ChangedAt, entry.ID)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(result, "waitlist entry", entry.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAverageTurnMinutes returns how long, on average, parties stayed at tables
+// of at least minSeats seats, measured from order creation to close. It
+// returns 0 when there is no history.
+func (r *venueRepository) GetAverageTurnMinutes(ctx context.Context, locationID string, minSeats int, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM (o.closed_at - o.created_at)) / 60), 0)
+		FROM bar_system.orders o
+		JOIN bar_system.tables t ON t.id = o.table_id
+		WHERE o.location_id = $1
+		  AND o.status = 'closed'
+		  AND o.closed_at IS NOT NULL
+		  AND o.created_at >= $2
+		  AND t.seats >= $3
+	`
+
+	var minutes float64
+	err := r.q.QueryRowContext(ctx, query, locationID, since, minSeats).Scan(&minutes)
+	return minutes, err
+}
+
+// GetOpenOrderStartTimes maps each table of the location with an open order
+// to the time its earliest open order was created.
+func (r *venueRepository) GetOpenOrderStartTimes(ctx context.Context, locationID string) (map[string]time.Time, error) {
+	query := `
+		SELECT table_id, MIN(created_at)
+		FROM bar_system.orders
+		WHERE location_id = $1 AND status NOT IN ('closed', 'cancelled')
+		GROUP BY table_id
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	startTimes := make(map[string]time.Time)
+	for rows.Next() {
+		var tableID string
+		var startedAt time.Time
+		if err := rows.Scan(&tableID, &startedAt); err != nil {
+			return nil, err
+		}
+		startTimes[tableID] = startedAt
+	}
+
+	return startTimes, rows.Err()
+}
+
+func scanWaitlistEntry(row rowScanner, entry *models.WaitlistEntry) error {
+	return row.Scan(&entry.ID, &entry.LocationID, &entry.PartyName, &entry.Phone, &entry.PartySize,
+		&entry.Position, &entry.QuotedWaitMinutes, &entry.Status, &entry.TableID, &entry.Notes,
+		&entry.JoinedAt, &entry.NotifiedAt, &entry.SeatedAt, &entry.UpdatedAt)
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"ms-venue-go/internal/models"
+)
+
+// Zone operations
+func (r *venueRepository) CreateZone(ctx context.Context, zone *models.Zone) error {
+	query := `
+		INSERT INTO bar_system.zones (id, location_id, name, is_smoking, environment, sort_order, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (location_id, name) DO NOTHING
+		RETURNING created_at, updated_at
+	`
+
+	err := r.q.QueryRowContext(ctx, query, zone.ID, zone.LocationID, zone.Name, zone.IsSmoking,
+		zone.Environment, zone.SortOrder, zone.IsActive).Scan(&zone.CreatedAt, &zone.UpdatedAt)
+
+	return insertError(err)
+}
+
+func (r *venueRepository) GetZonesByLocation(ctx context.Context, locationID string) ([]models.Zone, error) {
+	query := `
+		SELECT id, location_id, name, is_smoking, environment, sort_order, is_active, created_at, updated_at
+		FROM bar_system.zones
+		WHERE location_id = $1 AND is_active = true
+		ORDER BY sort_order, name
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := make([]models.Zone, 0)
+	for rows.Next() {
+		var zone models.Zone
+		err := rows.Scan(&zone.ID, &zone.LocationID, &zone.Name, &zone.IsSmoking,
+			&zone.Environment, &zone.SortOrder, &zone.IsActive, &zone.CreatedAt, &zone.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, rows.Err()
+}
+
+func (r *venueRepository) GetZoneByID(ctx context.Context, id string) (*models.Zone, error) {
+	query := `
+		SELECT id, location_id, name, is_smoking, environment, sort_order, is_active, created_at, updated_at
+		FROM bar_system.zones
+		WHERE id = $1
+	`
+
+	zone := &models.Zone{}
+	err := r.q.QueryRowContext(ctx, query, id).Scan(&zone.ID, &zone.LocationID, &zone.Name, &zone.IsSmoking,
+		&zone.Environment, &zone.SortOrder, &zone.IsActive, &zone.CreatedAt, &zone.UpdatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (r *venueRepository) UpdateZone(ctx context.Context, id string, zone *models.Zone) error {
+	query := `
+		UPDATE bar_system.zones
+		SET name = $1, is_smoking = $2, environment = $3, sort_order = $4, is_active = $5,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+	`
+
+	_, err := r.q.ExecContext(ctx, query, zone.Name, zone.IsSmoking, zone.Environment, zone.SortOrder, zone.IsActive, id)
+	return translateError(err)
+}
+
+// DeleteZone deactivates the zone and detaches its tables so they show up as
+// unassigned instead of pointing at an inactive zone.
+func (r *venueRepository) DeleteZone(ctx context.Context, id string) error {
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.zones
+		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bar_system.tables
+		SET zone_id = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE zone_id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *venueRepository) GetOccupancyByZone(ctx context.Context, locationID string) ([]models.ZoneOccupancy, error) {
+	query := `
+		SELECT COALESCE(z.id::text, ''), COALESCE(z.name, ''),
+		       COUNT(t.id),
+		       COALESCE(SUM(t.seats), 0),
+		       COUNT(*) FILTER (WHERE t.status = 'available'),
+		       COUNT(*) FILTER (WHERE t.status = 'occupied'),
+		       COUNT(*) FILTER (WHERE t.status = 'needs_cleaning'),
+		       COUNT(*) FILTER (WHERE t.status = 'reserved'),
+		       COALESCE(SUM(t.seats) FILTER (WHERE t.status = 'occupied'), 0)
+		FROM bar_system.tables t
+		LEFT JOIN bar_system.zones z ON z.id = t.zone_id
+		WHERE t.location_id = $1 AND t.is_active = true
+		GROUP BY z.id, z.name, z.sort_order
+		ORDER BY z.sort_order NULLS LAST, z.name
+	`
+
+	rows, err := r.q.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occupancy := make([]models.ZoneOccupancy, 0)
+	for rows.Next() {
+		var zone models.ZoneOccupancy
+		err := rows.Scan(&zone.ZoneID, &zone.ZoneName, &zone.TotalTables, &zone.TotalSeats,
+			&zone.Available, &zone.Occupied, &zone.NeedsCleaning, &zone.Reserved, &zone.OccupiedSeats)
+		if err != nil {
+			return nil, err
+		}
+		occupancy = append(occupancy, zone)
+	}
+
+	return occupancy, rows.Err()
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+const maxShiftDuration = 24 * time.Hour
+
+// Waiter assignment operations
+
+// AssignWaiter gives a waiter a zone or a set of tables for a shift. Two
+// assignments whose shifts overlap may not cover the same table, whether it
+// is listed explicitly or belongs to an assigned zone. The check and the
+// insert run in one transaction holding the location lock, so concurrent
+// assignments for the location cannot both pass the check.
+func (s *venueService) AssignWaiter(ctx context.Context, req *models.CreateWaiterAssignmentRequest, assignedBy string) (*models.WaiterAssignment, error) {
+	assignment := &models.WaiterAssignment{
+		ID:         generateUUID(),
+		LocationID: req.LocationID,
+		WaiterID:   req.WaiterID,
+		TableIDs:   req.TableIDs,
+		ShiftStart: req.ShiftStart,
+		ShiftEnd:   req.ShiftEnd,
+	}
+	if req.ZoneID != nil && *req.ZoneID != "" {
+		assignment.ZoneID = req.ZoneID
+	}
+	if assignment.TableIDs == nil {
+		assignment.TableIDs = make([]string, 0)
+	}
+	if assignedBy != "" {
+		assignment.AssignedBy = &assignedBy
+	}
+
+	err := s.inTx(ctx, func(tx *venueService) error {
+		return tx.createWaiterAssignment(ctx, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (s *venueService) createWaiterAssignment(ctx context.Context, assignment *models.WaiterAssignment) error {
+	if err := s.repo.LockLocation(ctx, assignment.LocationID); err != nil {
+		return referenceError("location", err)
+	}
+	if err := s.validateWaiterAssignment(ctx, assignment); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateWaiterAssignment(ctx, assignment); err != nil {
+		return writeError("create", "waiter assignment", assignment.ID, err)
+	}
+	return nil
+}
+
+func (s *venueService) GetWaiterAssignments(ctx context.Context, filter *models.WaiterAssignmentFilter) ([]models.WaiterAssignment, error) {
+	assignments, err := s.repo.GetWaiterAssignments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiter assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+func (s *venueService) GetWaiterAssignmentByID(ctx context.Context, id string) (*models.WaiterAssignment, error) {
+	assignment, err := s.repo.GetWaiterAssignmentByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("waiter assignment", err)
+	}
+	return assignment, nil
+}
+
+func (s *venueService) UnassignWaiter(ctx context.Context, id string) error {
+	err := s.repo.RemoveWaiterAssignment(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove waiter assignment: %w", err)
+	}
+	return nil
+}
+
+// GetWaiterTables returns the assignments a waiter is working at the given
+// time and the tables they cover, with their current status.
+func (s *venueService) GetWaiterTables(ctx context.Context, waiterID string, at time.Time) (*models.WaiterTables, error) {
+	assignments, err := s.repo.GetWaiterAssignments(ctx, &models.WaiterAssignmentFilter{
+		WaiterID: &waiterID,
+		From:     &at,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiter assignments: %w", err)
+	}
+
+	result := &models.WaiterTables{
+		WaiterID:    waiterID,
+		At:          at,
+		Assignments: make([]models.WaiterAssignment, 0),
+		Tables:      make([]models.Table, 0),
+	}
+
+	locationTables := make(map[string][]models.Table)
+	seen := make(map[string]bool)
+	for _, assignment := range assignments {
+		if assignment.ShiftStart.After(at) {
+			continue
+		}
+		result.Assignments = append(result.Assignments, assignment)
+
+		tables, ok := locationTables[assignment.LocationID]
+		if !ok {
+			tables, err = s.GetTablesByLocation(ctx, assignment.LocationID, nil)
+			if err != nil {
+				return nil, err
+			}
+			locationTables[assignment.LocationID] = tables
+		}
+
+		listed := make(map[string]bool, len(assignment.TableIDs))
+		for _, tableID := range assignment.TableIDs {
+			listed[tableID] = true
+		}
+		for _, table := range tables {
+			covered := listed[table.ID] ||
+				(assignment.ZoneID != nil && table.ZoneID != nil && *table.ZoneID == *assignment.ZoneID)
+			if covered && !seen[table.ID] {
+				seen[table.ID] = true
+				result.Tables = append(result.Tables, table)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *venueService) validateWaiterAssignment(ctx context.Context, assignment *models.WaiterAssignment) error {
+	if !assignment.ShiftEnd.After(assignment.ShiftStart) {
+		return validationError("shift_end must be after shift_start")
+	}
+	if assignment.ShiftEnd.Sub(assignment.ShiftStart) > maxShiftDuration {
+		return validationError("a shift cannot be longer than %v", maxShiftDuration)
+	}
+	if !assignment.ShiftEnd.After(time.Now()) {
+		return validationError("shift cannot end in the past")
+	}
+	if (assignment.ZoneID == nil) == (len(assignment.TableIDs) == 0) {
+		return validationError("exactly one of zone_id or table_ids is required")
+	}
+
+	venueID, err := s.repo.GetUserVenueID(ctx, assignment.WaiterID)
+	if err != nil {
+		return referenceError("waiter", err)
+	}
+	if venueID != nil && *venueID != assignment.LocationID {
+		return invalidReference("waiter belongs to another location")
+	}
+
+	covered, err := s.assignmentTableIDs(ctx, assignment, make(map[string]map[string]bool))
+	if err != nil {
+		return err
+	}
+	if assignment.ZoneID != nil {
+		if err := s.validateTableZone(ctx, assignment.LocationID, *assignment.ZoneID); err != nil {
+			return err
+		}
+	} else {
+		if len(covered) != len(assignment.TableIDs) {
+			return validationError("table_ids contains duplicates")
+		}
+		for _, tableID := range assignment.TableIDs {
+			table, err := s.repo.GetTableByID(ctx, tableID)
+			if err != nil {
+				return referenceError("table "+tableID, err)
+			}
+			if !table.IsActive {
+				return invalidReference("table %s is not active", table.Code)
+			}
+			if table.LocationID != assignment.LocationID {
+				return invalidReference("table %s does not belong to location", table.Code)
+			}
+		}
+	}
+
+	return s.checkAssignmentConflicts(ctx, assignment, covered)
+}
+
+// checkAssignmentConflicts rejects the assignment when an assignment whose
+// shift overlaps it already covers one of its tables or the same zone.
+func (s *venueService) checkAssignmentConflicts(ctx context.Context, assignment *models.WaiterAssignment, covered map[string]bool) error {
+	overlapping, err := s.repo.GetWaiterAssignments(ctx, &models.WaiterAssignmentFilter{
+		LocationID: &assignment.LocationID,
+		From:       &assignment.ShiftStart,
+		To:         &assignment.ShiftEnd,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check waiter assignment overlap: %w", err)
+	}
+
+	zoneTables := make(map[string]map[string]bool)
+	for i := range overlapping {
+		other := &overlapping[i]
+		if assignment.ZoneID != nil && other.ZoneID != nil && *assignment.ZoneID == *other.ZoneID {
+			return conflictError("zone is already assigned to waiter %s from %s to %s",
+				other.WaiterID, other.ShiftStart.Format(time.RFC3339), other.ShiftEnd.Format(time.RFC3339))
+		}
+
+		otherCovered, err := s.assignmentTableIDs(ctx, other, zoneTables)
+		if err != nil {
+			return err
+		}
+		for tableID := range otherCovered {
+			if covered[tableID] {
+				return conflictError("table %s is already assigned to waiter %s from %s to %s", tableID,
+					other.WaiterID, other.ShiftStart.Format(time.RFC3339), other.ShiftEnd.Format(time.RFC3339))
+			}
+		}
+	}
+
+	return nil
+}
+
+// assignmentTableIDs returns the tables an assignment covers right now. Zone
+// tables are looked up once per zone through zoneTables.
+func (s *venueService) assignmentTableIDs(ctx context.Context, assignment *models.WaiterAssignment, zoneTables map[string]map[string]bool) (map[string]bool, error) {
+	if assignment.ZoneID == nil {
+		covered := make(map[string]bool, len(assignment.TableIDs))
+		for _, tableID := range assignment.TableIDs {
+			covered[tableID] = true
+		}
+		return covered, nil
+	}
+
+	if covered, ok := zoneTables[*assignment.ZoneID]; ok {
+		return covered, nil
+	}
+	tables, err := s.repo.GetTablesByLocation(ctx, assignment.LocationID, &models.TableFilter{ZoneID: assignment.ZoneID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone tables: %w", err)
+	}
+	covered := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		covered[table.ID] = true
+	}
+	zoneTables[*assignment.ZoneID] = covered
+	return covered, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestConcurrentAssignmentsDoNotShareTables(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "ASG")
+	table := createTestTable(t, svc, location.ID, "T1", 4)
+	shiftStart := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	const attempts = 32
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range errs {
+		waiterID := uuid.NewString()
+		repo.AddUser(waiterID, &location.ID)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.AssignWaiter(ctx, &models.CreateWaiterAssignmentRequest{
+				LocationID: location.ID,
+				WaiterID:   waiterID,
+				TableIDs:   []string{table.ID},
+				ShiftStart: shiftStart.Add(time.Duration(i) * time.Minute),
+				ShiftEnd:   shiftStart.Add(4 * time.Hour),
+			}, "")
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assigned := 0
+	for _, err := range errs {
+		if err == nil {
+			assigned++
+			continue
+		}
+		assertErrorCode(t, err, models.ErrorCodeConflict)
+	}
+	if assigned != 1 {
+		t.Errorf("%d overlapping assignments succeeded, want 1", assigned)
+	}
+}
+
+func TestAssignWaiterUnknownLocation(t *testing.T) {
+	svc, _ := newTestService(t)
+	_, err := svc.AssignWaiter(context.Background(), &models.CreateWaiterAssignmentRequest{
+		LocationID: uuid.NewString(),
+		WaiterID:   uuid.NewString(),
+		ZoneID:     stringPtr(uuid.NewString()),
+		ShiftStart: time.Now().Add(time.Hour),
+		ShiftEnd:   time.Now().Add(2 * time.Hour),
+	}, "")
+	assertErrorCode(t, err, models.ErrorCodeInvalidReference)
+}
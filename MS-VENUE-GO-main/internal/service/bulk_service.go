@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+var tableShapes = map[string]bool{
+	models.TableShapeRound:     true,
+	models.TableShapeSquare:    true,
+	models.TableShapeRectangle: true,
+	models.TableShapeBarStool:  true,
+}
+
+// Bulk operations
+
+// ImportLocations validates every row and, unless dryRun is set or a row is
+// invalid, creates or updates all locations in one transaction.
+func (s *venueService) ImportLocations(ctx context.Context, rows []models.LocationImportRow, dryRun bool) (*models.ImportResult, error) {
+	result := newImportResult(len(rows), dryRun)
+
+	locations := make([]models.Location, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		rowNumber := i + 1
+		location := models.Location{
+			ID:          generateUUID(),
+			Code:        row.Code,
+			Name:        row.Name,
+			Address:     row.Address,
+			City:        row.City,
+			Phone:       row.Phone,
+			Email:       row.Email,
+			Latitude:    row.Latitude,
+			Longitude:   row.Longitude,
+			MaxCapacity: row.MaxCapacity,
+			IsActive:    true,
+		}
+
+		switch {
+		case row.Code == "" || row.Name == "" || row.Address == "":
+			addImportError(result, rowNumber, "code, name and address are required")
+			continue
+		case seen[row.Code] > 0:
+			addImportError(result, rowNumber, fmt.Sprintf("location %s already appears in row %d", row.Code, seen[row.Code]))
+			continue
+		}
+		seen[row.Code] = rowNumber
+
+		if err := validateLocationProfile(&location); err != nil {
+			addImportError(result, rowNumber, err.Error())
+			continue
+		}
+
+		existing, err := s.repo.GetLocationByCode(ctx, row.Code)
+		if err == nil && existing != nil {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+		locations = append(locations, location)
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	created, updated, err := s.repo.ImportLocations(ctx, locations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import locations: %w", err)
+	}
+	result.Created, result.Updated, result.Applied = created, updated, true
+
+	return result, nil
+}
+
+// tableImportTarget caches what a table import needs to know about a location.
+type tableImportTarget struct {
+	location *models.Location
+	plan     *models.FloorPlan
+	zones    map[string]string // zone name -> zone ID, including zones to create
+	tables   map[string]models.Table
+	imported []models.Table
+}
+
+// ImportTables validates every row and, unless dryRun is set or a row is
+// invalid, creates or updates all tables (and any missing zones) in one
+// transaction. Rows left at the origin count as not yet placed on the floor
+// plan; placed rows must fit the canvas and not overlap each other or the
+// location's tables the file leaves out.
+func (s *venueService) ImportTables(ctx context.Context, rows []models.TableImportRow, dryRun bool) (*models.ImportResult, error) {
+	result := newImportResult(len(rows), dryRun)
+
+	targets := make(map[string]*tableImportTarget)
+	newZones := make([]models.Zone, 0)
+	tables := make([]models.Table, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+
+	for i, row := range rows {
+		rowNumber := i + 1
+
+		if row.LocationCode == "" || row.Code == "" {
+			addImportError(result, rowNumber, "location_code and code are required")
+			continue
+		}
+		key := row.LocationCode + "/" + row.Code
+		if seen[key] > 0 {
+			addImportError(result, rowNumber, fmt.Sprintf("table %s of location %s already appears in row %d", row.Code, row.LocationCode, seen[key]))
+			continue
+		}
+		seen[key] = rowNumber
+
+		target, err := s.tableImportTarget(ctx, targets, row.LocationCode)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			addImportError(result, rowNumber, fmt.Sprintf("location %s does not exist or is not active", row.LocationCode))
+			continue
+		}
+
+		table, message := newImportedTable(row, target)
+		if message != "" {
+			addImportError(result, rowNumber, message)
+			continue
+		}
+
+		if existing, ok := target.tables[row.Code]; ok {
+			table.ID = existing.ID
+			table.Status = existing.Status
+			result.Updated++
+		} else {
+			token, err := s.newTableQRToken()
+			if err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			table.QRToken = &token
+			table.QRRotatedAt = &now
+			result.Created++
+		}
+
+		if row.Zone != "" {
+			zoneID, ok := target.zones[row.Zone]
+			if !ok {
+				zone := models.Zone{
+					ID:          generateUUID(),
+					LocationID:  target.location.ID,
+					Name:        row.Zone,
+					Environment: models.ZoneEnvironmentIndoor,
+					IsActive:    true,
+				}
+				newZones = append(newZones, zone)
+				zoneID = zone.ID
+				target.zones[row.Zone] = zoneID
+			}
+			table.ZoneID = &zoneID
+		}
+
+		target.imported = append(target.imported, table)
+		tables = append(tables, table)
+	}
+
+	codes := make([]string, 0, len(targets))
+	for code := range targets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		target := targets[code]
+		if target == nil || len(target.imported) == 0 {
+			continue
+		}
+		changes := &models.FloorPlan{
+			LocationID: target.location.ID,
+			Canvas:     target.plan.Canvas,
+			Tables:     target.imported,
+		}
+		if err := validateFloorPlan(mergeFloorPlan(target.plan, changes)); err != nil {
+			addImportError(result, 0, fmt.Sprintf("location %s: %s", code, err.Error()))
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	created, updated, err := s.repo.ImportTables(ctx, newZones, tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import tables: %w", err)
+	}
+	result.Created, result.Updated, result.Applied = created, updated, true
+
+	return result, nil
+}
+
+// tableImportTarget loads a location by code once per import. It returns nil
+// when the location does not exist or is inactive.
+func (s *venueService) tableImportTarget(ctx context.Context, targets map[string]*tableImportTarget, locationCode string) (*tableImportTarget, error) {
+	if target, ok := targets[locationCode]; ok {
+		return target, nil
+	}
+
+	location, err := s.repo.GetLocationByCode(ctx, locationCode)
+	if err != nil || !location.IsActive {
+		targets[locationCode] = nil
+		return nil, nil
+	}
+
+	plan, err := s.repo.GetFloorPlan(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor plan: %w", err)
+	}
+	zones, err := s.repo.GetZonesByLocation(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+
+	target := &tableImportTarget{
+		location: location,
+		plan:     plan,
+		zones:    make(map[string]string, len(zones)),
+		tables:   make(map[string]models.Table, len(plan.Tables)),
+	}
+	for _, zone := range zones {
+		target.zones[zone.Name] = zone.ID
+	}
+	for _, table := range plan.Tables {
+		target.tables[table.Code] = table
+	}
+
+	targets[locationCode] = target
+	return target, nil
+}
+
+// newImportedTable applies the row to a new table, filling in the same
+// defaults as CreateTable. It returns a message when the row is invalid.
+func newImportedTable(row models.TableImportRow, target *tableImportTarget) (models.Table, string) {
+	table := models.Table{
+		ID:         generateUUID(),
+		LocationID: target.location.ID,
+		Code:       row.Code,
+		Seats:      row.Seats,
+		Status:     models.TableStatusAvailable,
+		PosX:       row.PosX,
+		PosY:       row.PosY,
+		Rotation:   row.Rotation,
+		Shape:      row.Shape,
+		Width:      row.Width,
+		Height:     row.Height,
+		IsActive:   true,
+	}
+	if table.Shape == "" {
+		table.Shape = models.TableShapeSquare
+	}
+	if table.Width == 0 {
+		table.Width = defaultTableSize
+	}
+	if table.Height == 0 {
+		table.Height = defaultTableSize
+	}
+
+	switch {
+	case table.Seats < 1 || table.Seats > 20:
+		return table, "seats must be between 1 and 20"
+	case !tableShapes[table.Shape]:
+		return table, fmt.Sprintf("shape %s is not one of round, square, rectangle, bar_stool", table.Shape)
+	case table.Width <= 0 || table.Height <= 0:
+		return table, "width and height must be greater than zero, or empty for the default size"
+	case table.Rotation < 0 || table.Rotation >= 360:
+		return table, "rotation must be between 0 and 360 degrees"
+	}
+
+	return table, ""
+}
+
+// ExportLocations returns every active location in import format.
+func (s *venueService) ExportLocations(ctx context.Context) ([]models.LocationImportRow, error) {
+	locations, err := s.repo.GetAllLocations(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locations: %w", err)
+	}
+
+	rows := make([]models.LocationImportRow, 0, len(locations))
+	for _, location := range locations {
+		rows = append(rows, models.LocationImportRow{
+			Code:        location.Code,
+			Name:        location.Name,
+			Address:     location.Address,
+			City:        location.City,
+			Phone:       location.Phone,
+			Email:       location.Email,
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
+			MaxCapacity: location.MaxCapacity,
+		})
+	}
+
+	return rows, nil
+}
+
+// ExportTables returns the active tables of one location, or of every
+// active location when locationCode is empty, in import format.
+func (s *venueService) ExportTables(ctx context.Context, locationCode string) ([]models.TableImportRow, error) {
+	var locations []models.Location
+	if locationCode != "" {
+		location, err := s.repo.GetLocationByCode(ctx, locationCode)
+		if err != nil {
+			return nil, lookupError("location", err)
+		}
+		locations = []models.Location{*location}
+	} else {
+		all, err := s.repo.GetAllLocations(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get locations: %w", err)
+		}
+		locations = all
+	}
+
+	rows := make([]models.TableImportRow, 0)
+	for _, location := range locations {
+		zones, err := s.repo.GetZonesByLocation(ctx, location.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zones: %w", err)
+		}
+		zoneNames := make(map[string]string, len(zones))
+		for _, zone := range zones {
+			zoneNames[zone.ID] = zone.Name
+		}
+
+		tables, err := s.repo.GetTablesByLocation(ctx, location.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tables: %w", err)
+		}
+		for _, table := range tables {
+			row := models.TableImportRow{
+				LocationCode: location.Code,
+				Code:         table.Code,
+				Seats:        table.Seats,
+				Shape:        table.Shape,
+				PosX:         table.PosX,
+				PosY:         table.PosY,
+				Rotation:     table.Rotation,
+				Width:        table.Width,
+				Height:       table.Height,
+			}
+			if table.ZoneID != nil {
+				row.Zone = zoneNames[*table.ZoneID]
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func newImportResult(rows int, dryRun bool) *models.ImportResult {
+	return &models.ImportResult{
+		DryRun: dryRun,
+		Rows:   rows,
+		Errors: make([]models.ImportRowError, 0),
+	}
+}
+
+func addImportError(result *models.ImportResult, row int, message string) {
+	result.Errors = append(result.Errors, models.ImportRowError{Row: row, Message: message})
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ms-venue-go/internal/models"
+)
+
+func TestImportTablesValidatesLayout(t *testing.T) {
+	ctx := context.Background()
+
+	row := func(code string, x, y float64) models.TableImportRow {
+		return models.TableImportRow{LocationCode: "IMP", Code: code, Seats: 4, PosX: x, PosY: y}
+	}
+
+	tests := []struct {
+		name  string
+		rows  []models.TableImportRow
+		error string
+	}{
+		{name: "new table in a free spot", rows: []models.TableImportRow{row("T3", 500, 100)}},
+		{name: "new table left at the origin", rows: []models.TableImportRow{row("T3", 0, 0)}},
+		{name: "new table on a table left out of the file", rows: []models.TableImportRow{row("T3", 110, 100)}, error: "overlaps"},
+		{name: "existing table moved onto another", rows: []models.TableImportRow{row("T1", 310, 300)}, error: "overlaps"},
+		{name: "existing table moved away first", rows: []models.TableImportRow{row("T2", 500, 300), row("T3", 300, 300)}},
+		{name: "table outside the canvas", rows: []models.TableImportRow{row("T3", 990, 100)}, error: "outside the canvas"},
+		{
+			name:  "negative size",
+			rows:  []models.TableImportRow{{LocationCode: "IMP", Code: "T3", Seats: 4, Width: -10}},
+			error: "width and height must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "IMP")
+			t1 := createTestTable(t, svc, location.ID, "T1", 4)
+			t2 := createTestTable(t, svc, location.ID, "T2", 4)
+			_, err := svc.SaveFloorPlan(ctx, location.ID, &models.SaveFloorPlanRequest{
+				Canvas: models.Canvas{Width: 1000, Height: 800},
+				Tables: []models.TablePlacement{
+					{TableID: t1.ID, PosX: 100, PosY: 100, Shape: models.TableShapeSquare, Width: 60, Height: 60},
+					{TableID: t2.ID, PosX: 300, PosY: 300, Shape: models.TableShapeSquare, Width: 60, Height: 60},
+				},
+			})
+			if err != nil {
+				t.Fatalf("save plan: %v", err)
+			}
+
+			result, err := svc.ImportTables(ctx, tt.rows, true)
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if tt.error == "" {
+				if len(result.Errors) != 0 {
+					t.Fatalf("unexpected import errors: %+v", result.Errors)
+				}
+				return
+			}
+			if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, tt.error) {
+				t.Fatalf("import errors = %+v, want one containing %q", result.Errors, tt.error)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+// Clone operations
+
+// CloneLocation copies a location's profile, canvas, active zones and
+// tables, settings and weekly hours into a new location. Copied tables start
+// available with fresh QR tokens; the manager, schedule exceptions, table
+// groups and reservations are not copied.
+func (s *venueService) CloneLocation(ctx context.Context, sourceID string, req *models.CloneLocationRequest) (*models.CloneLocationResult, error) {
+	source, err := s.repo.GetLocationByID(ctx, sourceID)
+	if err != nil {
+		return nil, lookupError("location", err)
+	}
+	if existing, err := s.repo.GetLocationByCode(ctx, req.Code); err == nil && existing != nil {
+		return nil, duplicateCode("location", req.Code)
+	}
+
+	location := &models.Location{
+		ID:          generateUUID(),
+		Code:        req.Code,
+		Name:        req.Name,
+		Address:     source.Address,
+		City:        source.City,
+		Phone:       source.Phone,
+		Email:       source.Email,
+		Latitude:    source.Latitude,
+		Longitude:   source.Longitude,
+		MaxCapacity: source.MaxCapacity,
+		IsActive:    true,
+	}
+	if req.Address != "" {
+		location.Address = req.Address
+	}
+	if req.City != "" {
+		location.City = req.City
+	}
+	if err := validateLocationProfile(location); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.repo.GetFloorPlan(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor plan: %w", err)
+	}
+	zones, err := s.repo.GetZonesByLocation(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+	settings, err := s.repo.GetLocationSettings(ctx, []string{sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location settings: %w", err)
+	}
+	schedules, err := s.repo.GetLocationSchedules(ctx, []string{sourceID}, time.Now().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location schedule: %w", err)
+	}
+
+	clone := &models.LocationClone{
+		Location:     location,
+		Canvas:       plan.Canvas,
+		Zones:        make([]models.Zone, 0, len(zones)),
+		ZoneCanvases: make([]models.ZoneCanvas, 0, len(plan.Zones)),
+		Tables:       make([]models.Table, 0, len(plan.Tables)),
+	}
+	result := &models.CloneLocationResult{
+		Location: location,
+		ZoneIDs:  make(map[string]string, len(zones)),
+		TableIDs: make(map[string]string, len(plan.Tables)),
+	}
+
+	for _, zone := range zones {
+		newID := generateUUID()
+		result.ZoneIDs[zone.ID] = newID
+		zone.ID = newID
+		zone.LocationID = location.ID
+		clone.Zones = append(clone.Zones, zone)
+	}
+	for _, zone := range plan.Zones {
+		if newID, ok := result.ZoneIDs[zone.ZoneID]; ok {
+			zone.ZoneID = newID
+			clone.ZoneCanvases = append(clone.ZoneCanvases, zone)
+		}
+	}
+
+	now := time.Now()
+	for _, table := range plan.Tables {
+		token, err := s.newTableQRToken()
+		if err != nil {
+			return nil, err
+		}
+
+		newID := generateUUID()
+		result.TableIDs[table.ID] = newID
+		copied := models.Table{
+			ID:          newID,
+			LocationID:  location.ID,
+			Code:        table.Code,
+			Seats:       table.Seats,
+			Status:      models.TableStatusAvailable,
+			PosX:        table.PosX,
+			PosY:        table.PosY,
+			Rotation:    table.Rotation,
+			Shape:       table.Shape,
+			Width:       table.Width,
+			Height:      table.Height,
+			IsActive:    true,
+			QRToken:     &token,
+			QRRotatedAt: &now,
+		}
+		if table.ZoneID != nil {
+			// Tables of a deactivated zone are copied without a zone
+			if zoneID, ok := result.ZoneIDs[*table.ZoneID]; ok {
+				copied.ZoneID = &zoneID
+			}
+		}
+		clone.Tables = append(clone.Tables, copied)
+	}
+
+	if stored, ok := settings[sourceID]; ok {
+		copied := *stored
+		copied.LocationID = location.ID
+		clone.Settings = &copied
+	}
+	if schedule, ok := schedules[sourceID]; ok {
+		clone.Hours = make([]models.OpeningHours, 0, len(schedule.Hours))
+		for _, hours := range schedule.Hours {
+			hours.ID = generateUUID()
+			hours.LocationID = location.ID
+			clone.Hours = append(clone.Hours, hours)
+		}
+	}
+
+	if err := s.repo.CloneLocation(ctx, clone); err != nil {
+		return nil, writeError("clone", "location", req.Code, err)
+	}
+
+	return result, nil
+}
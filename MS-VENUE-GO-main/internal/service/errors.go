@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+)
+
+// Error is a failure the caller can act on, classified by one of the
+// models.ErrorCode* codes. Any other error returned by the service is an
+// internal failure.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrVersionConflict is returned when a write names a version of a location
+// or table that is no longer current.
+var ErrVersionConflict = &Error{Code: models.ErrorCodeVersionConflict, Message: "resource was modified; reload it and retry"}
+
+func notFound(entity string) error {
+	return &Error{Code: models.ErrorCodeNotFound, Message: entity + " not found"}
+}
+
+func duplicateCode(entity, code string) error {
+	return &Error{Code: models.ErrorCodeDuplicateCode, Message: fmt.Sprintf("%s %q already exists", entity, code)}
+}
+
+// invalidReference reports a request that points at a record which is
+// missing, inactive or belongs to another location.
+func invalidReference(format string, args ...interface{}) error {
+	return &Error{Code: models.ErrorCodeInvalidReference, Message: fmt.Sprintf(format, args...)}
+}
+
+func validationError(format string, args ...interface{}) error {
+	return &Error{Code: models.ErrorCodeValidation, Message: fmt.Sprintf(format, args...)}
+}
+
+// conflictError reports a request that is valid but not allowed in the
+// current state of the record, e.g. an illegal status transition.
+func conflictError(format string, args ...interface{}) error {
+	return &Error{Code: models.ErrorCodeConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func stillReferenced(format string, args ...interface{}) error {
+	return &Error{Code: models.ErrorCodeStillReferenced, Message: fmt.Sprintf(format, args...)}
+}
+
+// lookupError turns a failed repository lookup into a not-found error when
+// the record does not exist, and wraps it as an internal failure otherwise.
+func lookupError(entity string, err error) error {
+	if repository.IsNotFound(err) {
+		return notFound(entity)
+	}
+	return fmt.Errorf("failed to get %s: %w", entity, err)
+}
+
+// referenceError is lookupError for records named in a request body: a
+// missing record makes the request invalid rather than the URL unknown.
+func referenceError(entity string, err error) error {
+	if repository.IsNotFound(err) {
+		return invalidReference("%s does not exist", entity)
+	}
+	return fmt.Errorf("failed to get %s: %w", entity, err)
+}
+
+// writeError classifies a failed insert or update of entity with the given
+// code.
+func writeError(action, entity, code string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrDuplicate):
+		return duplicateCode(entity, code)
+	case errors.Is(err, repository.ErrInvalidReference):
+		return invalidReference("%s references a record that does not exist", entity)
+	}
+	return fmt.Errorf("failed to %s %s: %w", action, entity, err)
+}
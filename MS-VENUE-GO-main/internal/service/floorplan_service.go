@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"ms-venue-go/internal/models"
+)
+
+// defaultTableSize is the width and height, in canvas units, given to new tables.
+const defaultTableSize = 60
+
+// geometryEpsilon lets tables touch edge to edge without counting as overlapping.
+const geometryEpsilon = 1e-6
+
+// Floor plan operations
+func (s *venueService) GetFloorPlan(ctx context.Context, locationID string) (*models.FloorPlan, error) {
+	plan, err := s.repo.GetFloorPlan(ctx, locationID)
+	if err != nil {
+		return nil, lookupError("location", err)
+	}
+
+	if err := s.applyDerivedStatus(ctx, locationID, plan.Tables); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// SaveFloorPlan validates and stores the plan in one transaction. The
+// location is locked first so concurrent saves cannot interleave.
+func (s *venueService) SaveFloorPlan(ctx context.Context, locationID string, req *models.SaveFloorPlanRequest) (*models.FloorPlan, error) {
+	err := s.inTx(ctx, func(tx *venueService) error {
+		return tx.saveFloorPlan(ctx, locationID, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetFloorPlan(ctx, locationID)
+}
+
+func (s *venueService) saveFloorPlan(ctx context.Context, locationID string, req *models.SaveFloorPlanRequest) error {
+	if err := s.repo.LockLocation(ctx, locationID); err != nil {
+		return lookupError("location", err)
+	}
+
+	current, err := s.repo.GetFloorPlan(ctx, locationID)
+	if err != nil {
+		return lookupError("location", err)
+	}
+
+	zones, err := s.repo.GetZonesByLocation(ctx, locationID)
+	if err != nil {
+		return fmt.Errorf("failed to get zones: %w", err)
+	}
+	activeZones := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		activeZones[zone.ID] = true
+	}
+
+	tablesByID := make(map[string]models.Table, len(current.Tables))
+	for _, table := range current.Tables {
+		tablesByID[table.ID] = table
+	}
+
+	plan := &models.FloorPlan{
+		LocationID: locationID,
+		Canvas:     req.Canvas,
+		Zones:      req.Zones,
+		Tables:     make([]models.Table, 0, len(req.Tables)),
+	}
+
+	seenZones := make(map[string]bool, len(req.Zones))
+	for _, zone := range req.Zones {
+		if !activeZones[zone.ZoneID] {
+			return invalidReference("zone %s is not an active zone of this location", zone.ZoneID)
+		}
+		if seenZones[zone.ZoneID] {
+			return validationError("zone %s appears more than once", zone.ZoneID)
+		}
+		seenZones[zone.ZoneID] = true
+	}
+
+	seenTables := make(map[string]bool, len(req.Tables))
+	for _, placement := range req.Tables {
+		table, ok := tablesByID[placement.TableID]
+		if !ok {
+			return invalidReference("table %s is not an active table of this location", placement.TableID)
+		}
+		if seenTables[placement.TableID] {
+			return validationError("table %s appears more than once", placement.TableID)
+		}
+		seenTables[placement.TableID] = true
+
+		table.PosX = placement.PosX
+		table.PosY = placement.PosY
+		table.Rotation = placement.Rotation
+		table.Shape = placement.Shape
+		table.Width = placement.Width
+		table.Height = placement.Height
+		plan.Tables = append(plan.Tables, table)
+	}
+
+	// Only the listed tables and zones are written, but the tables left out
+	// stay on the floor and must still fit around the saved ones
+	if err := validateFloorPlan(mergeFloorPlan(current, plan)); err != nil {
+		return err
+	}
+
+	err = s.repo.SaveFloorPlan(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("failed to save floor plan: %w", err)
+	}
+
+	return nil
+}
+
+// mergeFloorPlan returns the layout a location has after saving changes over
+// its current plan: the canvas of changes, its zone canvases replacing the
+// stored ones, and its tables replacing the stored tables with the same ID or,
+// when new, added. Stored zones and tables the changes leave out are kept.
+func mergeFloorPlan(current, changes *models.FloorPlan) *models.FloorPlan {
+	layout := &models.FloorPlan{
+		LocationID: current.LocationID,
+		Canvas:     changes.Canvas,
+		Zones:      make([]models.ZoneCanvas, 0, len(current.Zones)+len(changes.Zones)),
+		Tables:     make([]models.Table, 0, len(current.Tables)+len(changes.Tables)),
+	}
+
+	changedZones := make(map[string]bool, len(changes.Zones))
+	for _, zone := range changes.Zones {
+		changedZones[zone.ZoneID] = true
+	}
+	for _, zone := range current.Zones {
+		if !changedZones[zone.ZoneID] {
+			layout.Zones = append(layout.Zones, zone)
+		}
+	}
+	layout.Zones = append(layout.Zones, changes.Zones...)
+
+	changedTables := make(map[string]bool, len(changes.Tables))
+	for _, table := range changes.Tables {
+		changedTables[table.ID] = true
+	}
+	for _, table := range current.Tables {
+		if !changedTables[table.ID] {
+			layout.Tables = append(layout.Tables, table)
+		}
+	}
+	layout.Tables = append(layout.Tables, changes.Tables...)
+
+	return layout
+}
+
+// isPlaced reports whether a table has been put on the floor plan. New tables
+// wait at the origin until they are placed.
+func isPlaced(table models.Table) bool {
+	return table.PosX != 0 || table.PosY != 0
+}
+
+// validateFloorPlan checks that every placed table lies inside the canvas it
+// is drawn on and does not overlap another table on that same canvas.
+func validateFloorPlan(plan *models.FloorPlan) error {
+	zoneCanvases := make(map[string]models.Canvas, len(plan.Zones))
+	for _, zone := range plan.Zones {
+		zoneCanvases[zone.ZoneID] = zone.Canvas
+	}
+
+	byCanvas := make(map[string][]footprint)
+	for _, table := range plan.Tables {
+		if !isPlaced(table) {
+			continue
+		}
+
+		canvasKey := ""
+		canvas := plan.Canvas
+		if table.ZoneID != nil {
+			if zoneCanvas, ok := zoneCanvases[*table.ZoneID]; ok {
+				canvasKey = *table.ZoneID
+				canvas = zoneCanvas
+			}
+		}
+
+		fp := newFootprint(table)
+		if !fp.within(canvas) {
+			return validationError("table %s lies outside the canvas", table.Code)
+		}
+
+		for _, other := range byCanvas[canvasKey] {
+			if fp.overlaps(other) {
+				return validationError("table %s overlaps table %s", table.Code, other.code)
+			}
+		}
+		byCanvas[canvasKey] = append(byCanvas[canvasKey], fp)
+	}
+
+	return nil
+}
+
+type point struct {
+	x, y float64
+}
+
+// footprint is the area a table covers on the canvas: a circle for round
+// tables and bar stools, a rotated rectangle otherwise.
+type footprint struct {
+	code    string
+	round   bool
+	center  point
+	radius  float64
+	halfW   float64
+	halfH   float64
+	angle   float64
+	corners [4]point
+}
+
+func newFootprint(table models.Table) footprint {
+	fp := footprint{
+		code:   table.Code,
+		center: point{table.PosX, table.PosY},
+	}
+
+	if table.Shape == models.TableShapeRound || table.Shape == models.TableShapeBarStool {
+		fp.round = true
+		fp.radius = table.Width / 2
+		return fp
+	}
+
+	fp.halfW = table.Width / 2
+	fp.halfH = table.Height / 2
+	fp.angle = table.Rotation * math.Pi / 180
+	sin, cos := math.Sincos(fp.angle)
+	offsets := [4]point{{-fp.halfW, -fp.halfH}, {fp.halfW, -fp.halfH}, {fp.halfW, fp.halfH}, {-fp.halfW, fp.halfH}}
+	for i, o := range offsets {
+		fp.corners[i] = point{
+			x: fp.center.x + o.x*cos - o.y*sin,
+			y: fp.center.y + o.x*sin + o.y*cos,
+		}
+	}
+	return fp
+}
+
+func (fp footprint) within(canvas models.Canvas) bool {
+	inside := func(p point, margin float64) bool {
+		return p.x-margin >= -geometryEpsilon && p.y-margin >= -geometryEpsilon &&
+			p.x+margin <= canvas.Width+geometryEpsilon && p.y+margin <= canvas.Height+geometryEpsilon
+	}
+
+	if fp.round {
+		return inside(fp.center, fp.radius)
+	}
+	for _, corner := range fp.corners {
+		if !inside(corner, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fp footprint) overlaps(other footprint) bool {
+	switch {
+	case fp.round && other.round:
+		return math.Hypot(fp.center.x-other.center.x, fp.center.y-other.center.y) < fp.radius+other.radius-geometryEpsilon
+	case fp.round:
+		return other.overlapsCircle(fp)
+	case other.round:
+		return fp.overlapsCircle(other)
+	default:
+		return fp.overlapsRect(other)
+	}
+}
+
+// overlapsCircle moves the circle into the rectangle's local frame and
+// compares the distance to the closest point of the rectangle.
+func (fp footprint) overlapsCircle(circle footprint) bool {
+	sin, cos := math.Sincos(-fp.angle)
+	dx := circle.center.x - fp.center.x
+	dy := circle.center.y - fp.center.y
+	localX := dx*cos - dy*sin
+	localY := dx*sin + dy*cos
+
+	closestX := math.Max(-fp.halfW, math.Min(localX, fp.halfW))
+	closestY := math.Max(-fp.halfH, math.Min(localY, fp.halfH))
+	return math.Hypot(localX-closestX, localY-closestY) < circle.radius-geometryEpsilon
+}
+
+// overlapsRect applies the separating axis theorem to two rotated rectangles.
+func (fp footprint) overlapsRect(other footprint) bool {
+	for _, rect := range []footprint{fp, other} {
+		for i := 0; i < 2; i++ {
+			edge := point{rect.corners[i+1].x - rect.corners[i].x, rect.corners[i+1].y - rect.corners[i].y}
+			axis := point{-edge.y, edge.x}
+
+			minA, maxA := project(fp.corners, axis)
+			minB, maxB := project(other.corners, axis)
+			length := math.Hypot(axis.x, axis.y)
+			if maxA <= minB+geometryEpsilon*length || maxB <= minA+geometryEpsilon*length {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func project(corners [4]point, axis point) (float64, float64) {
+	min := math.Inf(1)
+	max := math.Inf(-1)
+	for _, c := range corners {
+		v := c.x*axis.x + c.y*axis.y
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return min, max
+}
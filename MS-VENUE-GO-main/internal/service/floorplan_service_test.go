@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestSaveFloorPlanValidatesWholeLayout(t *testing.T) {
+	ctx := context.Background()
+
+	place := func(table *models.Table, x, y float64) models.TablePlacement {
+		return models.TablePlacement{TableID: table.ID, PosX: x, PosY: y, Shape: models.TableShapeSquare, Width: 60, Height: 60}
+	}
+	canvas := models.Canvas{Width: 800, Height: 600}
+
+	tests := []struct {
+		name string
+		req  func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest
+		code string
+	}{
+		{
+			name: "move a listed table to a free spot",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{Canvas: canvas, Tables: []models.TablePlacement{place(a, 500, 100)}}
+			},
+		},
+		{
+			name: "move a listed table onto an unlisted one",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{Canvas: canvas, Tables: []models.TablePlacement{place(a, 320, 300)}}
+			},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "shrink the canvas past an unlisted table",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{Canvas: models.Canvas{Width: 250, Height: 250}}
+			},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "unlisted zone keeps its canvas",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{Canvas: canvas, Tables: []models.TablePlacement{place(c, 400, 400)}}
+			},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "tables on different canvases may share coordinates",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{Canvas: canvas, Tables: []models.TablePlacement{place(a, 150, 150)}}
+			},
+		},
+		{
+			name: "shrink an unlisted table's zone",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{
+					Canvas: canvas,
+					Zones:  []models.ZoneCanvas{{ZoneID: zone.ID, Canvas: models.Canvas{Width: 100, Height: 100}}},
+				}
+			},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "unknown zone",
+			req: func(a, b, c *models.Table, zone *models.Zone) models.SaveFloorPlanRequest {
+				return models.SaveFloorPlanRequest{
+					Canvas: canvas,
+					Zones:  []models.ZoneCanvas{{ZoneID: uuid.NewString(), Canvas: canvas}},
+				}
+			},
+			code: models.ErrorCodeInvalidReference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "PLAN")
+			zone := createTestZone(t, svc, location.ID, "Terraza")
+			a := createTestTable(t, svc, location.ID, "A", 4)
+			b := createTestTable(t, svc, location.ID, "B", 4)
+			c, err := svc.CreateTable(ctx, &models.CreateTableRequest{LocationID: location.ID, ZoneID: &zone.ID, Code: "C", Seats: 4})
+			if err != nil {
+				t.Fatalf("create table: %v", err)
+			}
+			// Left at the origin: not placed, so never in the way
+			createTestTable(t, svc, location.ID, "D", 4)
+
+			_, err = svc.SaveFloorPlan(ctx, location.ID, &models.SaveFloorPlanRequest{
+				Canvas: canvas,
+				Zones:  []models.ZoneCanvas{{ZoneID: zone.ID, Canvas: models.Canvas{Width: 200, Height: 200}}},
+				Tables: []models.TablePlacement{place(a, 100, 100), place(b, 300, 300), place(c, 150, 150)},
+			})
+			if err != nil {
+				t.Fatalf("save initial plan: %v", err)
+			}
+
+			req := tt.req(a, b, c, zone)
+			_, err = svc.SaveFloorPlan(ctx, location.ID, &req)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
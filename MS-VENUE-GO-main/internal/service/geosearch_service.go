@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"ms-venue-go/internal/models"
+)
+
+const (
+	earthRadiusKm = 6371.0
+
+	defaultSearchRadiusKm = 10
+	maxSearchRadiusKm     = 500
+)
+
+// Geosearch operations
+
+// FindNearbyLocations returns the active locations with coordinates within
+// the radius of the given point, nearest first.
+func (s *venueService) FindNearbyLocations(ctx context.Context, query *models.NearbyLocationsQuery) ([]models.NearbyLocation, error) {
+	if query.Latitude < -90 || query.Latitude > 90 {
+		return nil, validationError("lat must be between -90 and 90")
+	}
+	if query.Longitude < -180 || query.Longitude > 180 {
+		return nil, validationError("lng must be between -180 and 180")
+	}
+	if query.RadiusKm == 0 {
+		query.RadiusKm = defaultSearchRadiusKm
+	}
+	if query.RadiusKm < 0 || query.RadiusKm > maxSearchRadiusKm {
+		return nil, validationError("radius_km must be between 0 and %d", maxSearchRadiusKm)
+	}
+
+	// GetAllLocations only returns active locations and fills open_now
+	locations, err := s.GetAllLocations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nearby := make([]models.NearbyLocation, 0)
+	for _, location := range locations {
+		if location.Latitude == nil || location.Longitude == nil {
+			continue
+		}
+		if query.OpenOnly && (location.OpenNow == nil || !*location.OpenNow) {
+			continue
+		}
+
+		distance := greatCircleDistanceKm(query.Latitude, query.Longitude, *location.Latitude, *location.Longitude)
+		if distance > query.RadiusKm {
+			continue
+		}
+
+		nearby = append(nearby, models.NearbyLocation{
+			Location:   location,
+			DistanceKm: math.Round(distance*100) / 100,
+		})
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+
+	for i := range nearby {
+		tables, err := s.GetTablesByLocation(ctx, nearby[i].ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if table.IsActive && table.Status == models.TableStatusAvailable {
+				nearby[i].AvailableTables++
+			}
+		}
+	}
+
+	return nearby, nil
+}
+
+// greatCircleDistanceKm applies the haversine formula to two points given in degrees.
+func greatCircleDistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
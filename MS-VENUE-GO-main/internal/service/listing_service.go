@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var (
+	locationSorts = []string{models.LocationSortCreatedAt, models.LocationSortName, models.LocationSortCode}
+	tableSorts    = []string{models.TableSortCode, models.TableSortSeats, models.TableSortCreatedAt}
+)
+
+// cursor is the opaque next_cursor handed to clients: the sort of the
+// listing and the key of the last row of the page.
+type cursor struct {
+	Sort  string `json:"sort"`
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+// page is a validated page request.
+type page struct {
+	sort       string // as requested, e.g. "-seats"
+	key        string // sort key without direction
+	descending bool
+	limit      int
+	after      *models.PageKey
+}
+
+// parsePage validates req against the sort keys a listing supports;
+// defaultSort is used when req names none.
+func parsePage(req models.PageRequest, sorts []string, defaultSort string) (*page, error) {
+	p := &page{sort: req.Sort, limit: req.Limit}
+	if p.sort == "" {
+		p.sort = defaultSort
+	}
+	p.key = strings.TrimPrefix(p.sort, "-")
+	p.descending = p.key != p.sort
+	if !containsString(sorts, p.key) {
+		return nil, validationError("sort must be one of %s, optionally prefixed with -", strings.Join(sorts, ", "))
+	}
+
+	switch {
+	case p.limit == 0:
+		p.limit = defaultPageSize
+	case p.limit < 1 || p.limit > maxPageSize:
+		return nil, validationError("limit must be between 1 and %d", maxPageSize)
+	}
+
+	if req.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+		var c cursor
+		if err != nil || json.Unmarshal(raw, &c) != nil {
+			return nil, validationError("invalid cursor")
+		}
+		if c.Sort != p.sort {
+			return nil, validationError("cursor was issued for sort %q, not %q", c.Sort, p.sort)
+		}
+		if _, err := uuid.Parse(c.ID); err != nil || !validCursorValue(p.key, c.Value) {
+			return nil, validationError("invalid cursor")
+		}
+		p.after = &models.PageKey{Value: c.Value, ID: c.ID}
+	}
+	return p, nil
+}
+
+// next returns the cursor of the page that follows the row with the given
+// sort key value and ID.
+func (p *page) next(value, id string) *string {
+	raw, _ := json.Marshal(cursor{Sort: p.sort, Value: value, ID: id})
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return &encoded
+}
+
+// validCursorValue reports whether value can be cast to the type of the
+// column behind the sort key, as the repository does when comparing it.
+// Locations and tables share the created_at key.
+func validCursorValue(key, value string) bool {
+	switch key {
+	case models.TableSortSeats:
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	case models.TableSortCreatedAt:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	default:
+		// text columns reject NUL
+		return !strings.ContainsRune(value, 0)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTime formats a timestamp sort key so that Postgres reads it back
+// without losing precision.
+func sortTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// Listing operations
+
+// ListLocations returns one page of the locations matching filter. The
+// default order is newest first.
+func (s *venueService) ListLocations(ctx context.Context, filter *models.LocationFilter, req models.PageRequest) (*models.LocationPage, error) {
+	p, err := parsePage(req, locationSorts, "-"+models.LocationSortCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &models.LocationFilter{}
+	}
+	filter.Sort, filter.Descending, filter.After = p.key, p.descending, p.after
+	filter.Limit = p.limit + 1 // one more row tells whether another page follows
+
+	locations, err := s.repo.GetAllLocations(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locations: %w", err)
+	}
+
+	result := &models.LocationPage{Items: locations}
+	if len(locations) > p.limit {
+		result.Items = locations[:p.limit]
+		last := result.Items[p.limit-1]
+		var value string
+		switch p.key {
+		case models.LocationSortName:
+			value = last.Name
+		case models.LocationSortCode:
+			value = last.Code
+		default:
+			value = sortTime(last.CreatedAt)
+		}
+		result.NextCursor = p.next(value, last.ID)
+	}
+
+	if err := s.applyOpenNow(ctx, result.Items); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListTables returns one page of a location's tables matching filter. The
+// default order is by code. A status filter matches the status the listing
+// shows, which bookings about to start can change, so tables are read in
+// batches until the page is full.
+func (s *venueService) ListTables(ctx context.Context, locationID string, filter *models.TableFilter, req models.PageRequest) (*models.TablePage, error) {
+	p, err := parsePage(req, tableSorts, models.TableSortCode)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &models.TableFilter{}
+	}
+	if filter.Status != nil {
+		if _, ok := tableTransitions[*filter.Status]; !ok {
+			return nil, validationError("status must be one of available, occupied, needs_cleaning, reserved")
+		}
+		filter.StoredStatuses = storedTableStatuses(*filter.Status)
+	}
+	if filter.SeatsMin != nil && filter.SeatsMax != nil && *filter.SeatsMin > *filter.SeatsMax {
+		return nil, validationError("seats_min cannot be greater than seats_max")
+	}
+	filter.Sort, filter.Descending, filter.After = p.key, p.descending, p.after
+	filter.Limit = p.limit + 1
+
+	tables := make([]models.Table, 0)
+	for {
+		batch, err := s.repo.GetTablesByLocation(ctx, locationID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tables: %w", err)
+		}
+		if err := s.applyDerivedStatus(ctx, locationID, batch); err != nil {
+			return nil, err
+		}
+		for _, table := range batch {
+			if filter.Status == nil || table.Status == *filter.Status {
+				tables = append(tables, table)
+			}
+		}
+		if len(tables) > p.limit || len(batch) < filter.Limit {
+			break
+		}
+		last := batch[len(batch)-1]
+		filter.After = &models.PageKey{Value: tableSortValue(p.key, last), ID: last.ID}
+	}
+
+	result := &models.TablePage{Items: tables}
+	if len(tables) > p.limit {
+		result.Items = tables[:p.limit]
+		last := result.Items[p.limit-1]
+		result.NextCursor = p.next(tableSortValue(p.key, last), last.ID)
+	}
+
+	return result, nil
+}
+
+// tableSortValue is table's value for the sort key, as stored in cursors.
+func tableSortValue(key string, table models.Table) string {
+	switch key {
+	case models.TableSortSeats:
+		return strconv.Itoa(table.Seats)
+	case models.TableSortCreatedAt:
+		return sortTime(table.CreatedAt)
+	default:
+		return table.Code
+	}
+}
+
+// storedTableStatuses lists the stored statuses of the tables that can be
+// listed in status: a booking about to start shows an available table as
+// reserved, and a reserved table without one as available.
+func storedTableStatuses(status string) []string {
+	switch status {
+	case models.TableStatusAvailable, models.TableStatusReserved:
+		return []string{models.TableStatusAvailable, models.TableStatusReserved}
+	default:
+		return []string{status}
+	}
+}
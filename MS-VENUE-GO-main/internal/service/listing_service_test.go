@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestListTablesFilters(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "LST")
+	zone := createTestZone(t, svc, location.ID, "Terrace")
+	createTestTable(t, svc, location.ID, "A1", 2)
+	createTestTable(t, svc, location.ID, "A2", 4)
+	b1 := createTestTable(t, svc, location.ID, "B1", 6)
+	b2 := createTestTable(t, svc, location.ID, "B2", 8)
+	if _, err := svc.UpdateTable(ctx, b1.ID, &models.UpdateTableRequest{ZoneID: &zone.ID}, 0); err != nil {
+		t.Fatalf("move table to zone: %v", err)
+	}
+	setTableStatus(t, svc, b1.ID, models.TableStatusOccupied)
+	if err := svc.DeleteTable(ctx, b2.ID, 0); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.TableFilter
+		codes  string
+	}{
+		{name: "active tables", codes: "A1 A2 B1"},
+		{name: "code prefix", filter: models.TableFilter{CodePrefix: "B"}, codes: "B1"},
+		{name: "seats range", filter: models.TableFilter{SeatsMin: intPtr(4), SeatsMax: intPtr(6)}, codes: "A2 B1"},
+		{name: "zone", filter: models.TableFilter{ZoneID: &zone.ID}, codes: "B1"},
+		{name: "status", filter: models.TableFilter{Status: stringPtr(models.TableStatusAvailable)}, codes: "A1 A2"},
+		{name: "inactive only", filter: models.TableFilter{IsActive: boolPtr(false)}, codes: "B2"},
+		{name: "including inactive", filter: models.TableFilter{IncludeInactive: true, CodePrefix: "B"}, codes: "B1 B2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			page, err := svc.ListTables(ctx, location.ID, &filter, models.PageRequest{})
+			if err != nil {
+				t.Fatalf("list tables: %v", err)
+			}
+			var codes []string
+			for _, table := range page.Items {
+				codes = append(codes, table.Code)
+			}
+			if got := fmt.Sprint(codes); got != "["+tt.codes+"]" {
+				t.Errorf("listed %s, want [%s]", got, tt.codes)
+			}
+			if page.NextCursor != nil {
+				t.Errorf("single page has next_cursor %q", *page.NextCursor)
+			}
+		})
+	}
+}
+
+func TestListTablesPaginates(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "PAGE")
+	var all []models.Table
+	for i := 1; i <= 7; i++ {
+		all = append(all, *createTestTable(t, svc, location.ID, fmt.Sprintf("T%d", i), 2+i%3))
+	}
+
+	tests := []struct {
+		sort string
+		less func(a, b models.Table) bool
+	}{
+		{sort: "", less: func(a, b models.Table) bool { return a.Code < b.Code }},
+		{sort: "-code", less: func(a, b models.Table) bool { return a.Code > b.Code }},
+		{sort: "seats", less: func(a, b models.Table) bool {
+			if a.Seats != b.Seats {
+				return a.Seats < b.Seats
+			}
+			return a.ID < b.ID
+		}},
+		{sort: "-created_at", less: func(a, b models.Table) bool {
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.ID > b.ID
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort "+tt.sort, func(t *testing.T) {
+			want := append([]models.Table(nil), all...)
+			sort.Slice(want, func(i, j int) bool { return tt.less(want[i], want[j]) })
+
+			var listed []models.Table
+			req := models.PageRequest{Sort: tt.sort, Limit: 3}
+			for pages := 1; ; pages++ {
+				page, err := svc.ListTables(ctx, location.ID, nil, req)
+				if err != nil {
+					t.Fatalf("list page %d: %v", pages, err)
+				}
+				listed = append(listed, page.Items...)
+				if page.NextCursor == nil {
+					if pages != 3 {
+						t.Errorf("listed %d pages, want 3", pages)
+					}
+					break
+				}
+				req.Cursor = *page.NextCursor
+			}
+
+			if len(listed) != len(want) {
+				t.Fatalf("listed %d tables, want %d", len(listed), len(want))
+			}
+			for i := range want {
+				if listed[i].ID != want[i].ID {
+					t.Fatalf("position %d is %s, want %s", i, listed[i].Code, want[i].Code)
+				}
+			}
+		})
+	}
+}
+
+// The status filter matches the status the listing shows, not the stored one.
+func TestListTablesFiltersByDerivedStatus(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "DRV")
+	booked := createTestTable(t, svc, location.ID, "T1", 4)
+	released := createTestTable(t, svc, location.ID, "T2", 4)
+	createTestTable(t, svc, location.ID, "T3", 4)
+	occupied := createTestTable(t, svc, location.ID, "T4", 4)
+	setTableStatus(t, svc, occupied.ID, models.TableStatusOccupied)
+
+	// T1 is stored as available but its booking starts within the hold window
+	starts := time.Now().Add(10 * time.Minute)
+	err := repo.CreateReservation(ctx, &models.Reservation{
+		ID: uuid.NewString(), LocationID: location.ID, TableID: booked.ID, PartyName: "Rojas", Phone: "+573001234567",
+		PartySize: 2, StartsAt: starts, EndsAt: starts.Add(time.Hour), Status: models.ReservationStatusBooked,
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	// T2 is stored as reserved but its booking is gone
+	reservation, err := svc.CreateReservation(ctx, &models.CreateReservationRequest{
+		LocationID: location.ID, TableID: released.ID, PartyName: "Gómez", Phone: "+573001234567",
+		PartySize: 2, StartsAt: starts, EndsAt: starts.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	reservation.Status = models.ReservationStatusCancelled
+	if err := repo.UpdateReservation(ctx, reservation.ID, reservation); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+
+	tests := []struct {
+		status string
+		pages  []string
+	}{
+		{status: models.TableStatusReserved, pages: []string{"T1"}},
+		{status: models.TableStatusAvailable, pages: []string{"T2", "T3"}},
+		{status: models.TableStatusOccupied, pages: []string{"T4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			var pages []string
+			req := models.PageRequest{Limit: 1}
+			for {
+				page, err := svc.ListTables(ctx, location.ID, &models.TableFilter{Status: stringPtr(tt.status)}, req)
+				if err != nil {
+					t.Fatalf("list tables: %v", err)
+				}
+				for _, table := range page.Items {
+					if table.Status != tt.status {
+						t.Errorf("%s is listed as %s", table.Code, table.Status)
+					}
+					pages = append(pages, table.Code)
+				}
+				if page.NextCursor == nil {
+					break
+				}
+				req.Cursor = *page.NextCursor
+			}
+			if fmt.Sprint(pages) != fmt.Sprint(tt.pages) {
+				t.Errorf("pages = %v, want %v", pages, tt.pages)
+			}
+		})
+	}
+}
+
+func TestListTablesRejectsTamperedCursor(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	location := createTestLocation(t, svc, "CUR")
+	id := uuid.NewString()
+
+	tests := []struct {
+		name  string
+		sort  string
+		value string
+		id    string
+	}{
+		{name: "seats that are not a number", sort: "seats", value: "four", id: id},
+		{name: "seats out of range", sort: "seats", value: "99999999999", id: id},
+		{name: "created_at that is not a time", sort: "-created_at", value: "yesterday", id: id},
+		{name: "code with a NUL byte", sort: "code", value: "T\x001", id: id},
+		{name: "id that is not a UUID", sort: "code", value: "T1", id: "1 OR 1=1"},
+		{name: "missing id", sort: "code", value: "T1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := (&page{sort: tt.sort}).next(tt.value, tt.id)
+			_, err := svc.ListTables(ctx, location.ID, nil, models.PageRequest{Sort: tt.sort, Cursor: *cursor})
+			assertErrorCode(t, err, models.ErrorCodeValidation)
+		})
+	}
+
+	cursor := (&page{sort: "seats"}).next("4", id)
+	if _, err := svc.ListTables(ctx, location.ID, nil, models.PageRequest{Sort: "seats", Cursor: *cursor}); err != nil {
+		t.Errorf("well-formed cursor: %v", err)
+	}
+}
+
+func TestListLocations(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	bar := createTestLocation(t, svc, "BAR-1")
+	createTestLocation(t, svc, "BAR-2")
+	createTestLocation(t, svc, "CAFE-1")
+	if err := svc.DeleteLocation(ctx, bar.ID, 0); err != nil {
+		t.Fatalf("delete location: %v", err)
+	}
+
+	page, err := svc.ListLocations(ctx, &models.LocationFilter{CodePrefix: "BAR"}, models.PageRequest{Sort: "code"})
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Code != "BAR-2" {
+		t.Errorf("active BAR locations = %+v", page.Items)
+	}
+
+	page, err = svc.ListLocations(ctx, &models.LocationFilter{IncludeInactive: true}, models.PageRequest{Sort: "-code", Limit: 2})
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].Code != "CAFE-1" || page.Items[1].Code != "BAR-2" || page.NextCursor == nil {
+		t.Fatalf("first page = %+v", page)
+	}
+	page, err = svc.ListLocations(ctx, &models.LocationFilter{IncludeInactive: true}, models.PageRequest{Sort: "-code", Limit: 2, Cursor: *page.NextCursor})
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != bar.ID || page.NextCursor != nil {
+		t.Errorf("last page = %+v", page)
+	}
+
+	tests := []struct {
+		name string
+		req  models.PageRequest
+	}{
+		{name: "unknown sort", req: models.PageRequest{Sort: "seats"}},
+		{name: "negative limit", req: models.PageRequest{Limit: -1}},
+		{name: "limit too large", req: models.PageRequest{Limit: maxPageSize + 1}},
+		{name: "malformed cursor", req: models.PageRequest{Cursor: "%%%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ListLocations(ctx, nil, tt.req)
+			assertErrorCode(t, err, models.ErrorCodeValidation)
+		})
+	}
+}
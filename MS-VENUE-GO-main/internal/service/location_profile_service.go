@@ -0,0 +1,48 @@
+package service
+
+import (
+	"net/mail"
+	"regexp"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// e164Pattern matches international phone numbers such as +573001234567.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// validateLocationProfile checks the optional contact, geo and capacity
+// fields of a location. Empty strings and nil values mean "not set".
+func validateLocationProfile(location *models.Location) error {
+	if location.Phone != "" && !e164Pattern.MatchString(location.Phone) {
+		return validationError("phone must be in E.164 format, e.g. +573001234567")
+	}
+	if location.Email != "" {
+		address, err := mail.ParseAddress(location.Email)
+		if err != nil || address.Address != location.Email {
+			return validationError("email is not a valid address")
+		}
+	}
+
+	if (location.Latitude == nil) != (location.Longitude == nil) {
+		return validationError("latitude and longitude must be provided together")
+	}
+	if location.Latitude != nil && (*location.Latitude < -90 || *location.Latitude > 90) {
+		return validationError("latitude must be between -90 and 90")
+	}
+	if location.Longitude != nil && (*location.Longitude < -180 || *location.Longitude > 180) {
+		return validationError("longitude must be between -180 and 180")
+	}
+
+	if location.MaxCapacity != nil && *location.MaxCapacity <= 0 {
+		return validationError("max_capacity must be greater than zero")
+	}
+	if location.ManagerID != nil {
+		if _, err := uuid.Parse(*location.ManagerID); err != nil {
+			return validationError("manager_id must be a valid user ID")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+)
+
+// reservationHoldWindow is how long before a booking starts its table is held as reserved.
+const reservationHoldWindow = 30 * time.Minute
+
+// Reservation operations
+func (s *venueService) CreateReservation(ctx context.Context, req *models.CreateReservationRequest) (*models.Reservation, error) {
+	reservation := &models.Reservation{
+		ID:         generateUUID(),
+		LocationID: req.LocationID,
+		TableID:    req.TableID,
+		PartyName:  req.PartyName,
+		Phone:      req.Phone,
+		PartySize:  req.PartySize,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Status:     models.ReservationStatusBooked,
+		Notes:      req.Notes,
+	}
+
+	err := s.inTx(ctx, func(tx *venueService) error {
+		if err := tx.validateReservation(ctx, reservation); err != nil {
+			return err
+		}
+		if err := tx.repo.CreateReservation(ctx, reservation); err != nil {
+			return writeError("create", "reservation", reservation.ID, err)
+		}
+		return tx.syncTableStatus(ctx, reservation.TableID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *venueService) GetReservations(ctx context.Context, filter *models.ReservationFilter) ([]models.Reservation, error) {
+	reservations, err := s.repo.GetReservations(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+func (s *venueService) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	reservation, err := s.repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("reservation", err)
+	}
+	return reservation, nil
+}
+
+// UpdateReservation changes a booked reservation. The overlap check and the
+// write run in one transaction, like CreateReservation.
+func (s *venueService) UpdateReservation(ctx context.Context, id string, req *models.UpdateReservationRequest) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		reservation, err = tx.updateReservation(ctx, id, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *venueService) updateReservation(ctx context.Context, id string, req *models.UpdateReservationRequest) (*models.Reservation, error) {
+	// Get existing reservation
+	reservation, err := s.repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("reservation", err)
+	}
+
+	if reservation.Status != models.ReservationStatusBooked {
+		return nil, conflictError("only booked reservations can be modified")
+	}
+
+	previousTableID := reservation.TableID
+
+	// Update fields if provided
+	if req.TableID != "" {
+		reservation.TableID = req.TableID
+	}
+	if req.PartyName != "" {
+		reservation.PartyName = req.PartyName
+	}
+	if req.Phone != "" {
+		reservation.Phone = req.Phone
+	}
+	if req.PartySize > 0 {
+		reservation.PartySize = req.PartySize
+	}
+	if req.StartsAt != nil {
+		reservation.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		reservation.EndsAt = *req.EndsAt
+	}
+	if req.Notes != nil {
+		reservation.Notes = *req.Notes
+	}
+
+	if err := s.validateReservation(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateReservation(ctx, id, reservation)
+	if err != nil {
+		return nil, writeError("update", "reservation", id, err)
+	}
+
+	if previousTableID != reservation.TableID {
+		if err := s.syncTableStatus(ctx, previousTableID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.syncTableStatus(ctx, reservation.TableID); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// UpdateReservationStatus moves a booked reservation to its final status.
+// Seating also occupies the table, in the same transaction.
+func (s *venueService) UpdateReservationStatus(ctx context.Context, id string, req *models.UpdateReservationStatusRequest) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		reservation, err = tx.updateReservationStatus(ctx, id, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *venueService) updateReservationStatus(ctx context.Context, id string, req *models.UpdateReservationStatusRequest) (*models.Reservation, error) {
+	reservation, err := s.repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("reservation", err)
+	}
+
+	// Only booked reservations move here: a reservation is either seated,
+	// marked as a no-show or cancelled. Seated ones are completed when their
+	// table is freed (see completeSeatedReservations).
+	if reservation.Status != models.ReservationStatusBooked || req.Status == models.ReservationStatusBooked {
+		return nil, conflictError("cannot change reservation status from %s to %s", reservation.Status, req.Status)
+	}
+
+	var table *models.Table
+	if req.Status == models.ReservationStatusSeated {
+		if err := s.syncTableStatus(ctx, reservation.TableID); err != nil {
+			return nil, err
+		}
+		table, err = s.repo.GetTableByID(ctx, reservation.TableID)
+		if err != nil {
+			return nil, lookupError("table", err)
+		}
+		if table.GroupID != nil {
+			return nil, conflictError("table is part of a group; seat the party through the group")
+		}
+		if table.Status != models.TableStatusAvailable && table.Status != models.TableStatusReserved {
+			return nil, conflictError("table is %s and cannot seat the party", table.Status)
+		}
+	}
+
+	reservation.Status = req.Status
+
+	err = s.repo.UpdateReservation(ctx, id, reservation)
+	if err != nil {
+		return nil, writeError("update", "reservation", id, err)
+	}
+
+	if table != nil {
+		if err := s.recordTableTransition(ctx, table, models.TableStatusOccupied, "reservation seated", ""); err != nil {
+			return nil, err
+		}
+		return reservation, nil
+	}
+
+	if err := s.syncTableStatus(ctx, reservation.TableID); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *venueService) CancelReservation(ctx context.Context, id string) (*models.Reservation, error) {
+	return s.UpdateReservationStatus(ctx, id, &models.UpdateReservationStatusRequest{
+		Status: models.ReservationStatusCancelled,
+	})
+}
+
+// validateReservation checks the time slot, the target table and that the
+// table is not already booked for an overlapping slot. Callers run it in a
+// transaction: the table stays locked until the booking is written, so
+// concurrent bookings of one table check for overlaps one at a time.
+func (s *venueService) validateReservation(ctx context.Context, reservation *models.Reservation) error {
+	if !reservation.EndsAt.After(reservation.StartsAt) {
+		return validationError("ends_at must be after starts_at")
+	}
+	if !reservation.EndsAt.After(time.Now()) {
+		return validationError("reservation cannot end in the past")
+	}
+	if err := s.checkLocationOpen(ctx, reservation.LocationID, reservation.StartsAt); err != nil {
+		return err
+	}
+
+	if err := s.repo.LockTable(ctx, reservation.TableID); err != nil {
+		if repository.IsNotFound(err) {
+			return invalidReference("table does not exist")
+		}
+		return fmt.Errorf("failed to lock table: %w", err)
+	}
+	table, err := s.repo.GetTableByID(ctx, reservation.TableID)
+	if err != nil {
+		return referenceError("table", err)
+	}
+	if !table.IsActive {
+		return invalidReference("table is not active")
+	}
+	if table.LocationID != reservation.LocationID {
+		return invalidReference("table does not belong to location")
+	}
+	if reservation.PartySize > table.Seats {
+		return validationError("party size %d exceeds table seats %d", reservation.PartySize, table.Seats)
+	}
+
+	overlaps, err := s.repo.HasOverlappingReservation(ctx, reservation.TableID, reservation.StartsAt, reservation.EndsAt, reservation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check reservation overlap: %w", err)
+	}
+	if overlaps {
+		return conflictError("table already has a reservation in the requested time slot")
+	}
+
+	return nil
+}
+
+// syncTableStatus stores the status derived from the table's current
+// reservations so that consumers reading the tables row directly agree.
+// Derived changes bypass the staff lifecycle but are still recorded. Grouped
+// tables are left alone so members never drift apart.
+func (s *venueService) syncTableStatus(ctx context.Context, tableID string) error {
+	table, err := s.repo.GetTableByID(ctx, tableID)
+	if err != nil {
+		return lookupError("table", err)
+	}
+	if table.GroupID != nil {
+		// Grouped tables change status together through their group
+		return nil
+	}
+
+	now := time.Now()
+	reservations, err := s.repo.GetActiveReservationsByTable(ctx, tableID, now, now.Add(reservationHoldWindow))
+	if err != nil {
+		return fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	status := deriveTableStatus(table.Status, reservations, now)
+	if status == table.Status {
+		return nil
+	}
+
+	return s.recordTableTransition(ctx, table, status, "derived from reservations", "")
+}
+
+// applyDerivedStatus overlays the reservation-derived status on tables of a
+// location without persisting it.
+func (s *venueService) applyDerivedStatus(ctx context.Context, locationID string, tables []models.Table) error {
+	now := time.Now()
+	reservations, err := s.repo.GetActiveReservationsByLocation(ctx, locationID, now, now.Add(reservationHoldWindow))
+	if err != nil {
+		return fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	byTable := make(map[string][]models.Reservation)
+	for _, reservation := range reservations {
+		byTable[reservation.TableID] = append(byTable[reservation.TableID], reservation)
+	}
+	for i := range tables {
+		if tables[i].GroupID == nil {
+			tables[i].Status = deriveTableStatus(tables[i].Status, byTable[tables[i].ID], now)
+		}
+	}
+
+	return nil
+}
+
+// deriveTableStatus computes a table's status from the bookings that are
+// active around now. A booking starting within the hold window reserves an
+// available table, and a reserved status with no such booking is released.
+// Seated parties are not derived here: seating moves the table to occupied
+// once and the staff lifecycle takes over from there.
+func deriveTableStatus(current string, reservations []models.Reservation, now time.Time) string {
+	reserved := false
+	for _, reservation := range reservations {
+		if reservation.Status == models.ReservationStatusBooked &&
+			reservation.EndsAt.After(now) &&
+			!reservation.StartsAt.After(now.Add(reservationHoldWindow)) {
+			reserved = true
+			break
+		}
+	}
+
+	switch {
+	case reserved && (current == models.TableStatusAvailable || current == models.TableStatusReserved):
+		return models.TableStatusReserved
+	case current == models.TableStatusReserved:
+		return models.TableStatusAvailable
+	default:
+		return current
+	}
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+func TestCreateReservation(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "RES")
+	other := createTestLocation(t, svc, "OTHER")
+	table := createTestTable(t, svc, location.ID, "T1", 4)
+	foreign := createTestTable(t, svc, other.ID, "T1", 4)
+	deleted := createTestTable(t, svc, location.ID, "T2", 4)
+	if err := svc.DeleteTable(ctx, deleted.ID, 0); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	booking := func(tableID string, size int, starts time.Time, length time.Duration) models.CreateReservationRequest {
+		return models.CreateReservationRequest{
+			LocationID: location.ID,
+			TableID:    tableID,
+			PartyName:  "Gómez",
+			Phone:      "+573001234567",
+			PartySize:  size,
+			StartsAt:   starts,
+			EndsAt:     starts.Add(length),
+		}
+	}
+
+	tests := []struct {
+		name string
+		req  models.CreateReservationRequest
+		code string
+	}{
+		{name: "free slot", req: booking(table.ID, 4, tomorrow, 2*time.Hour)},
+		{name: "overlapping slot", req: booking(table.ID, 2, tomorrow.Add(time.Hour), 2*time.Hour), code: models.ErrorCodeConflict},
+		{name: "adjacent slot", req: booking(table.ID, 2, tomorrow.Add(2*time.Hour), time.Hour)},
+		{name: "ends before it starts", req: booking(table.ID, 2, tomorrow, -time.Hour), code: models.ErrorCodeValidation},
+		{name: "ends in the past", req: booking(table.ID, 2, time.Now().Add(-2*time.Hour), time.Hour), code: models.ErrorCodeValidation},
+		{name: "party larger than table", req: booking(table.ID, 6, tomorrow.Add(6*time.Hour), time.Hour), code: models.ErrorCodeValidation},
+		{name: "table of another location", req: booking(foreign.ID, 2, tomorrow, time.Hour), code: models.ErrorCodeInvalidReference},
+		{name: "inactive table", req: booking(deleted.ID, 2, tomorrow, time.Hour), code: models.ErrorCodeInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation, err := svc.CreateReservation(ctx, &tt.req)
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && reservation.Status != models.ReservationStatusBooked {
+				t.Errorf("status = %s, want booked", reservation.Status)
+			}
+		})
+	}
+
+	stored, err := svc.GetTableByID(ctx, table.ID)
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	if stored.Status != models.TableStatusAvailable {
+		t.Errorf("a booking outside the hold window should leave the table available, got %s", stored.Status)
+	}
+}
+
+func TestConcurrentReservationsDoNotDoubleBook(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "RES")
+	table := createTestTable(t, svc, location.ID, "T1", 4)
+	starts := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	const attempts = 32
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.CreateReservation(ctx, &models.CreateReservationRequest{
+				LocationID: location.ID,
+				TableID:    table.ID,
+				PartyName:  "Gómez",
+				Phone:      "+573001234567",
+				PartySize:  2,
+				StartsAt:   starts.Add(time.Duration(i) * time.Minute),
+				EndsAt:     starts.Add(2 * time.Hour),
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	booked := 0
+	for _, err := range errs {
+		if err == nil {
+			booked++
+			continue
+		}
+		assertErrorCode(t, err, models.ErrorCodeConflict)
+	}
+	if booked != 1 {
+		t.Errorf("%d overlapping bookings succeeded, want 1", booked)
+	}
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		status      string
+		code        string
+		tableStatus string
+	}{
+		{name: "seat", status: models.ReservationStatusSeated, tableStatus: models.TableStatusOccupied},
+		{name: "no show", status: models.ReservationStatusNoShow, tableStatus: models.TableStatusAvailable},
+		{name: "cancel", status: models.ReservationStatusCancelled, tableStatus: models.TableStatusAvailable},
+		{name: "back to booked", status: models.ReservationStatusBooked, code: models.ErrorCodeConflict, tableStatus: models.TableStatusReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "RES")
+			table := createTestTable(t, svc, location.ID, "T1", 4)
+
+			// Starting within the hold window reserves the table right away
+			starts := time.Now().Add(10 * time.Minute)
+			reservation, err := svc.CreateReservation(ctx, &models.CreateReservationRequest{
+				LocationID: location.ID,
+				TableID:    table.ID,
+				PartyName:  "Rojas",
+				Phone:      "+573001234567",
+				PartySize:  2,
+				StartsAt:   starts,
+				EndsAt:     starts.Add(time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("create reservation: %v", err)
+			}
+			held, err := svc.GetTableByID(ctx, table.ID)
+			if err != nil {
+				t.Fatalf("get table: %v", err)
+			}
+			if held.Status != models.TableStatusReserved {
+				t.Fatalf("table should be held as reserved, got %s", held.Status)
+			}
+
+			_, err = svc.UpdateReservationStatus(ctx, reservation.ID, &models.UpdateReservationStatusRequest{Status: tt.status})
+			assertErrorCode(t, err, tt.code)
+
+			stored, err := svc.GetTableByID(ctx, table.ID)
+			if err != nil {
+				t.Fatalf("get table: %v", err)
+			}
+			if stored.Status != tt.tableStatus {
+				t.Errorf("table status = %s, want %s", stored.Status, tt.tableStatus)
+			}
+
+			if tt.code == "" {
+				_, err = svc.CancelReservation(ctx, reservation.ID)
+				assertErrorCode(t, err, models.ErrorCodeConflict)
+			}
+		})
+	}
+}
+
+func TestSeatedReservationCompletesWhenTableIsFreed(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		steps []string
+		want  string
+	}{
+		{name: "still occupied", want: models.ReservationStatusSeated},
+		{name: "needs cleaning", steps: []string{models.TableStatusNeedsCleaning}, want: models.ReservationStatusCompleted},
+		{name: "available again", steps: []string{models.TableStatusNeedsCleaning, models.TableStatusAvailable}, want: models.ReservationStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "RES")
+			table := createTestTable(t, svc, location.ID, "T1", 4)
+
+			starts := time.Now().Add(10 * time.Minute)
+			reservation, err := svc.CreateReservation(ctx, &models.CreateReservationRequest{
+				LocationID: location.ID,
+				TableID:    table.ID,
+				PartyName:  "Rojas",
+				Phone:      "+573001234567",
+				PartySize:  2,
+				StartsAt:   starts,
+				EndsAt:     starts.Add(2 * time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("create reservation: %v", err)
+			}
+			_, err = svc.UpdateReservationStatus(ctx, reservation.ID, &models.UpdateReservationStatusRequest{Status: models.ReservationStatusSeated})
+			if err != nil {
+				t.Fatalf("seat reservation: %v", err)
+			}
+
+			for _, status := range tt.steps {
+				if _, err := svc.TransitionTable(ctx, table.ID, &models.TableTransitionRequest{Status: status}, ""); err != nil {
+					t.Fatalf("move table to %s: %v", status, err)
+				}
+			}
+
+			stored, err := svc.GetReservationByID(ctx, reservation.ID)
+			if err != nil {
+				t.Fatalf("get reservation: %v", err)
+			}
+			if stored.Status != tt.want {
+				t.Errorf("reservation status = %s, want %s", stored.Status, tt.want)
+			}
+
+			// A completed reservation no longer holds the rest of its slot
+			_, err = svc.CreateReservation(ctx, &models.CreateReservationRequest{
+				LocationID: location.ID,
+				TableID:    table.ID,
+				PartyName:  "Gómez",
+				Phone:      "+573001234567",
+				PartySize:  2,
+				StartsAt:   starts.Add(time.Hour),
+				EndsAt:     starts.Add(3 * time.Hour),
+			})
+			if tt.want == models.ReservationStatusCompleted {
+				assertErrorCode(t, err, "")
+			} else {
+				assertErrorCode(t, err, models.ErrorCodeConflict)
+			}
+		})
+	}
+}
+
+func TestTableGroupFreeCompletesSeatedReservations(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	location := createTestLocation(t, svc, "RES")
+	t1 := createTestTable(t, svc, location.ID, "T1", 4)
+	t2 := createTestTable(t, svc, location.ID, "T2", 4)
+
+	starts := time.Now().Add(10 * time.Minute)
+	reservation, err := svc.CreateReservation(ctx, &models.CreateReservationRequest{
+		LocationID: location.ID,
+		TableID:    t1.ID,
+		PartyName:  "Rojas",
+		Phone:      "+573001234567",
+		PartySize:  4,
+		StartsAt:   starts,
+		EndsAt:     starts.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	if _, err := svc.UpdateReservationStatus(ctx, reservation.ID, &models.UpdateReservationStatusRequest{Status: models.ReservationStatusSeated}); err != nil {
+		t.Fatalf("seat reservation: %v", err)
+	}
+	setTableStatus(t, svc, t2.ID, models.TableStatusOccupied)
+
+	group, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{LocationID: location.ID, TableIDs: []string{t1.ID, t2.ID}})
+	if err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	_, err = svc.TransitionTableGroup(ctx, group.ID, &models.TableTransitionRequest{Status: models.TableStatusNeedsCleaning}, "")
+	if err != nil {
+		t.Fatalf("free group: %v", err)
+	}
+
+	stored, err := svc.GetReservationByID(ctx, reservation.ID)
+	if err != nil {
+		t.Fatalf("get reservation: %v", err)
+	}
+	if stored.Status != models.ReservationStatusCompleted {
+		t.Errorf("reservation status = %s, want completed", stored.Status)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+)
+
+// Restore and purge operations
+
+// RestoreLocation brings back a deleted location along with the tables that
+// were deleted with it.
+func (s *venueService) RestoreLocation(ctx context.Context, id string) (*models.Location, error) {
+	location, err := s.repo.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("location", err)
+	}
+	if location.IsActive {
+		return nil, conflictError("location is not deleted")
+	}
+
+	if err := s.repo.RestoreLocation(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore location: %w", err)
+	}
+
+	return s.GetLocationByID(ctx, id)
+}
+
+// RestoreTable brings back a deleted table. Its location must be active.
+func (s *venueService) RestoreTable(ctx context.Context, id string) (*models.Table, error) {
+	table, err := s.repo.GetTableByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("table", err)
+	}
+	if table.IsActive {
+		return nil, conflictError("table is not deleted")
+	}
+
+	location, err := s.repo.GetLocationByID(ctx, table.LocationID)
+	if err != nil {
+		return nil, lookupError("location", err)
+	}
+	if !location.IsActive {
+		return nil, conflictError("location is deleted; restore the location first")
+	}
+
+	if err := s.repo.RestoreTable(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore table: %w", err)
+	}
+
+	return s.GetTableByID(ctx, id)
+}
+
+// PurgeLocation permanently removes a deleted location and everything in
+// it. It refuses while orders or staff members still reference it.
+func (s *venueService) PurgeLocation(ctx context.Context, id string) error {
+	location, err := s.repo.GetLocationByID(ctx, id)
+	if err != nil {
+		return lookupError("location", err)
+	}
+	if location.IsActive {
+		return conflictError("only deleted locations can be purged")
+	}
+
+	orders, staff, err := s.repo.CountLocationReferences(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check location references: %w", err)
+	}
+	if orders > 0 {
+		return stillReferenced("location has %d orders", orders)
+	}
+	if staff > 0 {
+		return stillReferenced("location has %d staff members assigned", staff)
+	}
+
+	if err := s.repo.PurgeLocation(ctx, id); err != nil {
+		return fmt.Errorf("failed to purge location: %w", err)
+	}
+	return nil
+}
+
+// PurgeTable permanently removes a deleted table. It refuses while orders
+// reference the table.
+func (s *venueService) PurgeTable(ctx context.Context, id string) error {
+	table, err := s.repo.GetTableByID(ctx, id)
+	if err != nil {
+		return lookupError("table", err)
+	}
+	if table.IsActive {
+		return conflictError("only deleted tables can be purged")
+	}
+
+	orders, err := s.repo.CountTableOrders(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check table orders: %w", err)
+	}
+	if orders > 0 {
+		return stillReferenced("table has %d orders", orders)
+	}
+
+	if err := s.repo.PurgeTable(ctx, id); err != nil {
+		return fmt.Errorf("failed to purge table: %w", err)
+	}
+	return nil
+}
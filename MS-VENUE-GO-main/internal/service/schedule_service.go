@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+
+	// nextOpeningHorizon bounds how far ahead is-open looks for the next opening.
+	nextOpeningHorizon = 14
+)
+
+// openInterval is a concrete span of time during which a location is open.
+type openInterval struct {
+	start, end time.Time
+}
+
+// Schedule operations
+
+// Opening hours are interpreted in the location's configured time zone.
+func (s *venueService) GetLocationSchedule(ctx context.Context, locationID string) (*models.LocationSchedule, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+	zone, err := s.locationTimeZone(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.GetLocationSchedules(ctx, []string{locationID}, time.Now().In(zone).Format(dateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return schedules[locationID], nil
+}
+
+func (s *venueService) SetOpeningHours(ctx context.Context, locationID string, req *models.SetOpeningHoursRequest) (*models.LocationSchedule, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+
+	hours := make([]models.OpeningHours, 0, len(req.Hours))
+	for _, input := range req.Hours {
+		if err := validateClockRange(input.OpensAt, input.ClosesAt); err != nil {
+			return nil, err
+		}
+		hours = append(hours, models.OpeningHours{
+			ID:         generateUUID(),
+			LocationID: locationID,
+			DayOfWeek:  *input.DayOfWeek,
+			OpensAt:    input.OpensAt,
+			ClosesAt:   input.ClosesAt,
+		})
+	}
+	if err := validateWeeklyHours(hours); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.ReplaceOpeningHours(ctx, locationID, hours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save opening hours: %w", err)
+	}
+
+	return s.GetLocationSchedule(ctx, locationID)
+}
+
+func (s *venueService) CreateScheduleException(ctx context.Context, locationID string, req *models.CreateScheduleExceptionRequest) (*models.ScheduleException, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+
+	exception := &models.ScheduleException{
+		ID:         generateUUID(),
+		LocationID: locationID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		IsClosed:   req.IsClosed,
+		OpensAt:    req.OpensAt,
+		ClosesAt:   req.ClosesAt,
+		Reason:     req.Reason,
+	}
+	if exception.EndDate == "" {
+		exception.EndDate = exception.StartDate
+	}
+
+	start, err := time.Parse(dateLayout, exception.StartDate)
+	if err != nil {
+		return nil, validationError("start_date must use the YYYY-MM-DD format")
+	}
+	end, err := time.Parse(dateLayout, exception.EndDate)
+	if err != nil {
+		return nil, validationError("end_date must use the YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, validationError("end_date cannot be before start_date")
+	}
+
+	if exception.IsClosed {
+		if exception.OpensAt != nil || exception.ClosesAt != nil {
+			return nil, validationError("a closure cannot define opening hours")
+		}
+	} else {
+		if exception.OpensAt == nil || exception.ClosesAt == nil {
+			return nil, validationError("opens_at and closes_at are required unless the location is closed")
+		}
+		if err := validateClockRange(*exception.OpensAt, *exception.ClosesAt); err != nil {
+			return nil, err
+		}
+	}
+
+	overlaps, err := s.repo.HasOverlappingScheduleException(ctx, locationID, exception.StartDate, exception.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schedule exceptions: %w", err)
+	}
+	if overlaps {
+		return nil, conflictError("another exception already covers part of these dates")
+	}
+
+	err = s.repo.CreateScheduleException(ctx, exception)
+	if err != nil {
+		return nil, writeError("create", "schedule exception", exception.ID, err)
+	}
+
+	return exception, nil
+}
+
+func (s *venueService) GetScheduleExceptionByID(ctx context.Context, id string) (*models.ScheduleException, error) {
+	exception, err := s.repo.GetScheduleExceptionByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("schedule exception", err)
+	}
+	return exception, nil
+}
+
+func (s *venueService) DeleteScheduleException(ctx context.Context, id string) error {
+	err := s.repo.DeleteScheduleException(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule exception: %w", err)
+	}
+	return nil
+}
+
+func (s *venueService) GetOpenStatus(ctx context.Context, locationID string, at time.Time) (*models.OpenStatus, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+	zone, err := s.locationTimeZone(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.GetLocationSchedules(ctx, []string{locationID}, at.In(zone).AddDate(0, 0, -1).Format(dateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return evaluateSchedule(schedules[locationID], at, zone), nil
+}
+
+// applyOpenNow fills OpenNow for every location with a configured schedule.
+func (s *venueService) applyOpenNow(ctx context.Context, locations []models.Location) error {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(locations))
+	for _, location := range locations {
+		ids = append(ids, location.ID)
+	}
+
+	zones, err := s.locationTimeZones(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	// Two days back covers overnight ranges in every time zone
+	now := time.Now()
+	schedules, err := s.repo.GetLocationSchedules(ctx, ids, now.AddDate(0, 0, -2).Format(dateLayout))
+	if err != nil {
+		return fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	for i := range locations {
+		schedule := schedules[locations[i].ID]
+		if !hasSchedule(schedule) {
+			continue
+		}
+		open := evaluateSchedule(schedule, now, zones[locations[i].ID]).IsOpen
+		locations[i].OpenNow = &open
+	}
+
+	return nil
+}
+
+// checkLocationOpen rejects times at which a location with a configured
+// schedule is closed. Locations without a schedule are always accepted.
+func (s *venueService) checkLocationOpen(ctx context.Context, locationID string, at time.Time) error {
+	zone, err := s.locationTimeZone(ctx, locationID)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := s.repo.GetLocationSchedules(ctx, []string{locationID}, at.In(zone).AddDate(0, 0, -1).Format(dateLayout))
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	schedule := schedules[locationID]
+	if hasSchedule(schedule) && !evaluateSchedule(schedule, at, zone).IsOpen {
+		return validationError("location is closed at %s", at.In(zone).Format(time.RFC3339))
+	}
+	return nil
+}
+
+func hasSchedule(schedule *models.LocationSchedule) bool {
+	return schedule != nil && (len(schedule.Hours) > 0 || len(schedule.Exceptions) > 0)
+}
+
+// evaluateSchedule works out whether the schedule is open at the given
+// instant, reading its clock times in zone. Ranges that started the previous day are considered so that
+// overnight hours keep the location open past midnight.
+func evaluateSchedule(schedule *models.LocationSchedule, at time.Time, zone *time.Location) *models.OpenStatus {
+	status := &models.OpenStatus{LocationID: schedule.LocationID, At: at}
+
+	at = at.In(zone)
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, zone)
+
+	for _, interval := range append(dayIntervals(schedule, today.AddDate(0, 0, -1)), dayIntervals(schedule, today)...) {
+		if at.Before(interval.start) || !at.Before(interval.end) {
+			continue
+		}
+
+		// Follow back-to-back ranges so closes_at is the real closing time
+		closesAt := interval.end
+		for extended := true; extended; {
+			extended = false
+			for day := 0; day <= 1; day++ {
+				for _, next := range dayIntervals(schedule, today.AddDate(0, 0, day)) {
+					if !next.start.After(closesAt) && next.end.After(closesAt) {
+						closesAt = next.end
+						extended = true
+					}
+				}
+			}
+		}
+
+		status.IsOpen = true
+		status.ClosesAt = &closesAt
+		return status
+	}
+
+	for day := 0; day <= nextOpeningHorizon; day++ {
+		for _, interval := range dayIntervals(schedule, today.AddDate(0, 0, day)) {
+			if interval.start.After(at) && (status.NextOpensAt == nil || interval.start.Before(*status.NextOpensAt)) {
+				start := interval.start
+				status.NextOpensAt = &start
+			}
+		}
+		if status.NextOpensAt != nil {
+			break
+		}
+	}
+
+	return status
+}
+
+// dayIntervals returns the ranges that open on the given day: those of the
+// exception covering it, or else its weekly hours.
+func dayIntervals(schedule *models.LocationSchedule, day time.Time) []openInterval {
+	date := day.Format(dateLayout)
+	for _, exception := range schedule.Exceptions {
+		if date < exception.StartDate || date > exception.EndDate {
+			continue
+		}
+		if exception.IsClosed || exception.OpensAt == nil || exception.ClosesAt == nil {
+			return nil
+		}
+		return []openInterval{newOpenInterval(day, *exception.OpensAt, *exception.ClosesAt)}
+	}
+
+	intervals := make([]openInterval, 0)
+	for _, hours := range schedule.Hours {
+		if hours.DayOfWeek == int(day.Weekday()) {
+			intervals = append(intervals, newOpenInterval(day, hours.OpensAt, hours.ClosesAt))
+		}
+	}
+	return intervals
+}
+
+// newOpenInterval anchors a clock range to a day. A range that does not close
+// after it opens ends on the following day.
+func newOpenInterval(day time.Time, opensAt, closesAt string) openInterval {
+	opens := clockMinutes(opensAt)
+	closes := clockMinutes(closesAt)
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), opens/60, opens%60, 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), closes/60, closes%60, 0, 0, day.Location())
+	if closes <= opens {
+		end = end.AddDate(0, 0, 1)
+	}
+	return openInterval{start: start, end: end}
+}
+
+func clockMinutes(clock string) int {
+	t, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+func validateClockRange(opensAt, closesAt string) error {
+	if _, err := time.Parse(clockLayout, opensAt); err != nil {
+		return validationError("opens_at must use the HH:MM format")
+	}
+	if _, err := time.Parse(clockLayout, closesAt); err != nil {
+		return validationError("closes_at must use the HH:MM format")
+	}
+	return nil
+}
+
+// validateWeeklyHours rejects ranges that overlap anywhere in the week,
+// including overnight ranges spilling into the next day and Saturday night
+// running into Sunday.
+func validateWeeklyHours(hours []models.OpeningHours) error {
+	type weekRange struct {
+		start, length int
+	}
+
+	ranges := make([]weekRange, len(hours))
+	for i, h := range hours {
+		opens := clockMinutes(h.OpensAt)
+		length := clockMinutes(h.ClosesAt) - opens
+		if length <= 0 {
+			length += minutesPerDay
+		}
+		ranges[i] = weekRange{start: h.DayOfWeek*minutesPerDay + opens, length: length}
+	}
+
+	for i := range ranges {
+		for j := i + 1; j < len(ranges); j++ {
+			a, b := ranges[i], ranges[j]
+			if (b.start-a.start+minutesPerWeek)%minutesPerWeek < a.length ||
+				(a.start-b.start+minutesPerWeek)%minutesPerWeek < b.length {
+				return validationError("opening hours %s-%s on day %d overlap %s-%s on day %d",
+					hours[i].OpensAt, hours[i].ClosesAt, hours[i].DayOfWeek,
+					hours[j].OpensAt, hours[j].ClosesAt, hours[j].DayOfWeek)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+const (
+	defaultSeatingDuration = 120 * time.Minute
+	defaultSuggestionLimit = 5
+
+	// immediateSeatingWindow decides when a request is for a walk-in: the
+	// tables must then be available right now, not just free of bookings.
+	immediateSeatingWindow = 15 * time.Minute
+
+	// maxMergedTables caps how many tables a suggested combination may join.
+	maxMergedTables = 3
+
+	// Score penalties, expressed in wasted-seat equivalents.
+	mergePenalty = 3
+	zonePenalty  = 10
+)
+
+// seatingUnit is something a party can be seated at as-is: a single table or
+// an existing table group.
+type seatingUnit struct {
+	tableIDs []string
+	codes    []string
+	groupID  *string
+	zoneID   *string
+	seats    int
+}
+
+// Seating operations
+
+// SuggestSeating ranks the tables, table groups and table combinations of a
+// location that can take the party for the requested slot, preferring the
+// least wasted seats, the fewest merges and the preferred zone.
+func (s *venueService) SuggestSeating(ctx context.Context, locationID string, req *models.SeatingSuggestionRequest) ([]models.SeatingSuggestion, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+
+	now := time.Now()
+	at := now
+	if req.At != nil {
+		at = *req.At
+	}
+	duration := defaultSeatingDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	limit := defaultSuggestionLimit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+	immediate := at.Before(now.Add(immediateSeatingWindow))
+
+	tables, err := s.GetTablesByLocation(ctx, locationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.GetTableGroupsByLocation(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.repo.GetActiveReservationsByLocation(ctx, locationID, at, at.Add(duration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	booked := make(map[string]bool, len(reservations))
+	for _, reservation := range reservations {
+		booked[reservation.TableID] = true
+	}
+	usable := func(table models.Table) bool {
+		if booked[table.ID] {
+			return false
+		}
+		return !immediate || table.Status == models.TableStatusAvailable
+	}
+
+	units := make([]seatingUnit, 0, len(groups))
+	for _, group := range groups {
+		groupID := group.ID
+		unit := seatingUnit{groupID: &groupID, seats: group.Seats}
+		ok := len(group.Tables) > 0
+		for i, table := range group.Tables {
+			if !usable(table) {
+				ok = false
+				break
+			}
+			unit.tableIDs = append(unit.tableIDs, table.ID)
+			unit.codes = append(unit.codes, table.Code)
+			if i == 0 {
+				unit.zoneID = table.ZoneID
+			} else if !sameZone(unit.zoneID, table.ZoneID) {
+				unit.zoneID = nil
+			}
+		}
+		if ok {
+			units = append(units, unit)
+		}
+	}
+
+	singles := make([]seatingUnit, 0, len(tables))
+	for _, table := range tables {
+		if table.GroupID != nil || !usable(table) {
+			continue
+		}
+		singles = append(singles, seatingUnit{
+			tableIDs: []string{table.ID},
+			codes:    []string{table.Code},
+			zoneID:   table.ZoneID,
+			seats:    table.Seats,
+		})
+	}
+	units = append(units, singles...)
+
+	suggestions := make([]models.SeatingSuggestion, 0)
+	for _, unit := range units {
+		if unit.seats >= req.PartySize {
+			suggestions = append(suggestions, newSeatingSuggestion([]seatingUnit{unit}, req))
+		}
+	}
+	for _, combo := range tableCombinations(singles, req.PartySize) {
+		suggestions = append(suggestions, newSeatingSuggestion(combo, req))
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		if len(a.TableIDs) != len(b.TableIDs) {
+			return len(a.TableIDs) < len(b.TableIDs)
+		}
+		return strings.Join(a.TableCodes, "+") < strings.Join(b.TableCodes, "+")
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// tableCombinations returns sets of 2 to maxMergedTables single tables from
+// the same zone that together seat the party, skipping sets where a member
+// could be dropped and the rest would still fit.
+func tableCombinations(singles []seatingUnit, partySize int) [][]seatingUnit {
+	combos := make([][]seatingUnit, 0)
+
+	var walk func(start int, picked []seatingUnit, seats int)
+	walk = func(start int, picked []seatingUnit, seats int) {
+		if len(picked) >= 2 && seats >= partySize {
+			smallest := picked[0].seats
+			for _, unit := range picked[1:] {
+				if unit.seats < smallest {
+					smallest = unit.seats
+				}
+			}
+			if seats-smallest < partySize {
+				combo := make([]seatingUnit, len(picked))
+				copy(combo, picked)
+				combos = append(combos, combo)
+			}
+			return
+		}
+		if len(picked) == maxMergedTables {
+			return
+		}
+		for i := start; i < len(singles); i++ {
+			if len(picked) > 0 && !sameZone(picked[0].zoneID, singles[i].zoneID) {
+				continue
+			}
+			walk(i+1, append(picked, singles[i]), seats+singles[i].seats)
+		}
+	}
+	walk(0, nil, 0)
+
+	return combos
+}
+
+func newSeatingSuggestion(units []seatingUnit, req *models.SeatingSuggestionRequest) models.SeatingSuggestion {
+	suggestion := models.SeatingSuggestion{
+		TableIDs:   make([]string, 0),
+		TableCodes: make([]string, 0),
+		ZoneID:     units[0].zoneID,
+		NeedsMerge: len(units) > 1,
+	}
+	if len(units) == 1 {
+		suggestion.GroupID = units[0].groupID
+	}
+	for _, unit := range units {
+		suggestion.TableIDs = append(suggestion.TableIDs, unit.tableIDs...)
+		suggestion.TableCodes = append(suggestion.TableCodes, unit.codes...)
+		suggestion.Seats += unit.seats
+	}
+
+	suggestion.WastedSeats = suggestion.Seats - req.PartySize
+	suggestion.Score = suggestion.WastedSeats + (len(units)-1)*mergePenalty
+	if req.ZoneID != nil && !sameZone(req.ZoneID, suggestion.ZoneID) {
+		suggestion.Score += zonePenalty
+	}
+	return suggestion
+}
+
+func sameZone(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+// Defaults applied to locations that have not saved their own settings.
+const (
+	defaultTimezone      = "America/Bogota"
+	defaultCurrencyCode  = "COP"
+	defaultTaxRate       = 19.0
+	defaultServiceCharge = 0.0
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Settings operations
+func (s *venueService) GetLocationSettings(ctx context.Context, locationID string) (*models.LocationSettings, error) {
+	if _, err := s.repo.GetLocationByID(ctx, locationID); err != nil {
+		return nil, lookupError("location", err)
+	}
+
+	stored, err := s.repo.GetLocationSettings(ctx, []string{locationID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location settings: %w", err)
+	}
+
+	if settings, ok := stored[locationID]; ok {
+		return settings, nil
+	}
+	return defaultLocationSettings(locationID), nil
+}
+
+func (s *venueService) UpdateLocationSettings(ctx context.Context, locationID string, req *models.UpdateLocationSettingsRequest) (*models.LocationSettings, error) {
+	settings, err := s.GetLocationSettings(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.Timezone != nil {
+		settings.Timezone = *req.Timezone
+	}
+	if req.CurrencyCode != nil {
+		settings.CurrencyCode = *req.CurrencyCode
+	}
+	if req.TaxRate != nil {
+		settings.TaxRate = *req.TaxRate
+	}
+	if req.TaxProfiles != nil {
+		settings.TaxProfiles = *req.TaxProfiles
+	}
+	if req.ServiceChargePercent != nil {
+		settings.ServiceChargePercent = *req.ServiceChargePercent
+	}
+	if req.ReceiptHeader != nil {
+		settings.ReceiptHeader = *req.ReceiptHeader
+	}
+
+	if err := validateLocationSettings(settings); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.SaveLocationSettings(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save location settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// locationTimeZones resolves the time zone of each location, falling back to
+// the default zone for locations without settings.
+func (s *venueService) locationTimeZones(ctx context.Context, locationIDs []string) (map[string]*time.Location, error) {
+	stored, err := s.repo.GetLocationSettings(ctx, locationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location settings: %w", err)
+	}
+
+	zones := make(map[string]*time.Location, len(locationIDs))
+	for _, id := range locationIDs {
+		name := defaultTimezone
+		if settings, ok := stored[id]; ok {
+			name = settings.Timezone
+		}
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load time zone %s: %w", name, err)
+		}
+		zones[id] = zone
+	}
+
+	return zones, nil
+}
+
+func (s *venueService) locationTimeZone(ctx context.Context, locationID string) (*time.Location, error) {
+	zones, err := s.locationTimeZones(ctx, []string{locationID})
+	if err != nil {
+		return nil, err
+	}
+	return zones[locationID], nil
+}
+
+func defaultLocationSettings(locationID string) *models.LocationSettings {
+	return &models.LocationSettings{
+		LocationID:           locationID,
+		Timezone:             defaultTimezone,
+		CurrencyCode:         defaultCurrencyCode,
+		TaxRate:              defaultTaxRate,
+		TaxProfiles:          make([]models.TaxProfile, 0),
+		ServiceChargePercent: defaultServiceCharge,
+	}
+}
+
+func validateLocationSettings(settings *models.LocationSettings) error {
+	if _, err := time.LoadLocation(settings.Timezone); err != nil || settings.Timezone == "" || settings.Timezone == "Local" {
+		return validationError("timezone must be a valid IANA time zone such as %s", defaultTimezone)
+	}
+	if !currencyCodePattern.MatchString(settings.CurrencyCode) {
+		return validationError("currency_code must be a three-letter ISO 4217 code")
+	}
+
+	seen := make(map[string]bool, len(settings.TaxProfiles))
+	for _, profile := range settings.TaxProfiles {
+		if seen[profile.Code] {
+			return validationError("tax profile %s appears more than once", profile.Code)
+		}
+		seen[profile.Code] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"ms-venue-go/internal/models"
+)
+
+// tableEventBuffer is how many events a subscriber may fall behind before it
+// is dropped; dropped subscribers see their channel closed and reconnect.
+const tableEventBuffer = 32
+
+// tableEventHub fans table events out to the stream subscribers of each
+// location on this replica.
+type tableEventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan models.TableEvent]struct{}
+}
+
+func newTableEventHub() *tableEventHub {
+	return &tableEventHub{
+		subscribers: make(map[string]map[chan models.TableEvent]struct{}),
+	}
+}
+
+func (h *tableEventHub) subscribe(locationID string) chan models.TableEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events := make(chan models.TableEvent, tableEventBuffer)
+	if h.subscribers[locationID] == nil {
+		h.subscribers[locationID] = make(map[chan models.TableEvent]struct{})
+	}
+	h.subscribers[locationID][events] = struct{}{}
+	return events
+}
+
+func (h *tableEventHub) unsubscribe(locationID string, events chan models.TableEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(locationID, events)
+}
+
+func (h *tableEventHub) hasSubscribers(locationID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscribers[locationID]) > 0
+}
+
+// publish delivers the event to the location's subscribers. An empty
+// LocationID reaches every subscriber.
+func (h *tableEventHub) publish(event models.TableEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for locationID, subscribers := range h.subscribers {
+		if event.LocationID != "" && event.LocationID != locationID {
+			continue
+		}
+		for events := range subscribers {
+			select {
+			case events <- event:
+			default:
+				h.remove(locationID, events)
+			}
+		}
+	}
+}
+
+// remove must be called with mu held.
+func (h *tableEventHub) remove(locationID string, events chan models.TableEvent) {
+	subscribers := h.subscribers[locationID]
+	if _, ok := subscribers[events]; !ok {
+		return
+	}
+	delete(subscribers, events)
+	close(events)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, locationID)
+	}
+}
+
+// Table event operations
+
+// SubscribeTableEvents streams the table events of a location. The channel
+// is closed when the subscriber falls too far behind; call unsubscribe when
+// done listening.
+func (s *venueService) SubscribeTableEvents(locationID string) (<-chan models.TableEvent, func()) {
+	events := s.tableEvents.subscribe(locationID)
+	return events, func() { s.tableEvents.unsubscribe(locationID, events) }
+}
+
+// ListenTableEvents relays database table notifications to the subscribers
+// on this replica until ctx is done.
+func (s *venueService) ListenTableEvents(ctx context.Context) error {
+	return s.repo.ListenTableEvents(ctx, func(change models.TableChange) {
+		s.publishTableChange(ctx, change)
+	})
+}
+
+func (s *venueService) publishTableChange(ctx context.Context, change models.TableChange) {
+	event := models.TableEvent{
+		Type:           change.Type,
+		LocationID:     change.LocationID,
+		PreviousStatus: change.PreviousStatus,
+		At:             time.Now(),
+	}
+
+	if change.Type != models.TableEventResync {
+		// Only load the table when someone on this replica is listening
+		if !s.tableEvents.hasSubscribers(change.LocationID) {
+			return
+		}
+		table, err := s.repo.GetTableByID(ctx, change.TableID)
+		if err != nil {
+			log.Printf("Table event %s for table %s: %v", change.Type, change.TableID, err)
+			return
+		}
+		event.Table = table
+	}
+
+	s.tableEvents.publish(event)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+)
+
+// Table group operations
+
+// CreateTableGroup combines tables that share a status into a group. The
+// members are locked while they are checked and attached, so a concurrent
+// transition cannot combine tables whose statuses differ.
+func (s *venueService) CreateTableGroup(ctx context.Context, req *models.CreateTableGroupRequest) (*models.TableGroup, error) {
+	var group *models.TableGroup
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		group, err = tx.createTableGroup(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTableGroupByID(ctx, group.ID)
+}
+
+func (s *venueService) createTableGroup(ctx context.Context, req *models.CreateTableGroupRequest) (*models.TableGroup, error) {
+	seen := make(map[string]bool, len(req.TableIDs))
+	for _, tableID := range req.TableIDs {
+		if seen[tableID] {
+			return nil, validationError("table %s appears more than once", tableID)
+		}
+		seen[tableID] = true
+	}
+	if err := s.lockTables(ctx, req.TableIDs); err != nil {
+		return nil, referenceError("table", err)
+	}
+
+	codes := make([]string, 0, len(req.TableIDs))
+	status := ""
+	for _, tableID := range req.TableIDs {
+		table, err := s.repo.GetTableByID(ctx, tableID)
+		if err != nil {
+			return nil, referenceError("table", err)
+		}
+		if table.LocationID != req.LocationID {
+			return nil, invalidReference("table %s does not belong to location", table.Code)
+		}
+		if !table.IsActive {
+			return nil, invalidReference("table %s is not active", table.Code)
+		}
+		if table.GroupID != nil {
+			return nil, conflictError("table %s is already part of a group", table.Code)
+		}
+		if status != "" && table.Status != status {
+			return nil, conflictError("tables must share the same status to be combined")
+		}
+		status = table.Status
+		codes = append(codes, table.Code)
+	}
+
+	group := &models.TableGroup{
+		ID:         generateUUID(),
+		LocationID: req.LocationID,
+		Code:       req.Code,
+	}
+	if group.Code == "" {
+		group.Code = strings.Join(codes, "+")
+	}
+
+	err := s.repo.CreateTableGroup(ctx, group, req.TableIDs, status)
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		// Another request grouped, moved or deleted one of the tables meanwhile
+		return nil, conflictError("some tables are no longer active, ungrouped and %s; reload them and retry", status)
+	case err != nil:
+		return nil, writeError("create", "table group", group.Code, err)
+	}
+
+	return group, nil
+}
+
+func (s *venueService) GetTableGroupsByLocation(ctx context.Context, locationID string) ([]models.TableGroup, error) {
+	groups, err := s.repo.GetTableGroupsByLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table groups: %w", err)
+	}
+
+	for i := range groups {
+		summarizeTableGroup(&groups[i])
+	}
+	return groups, nil
+}
+
+func (s *venueService) GetTableGroupByID(ctx context.Context, id string) (*models.TableGroup, error) {
+	group, err := s.repo.GetTableGroupByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("table group", err)
+	}
+
+	summarizeTableGroup(group)
+	return group, nil
+}
+
+// TransitionTableGroup moves every member table through the same lifecycle
+// step, recording one history entry per table. The members are locked and
+// moved in one transaction, so the group never ends up half-transitioned.
+func (s *venueService) TransitionTableGroup(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) (*models.TableGroup, error) {
+	err := s.inTx(ctx, func(tx *venueService) error {
+		return tx.transitionTableGroup(ctx, id, req, changedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTableGroupByID(ctx, id)
+}
+
+func (s *venueService) transitionTableGroup(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) error {
+	if req.Status == models.TableStatusReserved {
+		return validationError("reserved status is managed through reservations")
+	}
+
+	group, err := s.GetTableGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	memberIDs := make([]string, 0, len(group.Tables))
+	for _, table := range group.Tables {
+		memberIDs = append(memberIDs, table.ID)
+	}
+	if err := s.lockTables(ctx, memberIDs); err != nil {
+		return fmt.Errorf("failed to lock table group: %w", err)
+	}
+
+	// Read the members again now that no one else can move them
+	group, err = s.GetTableGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if group.DissolvedAt != nil {
+		return conflictError("table group is dissolved")
+	}
+	if !canTransitionTable(group.Status, req.Status) {
+		return conflictError("cannot change table group status from %s to %s", group.Status, req.Status)
+	}
+
+	changes := make([]*models.TableStatusChange, 0, len(group.Tables))
+	for _, table := range group.Tables {
+		change := &models.TableStatusChange{
+			ID:         generateUUID(),
+			TableID:    table.ID,
+			FromStatus: table.Status,
+			ToStatus:   req.Status,
+			Reason:     req.Reason,
+		}
+		if changedBy != "" {
+			change.ChangedBy = &changedBy
+		}
+		changes = append(changes, change)
+	}
+
+	if err := s.repo.TransitionTableStatus(ctx, changes...); err != nil {
+		return tableStatusError(err)
+	}
+	return s.completeSeatedReservations(ctx, req.Status, memberIDs...)
+}
+
+// SplitTableGroup dissolves the group; member tables keep the group's status.
+func (s *venueService) SplitTableGroup(ctx context.Context, id string) error {
+	err := s.repo.DissolveTableGroup(ctx, id)
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		return conflictError("table group is already dissolved")
+	case repository.IsNotFound(err):
+		return notFound("table group")
+	case err != nil:
+		return fmt.Errorf("failed to split table group: %w", err)
+	}
+	return nil
+}
+
+// summarizeTableGroup fills the virtual table fields from its members. Members
+// only change status together, so the first one speaks for the group.
+func summarizeTableGroup(group *models.TableGroup) {
+	group.Seats = 0
+	group.Status = ""
+	for _, table := range group.Tables {
+		group.Seats += table.Seats
+	}
+	if len(group.Tables) > 0 {
+		group.Status = group.Tables[0].Status
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateTableGroup(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "GRP")
+	other := createTestLocation(t, svc, "OTHER")
+	t1 := createTestTable(t, svc, location.ID, "T1", 4)
+	t2 := createTestTable(t, svc, location.ID, "T2", 2)
+	t3 := createTestTable(t, svc, location.ID, "T3", 2)
+	busy := createTestTable(t, svc, location.ID, "T4", 2)
+	deleted := createTestTable(t, svc, location.ID, "T5", 2)
+	foreign := createTestTable(t, svc, other.ID, "T1", 2)
+	setTableStatus(t, svc, busy.ID, models.TableStatusOccupied)
+	if err := svc.DeleteTable(ctx, deleted.ID, 0); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		tableIDs []string
+		code     string
+	}{
+		{name: "same table twice", tableIDs: []string{t1.ID, t1.ID}, code: models.ErrorCodeValidation},
+		{name: "table of another location", tableIDs: []string{t1.ID, foreign.ID}, code: models.ErrorCodeInvalidReference},
+		{name: "unknown table", tableIDs: []string{t1.ID, uuid.NewString()}, code: models.ErrorCodeInvalidReference},
+		{name: "inactive table", tableIDs: []string{t1.ID, deleted.ID}, code: models.ErrorCodeInvalidReference},
+		{name: "different statuses", tableIDs: []string{t1.ID, busy.ID}, code: models.ErrorCodeConflict},
+		{name: "available tables", tableIDs: []string{t1.ID, t2.ID}},
+		{name: "already grouped", tableIDs: []string{t2.ID, t3.ID}, code: models.ErrorCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{LocationID: location.ID, TableIDs: tt.tableIDs})
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if group.Code != "T1+T2" || group.Seats != 6 || group.Status != models.TableStatusAvailable || len(group.Tables) != 2 {
+				t.Errorf("group = %+v", group)
+			}
+		})
+	}
+}
+
+func TestTransitionAndSplitTableGroup(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "GRP")
+	t1 := createTestTable(t, svc, location.ID, "T1", 4)
+	t2 := createTestTable(t, svc, location.ID, "T2", 4)
+	group, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{LocationID: location.ID, TableIDs: []string{t1.ID, t2.ID}})
+	if err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	_, err = svc.TransitionTableGroup(ctx, group.ID, &models.TableTransitionRequest{Status: models.TableStatusNeedsCleaning}, "")
+	assertErrorCode(t, err, models.ErrorCodeConflict)
+
+	group, err = svc.TransitionTableGroup(ctx, group.ID, &models.TableTransitionRequest{Status: models.TableStatusOccupied}, "")
+	if err != nil {
+		t.Fatalf("transition group: %v", err)
+	}
+	for _, table := range group.Tables {
+		if table.Status != models.TableStatusOccupied {
+			t.Errorf("member %s is %s, want occupied", table.Code, table.Status)
+		}
+	}
+
+	if err := svc.SplitTableGroup(ctx, group.ID); err != nil {
+		t.Fatalf("split group: %v", err)
+	}
+	assertErrorCode(t, svc.SplitTableGroup(ctx, group.ID), models.ErrorCodeConflict)
+	assertErrorCode(t, svc.SplitTableGroup(ctx, uuid.NewString()), models.ErrorCodeNotFound)
+
+	table, err := svc.GetTableByID(ctx, t1.ID)
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	if table.GroupID != nil || table.Status != models.TableStatusOccupied {
+		t.Errorf("split table should be ungrouped and keep the group status, got %+v", table)
+	}
+}
+
+func TestConcurrentTableGroupTransitions(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "GRP")
+	var tableIDs []string
+	for _, code := range []string{"T1", "T2", "T3"} {
+		tableIDs = append(tableIDs, createTestTable(t, svc, location.ID, code, 4).ID)
+	}
+	group, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{LocationID: location.ID, TableIDs: tableIDs})
+	if err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	const attempts = 16
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.TransitionTableGroup(ctx, group.ID, &models.TableTransitionRequest{Status: models.TableStatusOccupied}, "")
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	moved := 0
+	for _, err := range errs {
+		if err == nil {
+			moved++
+			continue
+		}
+		assertErrorCode(t, err, models.ErrorCodeConflict)
+	}
+	if moved != 1 {
+		t.Errorf("%d transitions succeeded, want 1", moved)
+	}
+	for _, tableID := range tableIDs {
+		if history, _ := svc.GetTableStatusHistory(ctx, tableID); len(history) != 1 {
+			t.Errorf("table %s has %d history entries, want 1", tableID, len(history))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"ms-venue-go/internal/models"
+)
+
+const (
+	qrNonceBytes     = 16
+	qrSignatureBytes = 16
+)
+
+// Table QR operations
+
+// GetTableQRCode returns the table's current sticker. Tables created before
+// QR codes existed have none until a manager rotates it.
+func (s *venueService) GetTableQRCode(ctx context.Context, tableID string) (*models.TableQRCode, error) {
+	table, err := s.repo.GetTableByID(ctx, tableID)
+	if err != nil {
+		return nil, lookupError("table", err)
+	}
+	if table.QRToken == nil || table.QRRotatedAt == nil {
+		return nil, conflictError("table has no QR code yet; rotate it to issue one")
+	}
+
+	return s.tableQRCode(table, *table.QRToken)
+}
+
+// RotateTableQRCode issues a new token for the table. Stickers printed with
+// the previous token stop resolving immediately.
+func (s *venueService) RotateTableQRCode(ctx context.Context, tableID string) (*models.TableQRCode, error) {
+	table, err := s.repo.GetTableByID(ctx, tableID)
+	if err != nil {
+		return nil, lookupError("table", err)
+	}
+	if !table.IsActive {
+		return nil, conflictError("table is not active")
+	}
+
+	token, err := s.newTableQRToken()
+	if err != nil {
+		return nil, err
+	}
+
+	rotatedAt, err := s.repo.SetTableQRToken(ctx, tableID, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate table QR token: %w", err)
+	}
+	table.QRRotatedAt = &rotatedAt
+
+	return s.tableQRCode(table, token)
+}
+
+// ResolveTableToken maps a scanned token to its table and location. Tokens
+// with a bad signature are rejected before touching the database.
+func (s *venueService) ResolveTableToken(ctx context.Context, token string) (*models.TableResolution, error) {
+	// Same answer for forged, rotated and unknown tokens
+	if !s.verifyTableQRToken(token) {
+		return nil, notFound("table")
+	}
+
+	table, err := s.repo.GetTableByQRToken(ctx, token)
+	if err != nil {
+		return nil, lookupError("table", err)
+	}
+	if !table.IsActive {
+		return nil, notFound("table")
+	}
+
+	location, err := s.repo.GetLocationByID(ctx, table.LocationID)
+	if err != nil {
+		return nil, lookupError("location", err)
+	}
+	if !location.IsActive {
+		return nil, notFound("table")
+	}
+
+	if table.GroupID == nil {
+		tables := []models.Table{*table}
+		if err := s.applyDerivedStatus(ctx, table.LocationID, tables); err != nil {
+			return nil, err
+		}
+		table.Status = tables[0].Status
+	}
+
+	locations := []models.Location{*location}
+	if err := s.applyOpenNow(ctx, locations); err != nil {
+		return nil, err
+	}
+	settings, err := s.GetLocationSettings(ctx, location.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TableResolution{
+		Token: token,
+		Table: models.ResolvedTable{
+			Code:   table.Code,
+			Seats:  table.Seats,
+			Status: table.Status,
+		},
+		Location: models.ResolvedLocation{
+			Code:         location.Code,
+			Name:         location.Name,
+			Address:      location.Address,
+			City:         location.City,
+			Phone:        location.Phone,
+			CurrencyCode: settings.CurrencyCode,
+			OpenNow:      locations[0].OpenNow,
+		},
+	}, nil
+}
+
+func (s *venueService) tableQRCode(table *models.Table, token string) (*models.TableQRCode, error) {
+	menuURL, err := url.Parse(s.config.MenuBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid menu base URL: %w", err)
+	}
+	query := menuURL.Query()
+	query.Set("table", token)
+	menuURL.RawQuery = query.Encode()
+
+	return &models.TableQRCode{
+		TableID:   table.ID,
+		TableCode: table.Code,
+		Token:     token,
+		URL:       menuURL.String(),
+		RotatedAt: *table.QRRotatedAt,
+	}, nil
+}
+
+// newTableQRToken returns "<nonce>.<signature>": a random nonce and its
+// truncated HMAC-SHA256, both base64url-encoded. The nonce carries no table
+// data, so the token reveals nothing about internal IDs.
+func (s *venueService) newTableQRToken() (string, error) {
+	nonce := make([]byte, qrNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate table token: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(nonce)
+	return encoded + "." + s.signTableQRNonce(encoded), nil
+}
+
+func (s *venueService) verifyTableQRToken(token string) bool {
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.signTableQRNonce(nonce)))
+}
+
+func (s *venueService) signTableQRNonce(nonce string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.QRTokenSecret))
+	mac.Write([]byte("table-qr:" + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:qrSignatureBytes])
+}
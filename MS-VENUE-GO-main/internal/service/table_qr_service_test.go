@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestTableQRCodeIsOnlyIssuedByRotation(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+	location := createTestLocation(t, svc, "QR")
+
+	// A table from before QR codes existed
+	legacy := &models.Table{ID: uuid.NewString(), LocationID: location.ID, Code: "OLD", Seats: 4, Status: models.TableStatusAvailable, IsActive: true}
+	if err := repo.CreateTable(ctx, legacy); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	_, err := svc.GetTableQRCode(ctx, legacy.ID)
+	assertErrorCode(t, err, models.ErrorCodeConflict)
+	if table, _ := svc.GetTableByID(ctx, legacy.ID); table.QRToken != nil {
+		t.Fatal("reading the QR code should not issue a token")
+	}
+
+	rotated, err := svc.RotateTableQRCode(ctx, legacy.ID)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	qr, err := svc.GetTableQRCode(ctx, legacy.ID)
+	if err != nil {
+		t.Fatalf("get QR code: %v", err)
+	}
+	if qr.Token != rotated.Token {
+		t.Errorf("token = %s, want the rotated %s", qr.Token, rotated.Token)
+	}
+}
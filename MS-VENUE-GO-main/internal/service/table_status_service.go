@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+)
+
+// tableTransitions lists the status changes staff may request for a table.
+// Moving into or out of reserved is otherwise driven by reservations.
+var tableTransitions = map[string][]string{
+	models.TableStatusAvailable:     {models.TableStatusOccupied},
+	models.TableStatusOccupied:      {models.TableStatusNeedsCleaning},
+	models.TableStatusNeedsCleaning: {models.TableStatusAvailable},
+	models.TableStatusReserved:      {models.TableStatusOccupied},
+}
+
+// Table status operations
+
+// TransitionTable moves the table through the staff lifecycle. Freeing the
+// table completes the reservation seated at it, in the same transaction.
+func (s *venueService) TransitionTable(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) (*models.Table, error) {
+	var table *models.Table
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		table, err = tx.transitionTable(ctx, id, req, changedBy)
+		return err
+	})
+	return table, err
+}
+
+func (s *venueService) transitionTable(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) (*models.Table, error) {
+	// Bring the stored status up to date with reservations before validating
+	if err := s.syncTableStatus(ctx, id); err != nil {
+		return nil, err
+	}
+
+	table, err := s.repo.GetTableByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("table", err)
+	}
+
+	if !table.IsActive {
+		return nil, conflictError("table is not active")
+	}
+	if table.GroupID != nil {
+		return nil, conflictError("table is part of a group; change the group status instead")
+	}
+	if req.Status == models.TableStatusReserved {
+		return nil, validationError("reserved status is managed through reservations")
+	}
+	if !canTransitionTable(table.Status, req.Status) {
+		return nil, conflictError("cannot change table status from %s to %s", table.Status, req.Status)
+	}
+
+	if err := s.recordTableTransition(ctx, table, req.Status, req.Reason, changedBy); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (s *venueService) GetTableStatusHistory(ctx context.Context, id string) ([]models.TableStatusChange, error) {
+	history, err := s.repo.GetTableStatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table status history: %w", err)
+	}
+	return history, nil
+}
+
+func canTransitionTable(from, to string) bool {
+	for _, allowed := range tableTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// recordTableTransition persists the status change together with its history
+// entry and updates table in place. An empty changedBy records a system change.
+func (s *venueService) recordTableTransition(ctx context.Context, table *models.Table, status, reason, changedBy string) error {
+	change := &models.TableStatusChange{
+		ID:         generateUUID(),
+		TableID:    table.ID,
+		FromStatus: table.Status,
+		ToStatus:   status,
+		Reason:     reason,
+	}
+	if changedBy != "" {
+		change.ChangedBy = &changedBy
+	}
+
+	if err := s.repo.TransitionTableStatus(ctx, change); err != nil {
+		return tableStatusError(err)
+	}
+	if err := s.completeSeatedReservations(ctx, status, table.ID); err != nil {
+		return err
+	}
+
+	table.Status = status
+	table.UpdatedAt = change.ChangedAt
+	return nil
+}
+
+// completeSeatedReservations closes the reservations seated at the tables
+// once they move to status: a table that needs cleaning or is available again
+// no longer holds the party.
+func (s *venueService) completeSeatedReservations(ctx context.Context, status string, tableIDs ...string) error {
+	if status != models.TableStatusNeedsCleaning && status != models.TableStatusAvailable {
+		return nil
+	}
+	if err := s.repo.CompleteSeatedReservations(ctx, tableIDs); err != nil {
+		return fmt.Errorf("failed to complete seated reservations: %w", err)
+	}
+	return nil
+}
+
+// lockTables locks the tables in ID order, so that requests locking
+// overlapping sets of tables cannot deadlock each other.
+func (s *venueService) lockTables(ctx context.Context, ids []string) error {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	for _, id := range sorted {
+		if err := s.repo.LockTable(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableStatusError classifies a failed status write: ErrConflict means
+// another request moved one of the tables since it was read.
+func tableStatusError(err error) error {
+	if errors.Is(err, repository.ErrConflict) {
+		return conflictError("table status changed meanwhile; reload it and retry")
+	}
+	return fmt.Errorf("failed to change table status: %w", err)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// setTableStatus walks a new table through the staff lifecycle until it
+// reaches status.
+func setTableStatus(t *testing.T, svc VenueService, tableID, status string) {
+	t.Helper()
+	path := map[string][]string{
+		models.TableStatusAvailable:     nil,
+		models.TableStatusOccupied:      {models.TableStatusOccupied},
+		models.TableStatusNeedsCleaning: {models.TableStatusOccupied, models.TableStatusNeedsCleaning},
+	}
+	steps, ok := path[status]
+	if !ok {
+		t.Fatalf("no lifecycle path to %s", status)
+	}
+	for _, step := range steps {
+		if _, err := svc.TransitionTable(context.Background(), tableID, &models.TableTransitionRequest{Status: step}, ""); err != nil {
+			t.Fatalf("move table to %s: %v", step, err)
+		}
+	}
+}
+
+func TestTransitionTable(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		from string
+		to   string
+		code string
+	}{
+		{from: models.TableStatusAvailable, to: models.TableStatusOccupied},
+		{from: models.TableStatusAvailable, to: models.TableStatusNeedsCleaning, code: models.ErrorCodeConflict},
+		{from: models.TableStatusAvailable, to: models.TableStatusAvailable, code: models.ErrorCodeConflict},
+		{from: models.TableStatusAvailable, to: models.TableStatusReserved, code: models.ErrorCodeValidation},
+		{from: models.TableStatusOccupied, to: models.TableStatusNeedsCleaning},
+		{from: models.TableStatusOccupied, to: models.TableStatusAvailable, code: models.ErrorCodeConflict},
+		{from: models.TableStatusNeedsCleaning, to: models.TableStatusAvailable},
+		{from: models.TableStatusNeedsCleaning, to: models.TableStatusOccupied, code: models.ErrorCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			userID := uuid.NewString()
+			repo.AddUser(userID, nil)
+			location := createTestLocation(t, svc, "ST")
+			table := createTestTable(t, svc, location.ID, "T1", 4)
+			setTableStatus(t, svc, table.ID, tt.from)
+
+			updated, err := svc.TransitionTable(ctx, table.ID, &models.TableTransitionRequest{Status: tt.to, Reason: "test"}, userID)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if updated.Status != tt.to {
+				t.Errorf("status = %s, want %s", updated.Status, tt.to)
+			}
+
+			history, err := svc.GetTableStatusHistory(ctx, table.ID)
+			if err != nil {
+				t.Fatalf("get history: %v", err)
+			}
+			latest := history[0]
+			if latest.FromStatus != tt.from || latest.ToStatus != tt.to || latest.ChangedBy == nil || *latest.ChangedBy != userID {
+				t.Errorf("latest history entry = %+v", latest)
+			}
+		})
+	}
+}
+
+func TestTransitionTableRejectsUnavailableTables(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "ST")
+	deleted := createTestTable(t, svc, location.ID, "T1", 4)
+	grouped := createTestTable(t, svc, location.ID, "T2", 4)
+	partner := createTestTable(t, svc, location.ID, "T3", 4)
+	if err := svc.DeleteTable(ctx, deleted.ID, 0); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+	if _, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{
+		LocationID: location.ID,
+		TableIDs:   []string{grouped.ID, partner.ID},
+	}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		code string
+	}{
+		{name: "inactive table", id: deleted.ID, code: models.ErrorCodeConflict},
+		{name: "grouped table", id: grouped.ID, code: models.ErrorCodeConflict},
+		{name: "unknown table", id: uuid.NewString(), code: models.ErrorCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.TransitionTable(ctx, tt.id, &models.TableTransitionRequest{Status: models.TableStatusOccupied}, "")
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+// staleRepository serves some tables as they were before another request
+// moved them, the view a request has when it loses a race.
+type staleRepository struct {
+	*repository.MemoryVenueRepository
+	stale map[string]models.Table
+}
+
+func (r *staleRepository) GetTableByID(ctx context.Context, id string) (*models.Table, error) {
+	if table, ok := r.stale[id]; ok {
+		return &table, nil
+	}
+	return r.MemoryVenueRepository.GetTableByID(ctx, id)
+}
+
+func TestTransitionTableLostRace(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "RACE")
+	table := createTestTable(t, svc, location.ID, "T1", 4)
+	setTableStatus(t, svc, table.ID, models.TableStatusOccupied)
+
+	stale := NewVenueService(&staleRepository{MemoryVenueRepository: repo, stale: map[string]models.Table{table.ID: *table}},
+		Config{QRTokenSecret: "test-secret", MenuBaseURL: "http://menu.test"})
+	_, err := stale.TransitionTable(ctx, table.ID, &models.TableTransitionRequest{Status: models.TableStatusOccupied}, "")
+	assertErrorCode(t, err, models.ErrorCodeConflict)
+
+	if history, _ := svc.GetTableStatusHistory(ctx, table.ID); len(history) != 1 {
+		t.Errorf("history has %d entries, want 1", len(history))
+	}
+}
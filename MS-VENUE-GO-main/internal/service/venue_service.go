@@ -1,78 +1,231 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"ms-venue-go/internal/models"
 	"ms-venue-go/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type VenueService interface {
 	// Location operations
-	CreateLocation(req *models.CreateLocationRequest) (*models.Location, error)
-	GetAllLocations() ([]models.Location, error)
-	GetLocationByID(id string) (*models.Location, error)
-	UpdateLocation(id string, req *models.UpdateLocationRequest) (*models.Location, error)
-	DeleteLocation(id string) error
+	CreateLocation(ctx context.Context, req *models.CreateLocationRequest) (*models.Location, error)
+	GetAllLocations(ctx context.Context, filter *models.LocationFilter) ([]models.Location, error)
+	ListLocations(ctx context.Context, filter *models.LocationFilter, page models.PageRequest) (*models.LocationPage, error)
+	GetLocationByID(ctx context.Context, id string) (*models.Location, error)
+	UpdateLocation(ctx context.Context, id string, req *models.UpdateLocationRequest, version int) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id string, version int) error
+	RestoreLocation(ctx context.Context, id string) (*models.Location, error)
+	PurgeLocation(ctx context.Context, id string) error
 
 	// Table operations
-	CreateTable(req *models.CreateTableRequest) (*models.Table, error)
-	GetTablesByLocation(locationID string) ([]models.Table, error)
-	GetTableByID(id string) (*models.Table, error)
-	UpdateTable(id string, req *models.UpdateTableRequest) (*models.Table, error)
-	DeleteTable(id string) error
+	CreateTable(ctx context.Context, req *models.CreateTableRequest) (*models.Table, error)
+	GetTablesByLocation(ctx context.Context, locationID string, filter *models.TableFilter) ([]models.Table, error)
+	ListTables(ctx context.Context, locationID string, filter *models.TableFilter, page models.PageRequest) (*models.TablePage, error)
+	GetTableByID(ctx context.Context, id string) (*models.Table, error)
+	UpdateTable(ctx context.Context, id string, req *models.UpdateTableRequest, version int) (*models.Table, error)
+	DeleteTable(ctx context.Context, id string, version int) error
+	RestoreTable(ctx context.Context, id string) (*models.Table, error)
+	PurgeTable(ctx context.Context, id string) error
+
+	// Geosearch operations
+	FindNearbyLocations(ctx context.Context, query *models.NearbyLocationsQuery) ([]models.NearbyLocation, error)
+
+	// Table event operations
+	SubscribeTableEvents(locationID string) (<-chan models.TableEvent, func())
+	ListenTableEvents(ctx context.Context) error
+
+	// Table QR operations
+	GetTableQRCode(ctx context.Context, tableID string) (*models.TableQRCode, error)
+	RotateTableQRCode(ctx context.Context, tableID string) (*models.TableQRCode, error)
+	ResolveTableToken(ctx context.Context, token string) (*models.TableResolution, error)
+
+	// Bulk operations
+	ImportLocations(ctx context.Context, rows []models.LocationImportRow, dryRun bool) (*models.ImportResult, error)
+	ImportTables(ctx context.Context, rows []models.TableImportRow, dryRun bool) (*models.ImportResult, error)
+	ExportLocations(ctx context.Context) ([]models.LocationImportRow, error)
+	ExportTables(ctx context.Context, locationCode string) ([]models.TableImportRow, error)
+
+	// Clone operations
+	CloneLocation(ctx context.Context, sourceID string, req *models.CloneLocationRequest) (*models.CloneLocationResult, error)
+
+	// Settings operations
+	GetLocationSettings(ctx context.Context, locationID string) (*models.LocationSettings, error)
+	UpdateLocationSettings(ctx context.Context, locationID string, req *models.UpdateLocationSettingsRequest) (*models.LocationSettings, error)
+
+	// Schedule operations
+	GetLocationSchedule(ctx context.Context, locationID string) (*models.LocationSchedule, error)
+	SetOpeningHours(ctx context.Context, locationID string, req *models.SetOpeningHoursRequest) (*models.LocationSchedule, error)
+	CreateScheduleException(ctx context.Context, locationID string, req *models.CreateScheduleExceptionRequest) (*models.ScheduleException, error)
+	GetScheduleExceptionByID(ctx context.Context, id string) (*models.ScheduleException, error)
+	DeleteScheduleException(ctx context.Context, id string) error
+	GetOpenStatus(ctx context.Context, locationID string, at time.Time) (*models.OpenStatus, error)
+
+	// Zone operations
+	CreateZone(ctx context.Context, req *models.CreateZoneRequest) (*models.Zone, error)
+	GetZonesByLocation(ctx context.Context, locationID string) ([]models.Zone, error)
+	GetZoneByID(ctx context.Context, id string) (*models.Zone, error)
+	UpdateZone(ctx context.Context, id string, req *models.UpdateZoneRequest) (*models.Zone, error)
+	DeleteZone(ctx context.Context, id string) error
+	GetOccupancyByZone(ctx context.Context, locationID string) ([]models.ZoneOccupancy, error)
+
+	// Table group operations
+	CreateTableGroup(ctx context.Context, req *models.CreateTableGroupRequest) (*models.TableGroup, error)
+	GetTableGroupsByLocation(ctx context.Context, locationID string) ([]models.TableGroup, error)
+	GetTableGroupByID(ctx context.Context, id string) (*models.TableGroup, error)
+	TransitionTableGroup(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) (*models.TableGroup, error)
+	SplitTableGroup(ctx context.Context, id string) error
+
+	// Seating operations
+	SuggestSeating(ctx context.Context, locationID string, req *models.SeatingSuggestionRequest) ([]models.SeatingSuggestion, error)
+
+	// Waiter assignment operations
+	AssignWaiter(ctx context.Context, req *models.CreateWaiterAssignmentRequest, assignedBy string) (*models.WaiterAssignment, error)
+	GetWaiterAssignments(ctx context.Context, filter *models.WaiterAssignmentFilter) ([]models.WaiterAssignment, error)
+	GetWaiterAssignmentByID(ctx context.Context, id string) (*models.WaiterAssignment, error)
+	UnassignWaiter(ctx context.Context, id string) error
+	GetWaiterTables(ctx context.Context, waiterID string, at time.Time) (*models.WaiterTables, error)
+
+	// Waitlist operations
+	JoinWaitlist(ctx context.Context, req *models.CreateWaitlistEntryRequest) (*models.WaitlistEntry, error)
+	GetWaitlist(ctx context.Context, locationID string) ([]models.WaitlistEntry, error)
+	GetWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error)
+	ReorderWaitlist(ctx context.Context, locationID string, req *models.ReorderWaitlistRequest) ([]models.WaitlistEntry, error)
+	NotifyWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error)
+	SeatWaitlistEntry(ctx context.Context, id string, req *models.SeatWaitlistEntryRequest, changedBy string) (*models.WaitlistEntry, error)
+	CancelWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error)
+
+	// Floor plan operations
+	GetFloorPlan(ctx context.Context, locationID string) (*models.FloorPlan, error)
+	SaveFloorPlan(ctx context.Context, locationID string, req *models.SaveFloorPlanRequest) (*models.FloorPlan, error)
+
+	// Table status operations
+	TransitionTable(ctx context.Context, id string, req *models.TableTransitionRequest, changedBy string) (*models.Table, error)
+	GetTableStatusHistory(ctx context.Context, id string) ([]models.TableStatusChange, error)
+
+	// Reservation operations
+	CreateReservation(ctx context.Context, req *models.CreateReservationRequest) (*models.Reservation, error)
+	GetReservations(ctx context.Context, filter *models.ReservationFilter) ([]models.Reservation, error)
+	GetReservationByID(ctx context.Context, id string) (*models.Reservation, error)
+	UpdateReservation(ctx context.Context, id string, req *models.UpdateReservationRequest) (*models.Reservation, error)
+	UpdateReservationStatus(ctx context.Context, id string, req *models.UpdateReservationStatusRequest) (*models.Reservation, error)
+	CancelReservation(ctx context.Context, id string) (*models.Reservation, error)
+}
+
+// Config holds the settings the service needs beyond its repository.
+type Config struct {
+	// QRTokenSecret signs the tokens printed on table QR stickers.
+	QRTokenSecret string
+	// MenuBaseURL is the guest menu page that table QR codes point to.
+	MenuBaseURL string
 }
 
 type venueService struct {
-	repo repository.VenueRepository
+	repo        repository.VenueRepository
+	config      Config
+	tableEvents *tableEventHub
 }
 
-func NewVenueService(repo repository.VenueRepository) VenueService {
+func NewVenueService(repo repository.VenueRepository, config Config) VenueService {
 	return &venueService{
-		repo: repo,
+		repo:        repo,
+		config:      config,
+		tableEvents: newTableEventHub(),
 	}
 }
 
+// inTx runs fn against a copy of the service whose repository is bound to
+// one transaction, for operations that read and write several records and
+// must apply all or nothing.
+func (s *venueService) inTx(ctx context.Context, fn func(tx *venueService) error) error {
+	return s.repo.WithTx(ctx, func(repo repository.VenueRepository) error {
+		tx := *s
+		tx.repo = repo
+		return fn(&tx)
+	})
+}
+
 // Location operations
-func (s *venueService) CreateLocation(req *models.CreateLocationRequest) (*models.Location, error) {
+func (s *venueService) CreateLocation(ctx context.Context, req *models.CreateLocationRequest) (*models.Location, error) {
 	location := &models.Location{
-		ID:       generateUUID(),
-		Code:     req.Code,
-		Name:     req.Name,
-		Address:  req.Address,
-		IsActive: true,
+		ID:          generateUUID(),
+		Code:        req.Code,
+		Name:        req.Name,
+		Address:     req.Address,
+		City:        req.City,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		MaxCapacity: req.MaxCapacity,
+		ManagerID:   req.ManagerID,
+		IsActive:    true,
+	}
+
+	if err := validateLocationProfile(location); err != nil {
+		return nil, err
 	}
 
-	err := s.repo.CreateLocation(location)
+	err := s.repo.CreateLocation(ctx, location)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create location: %w", err)
+		return nil, writeError("create", "location", location.Code, err)
 	}
 
 	return location, nil
 }
 
-func (s *venueService) GetAllLocations() ([]models.Location, error) {
-	locations, err := s.repo.GetAllLocations()
+func (s *venueService) GetAllLocations(ctx context.Context, filter *models.LocationFilter) ([]models.Location, error) {
+	locations, err := s.repo.GetAllLocations(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get locations: %w", err)
 	}
+
+	if err := s.applyOpenNow(ctx, locations); err != nil {
+		return nil, err
+	}
+
 	return locations, nil
 }
 
-func (s *venueService) GetLocationByID(id string) (*models.Location, error) {
-	location, err := s.repo.GetLocationByID(id)
+func (s *venueService) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	location, err := s.repo.GetLocationByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get location: %w", err)
+		return nil, lookupError("location", err)
 	}
 	return location, nil
 }
 
-func (s *venueService) UpdateLocation(id string, req *models.UpdateLocationRequest) (*models.Location, error) {
+// UpdateLocation applies req if the location is still at version. A version
+// of 0 only requires the location to exist; the row stays locked from the
+// check to the write either way. Deleting and restoring go through
+// DeleteLocation and RestoreLocation, so is_active may only repeat the
+// current value.
+func (s *venueService) UpdateLocation(ctx context.Context, id string, req *models.UpdateLocationRequest, version int) (*models.Location, error) {
+	var location *models.Location
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		location, err = tx.updateLocation(ctx, id, req, version)
+		return err
+	})
+	return location, err
+}
+
+func (s *venueService) updateLocation(ctx context.Context, id string, req *models.UpdateLocationRequest, version int) (*models.Location, error) {
+	if err := s.repo.LockLocation(ctx, id); err != nil {
+		return nil, lookupError("location", err)
+	}
+
 	// Get existing location
-	location, err := s.repo.GetLocationByID(id)
+	location, err := s.repo.GetLocationByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get location: %w", err)
+		return nil, lookupError("location", err)
+	}
+	if err := checkVersion(location.Version, version); err != nil {
+		return nil, err
 	}
 
 	// Update fields if provided
@@ -85,20 +238,77 @@ func (s *venueService) UpdateLocation(id string, req *models.UpdateLocationReque
 	if req.Address != "" {
 		location.Address = req.Address
 	}
-	if req.IsActive != nil {
-		location.IsActive = *req.IsActive
+	if req.City != nil {
+		location.City = *req.City
+	}
+	if req.Phone != nil {
+		location.Phone = *req.Phone
+	}
+	if req.Email != nil {
+		location.Email = *req.Email
+	}
+	if req.Latitude != nil {
+		location.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		location.Longitude = req.Longitude
+	}
+	if req.MaxCapacity != nil {
+		location.MaxCapacity = req.MaxCapacity
+	}
+	if req.ManagerID != nil {
+		location.ManagerID = req.ManagerID
+		if *req.ManagerID == "" {
+			location.ManagerID = nil
+		}
+	}
+	if req.IsActive != nil && *req.IsActive != location.IsActive {
+		return nil, validationError("is_active cannot be changed here: use DELETE /locations/%s or POST /locations/%s/restore", id, id)
 	}
 
-	err = s.repo.UpdateLocation(id, location)
+	if err := validateLocationProfile(location); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateLocation(ctx, id, location)
+	if err == sql.ErrNoRows {
+		return nil, ErrVersionConflict
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update location: %w", err)
+		return nil, writeError("update", "location", location.Code, err)
 	}
 
 	return location, nil
 }
 
-func (s *venueService) DeleteLocation(id string) error {
-	err := s.repo.DeleteLocation(id)
+// DeleteLocation deletes the location if it is still at version. A version
+// of 0 only requires the location to exist.
+func (s *venueService) DeleteLocation(ctx context.Context, id string, version int) error {
+	return s.inTx(ctx, func(tx *venueService) error {
+		return tx.deleteLocation(ctx, id, version)
+	})
+}
+
+func (s *venueService) deleteLocation(ctx context.Context, id string, version int) error {
+	if err := s.repo.LockLocation(ctx, id); err != nil {
+		return lookupError("location", err)
+	}
+
+	location, err := s.repo.GetLocationByID(ctx, id)
+	if err != nil {
+		return lookupError("location", err)
+	}
+	if err := checkVersion(location.Version, version); err != nil {
+		return err
+	}
+	if !location.IsActive {
+		return conflictError("location is already deleted")
+	}
+
+	err = s.repo.DeleteLocation(ctx, id, location.Version)
+	if err == sql.ErrNoRows {
+		return ErrVersionConflict
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete location: %w", err)
 	}
@@ -106,45 +316,99 @@ func (s *venueService) DeleteLocation(id string) error {
 }
 
 // Table operations
-func (s *venueService) CreateTable(req *models.CreateTableRequest) (*models.Table, error) {
+func (s *venueService) CreateTable(ctx context.Context, req *models.CreateTableRequest) (*models.Table, error) {
 	table := &models.Table{
 		ID:         generateUUID(),
 		LocationID: req.LocationID,
 		Code:       req.Code,
 		Seats:      req.Seats,
-		Status:     "free",
+		Status:     models.TableStatusAvailable,
+		Shape:      models.TableShapeSquare,
+		Width:      defaultTableSize,
+		Height:     defaultTableSize,
 		IsActive:   true,
 	}
 
-	err := s.repo.CreateTable(table)
+	token, err := s.newTableQRToken()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create table: %w", err)
+		return nil, err
+	}
+	now := time.Now()
+	table.QRToken = &token
+	table.QRRotatedAt = &now
+
+	if req.ZoneID != nil && *req.ZoneID != "" {
+		if err := s.validateTableZone(ctx, table.LocationID, *req.ZoneID); err != nil {
+			return nil, err
+		}
+		table.ZoneID = req.ZoneID
+	}
+
+	err = s.repo.CreateTable(ctx, table)
+	if err != nil {
+		return nil, writeError("create", "table", table.Code, err)
 	}
 
 	return table, nil
 }
 
-func (s *venueService) GetTablesByLocation(locationID string) ([]models.Table, error) {
-	tables, err := s.repo.GetTablesByLocation(locationID)
+func (s *venueService) GetTablesByLocation(ctx context.Context, locationID string, filter *models.TableFilter) ([]models.Table, error) {
+	tables, err := s.repo.GetTablesByLocation(ctx, locationID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tables: %w", err)
 	}
+
+	if err := s.applyDerivedStatus(ctx, locationID, tables); err != nil {
+		return nil, err
+	}
+
 	return tables, nil
 }
 
-func (s *venueService) GetTableByID(id string) (*models.Table, error) {
-	table, err := s.repo.GetTableByID(id)
+func (s *venueService) GetTableByID(ctx context.Context, id string) (*models.Table, error) {
+	table, err := s.repo.GetTableByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get table: %w", err)
+		return nil, lookupError("table", err)
 	}
+
+	now := time.Now()
+	reservations, err := s.repo.GetActiveReservationsByTable(ctx, id, now, now.Add(reservationHoldWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	if table.GroupID == nil {
+		table.Status = deriveTableStatus(table.Status, reservations, now)
+	}
+
 	return table, nil
 }
 
-func (s *venueService) UpdateTable(id string, req *models.UpdateTableRequest) (*models.Table, error) {
+// UpdateTable applies req if the table is still at version. A version of 0
+// only requires the table to exist; the row stays locked from the check to
+// the write either way. Deleting and restoring go through DeleteTable and
+// RestoreTable, so is_active may only repeat the current value.
+func (s *venueService) UpdateTable(ctx context.Context, id string, req *models.UpdateTableRequest, version int) (*models.Table, error) {
+	var table *models.Table
+	err := s.inTx(ctx, func(tx *venueService) error {
+		var err error
+		table, err = tx.updateTable(ctx, id, req, version)
+		return err
+	})
+	return table, err
+}
+
+func (s *venueService) updateTable(ctx context.Context, id string, req *models.UpdateTableRequest, version int) (*models.Table, error) {
+	if err := s.repo.LockTable(ctx, id); err != nil {
+		return nil, lookupError("table", err)
+	}
+
 	// Get existing table
-	table, err := s.repo.GetTableByID(id)
+	table, err := s.repo.GetTableByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get table: %w", err)
+		return nil, lookupError("table", err)
+	}
+	if err := checkVersion(table.Version, version); err != nil {
+		return nil, err
 	}
 
 	// Update fields if provided
@@ -154,29 +418,74 @@ func (s *venueService) UpdateTable(id string, req *models.UpdateTableRequest) (*
 	if req.Seats > 0 {
 		table.Seats = req.Seats
 	}
-	if req.Status != "" {
-		table.Status = req.Status
+	if req.ZoneID != nil {
+		if *req.ZoneID == "" {
+			table.ZoneID = nil
+		} else {
+			if err := s.validateTableZone(ctx, table.LocationID, *req.ZoneID); err != nil {
+				return nil, err
+			}
+			table.ZoneID = req.ZoneID
+		}
 	}
-	if req.IsActive != nil {
-		table.IsActive = *req.IsActive
+	if req.IsActive != nil && *req.IsActive != table.IsActive {
+		return nil, validationError("is_active cannot be changed here: use DELETE /tables/%s or POST /tables/%s/restore", id, id)
 	}
 
-	err = s.repo.UpdateTable(id, table)
+	err = s.repo.UpdateTable(ctx, id, table)
+	if err == sql.ErrNoRows {
+		return nil, ErrVersionConflict
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update table: %w", err)
+		return nil, writeError("update", "table", table.Code, err)
 	}
 
 	return table, nil
 }
 
-func (s *venueService) DeleteTable(id string) error {
-	err := s.repo.DeleteTable(id)
+// DeleteTable deletes the table if it is still at version. A version of 0
+// only requires the table to exist.
+func (s *venueService) DeleteTable(ctx context.Context, id string, version int) error {
+	return s.inTx(ctx, func(tx *venueService) error {
+		return tx.deleteTable(ctx, id, version)
+	})
+}
+
+func (s *venueService) deleteTable(ctx context.Context, id string, version int) error {
+	if err := s.repo.LockTable(ctx, id); err != nil {
+		return lookupError("table", err)
+	}
+
+	table, err := s.repo.GetTableByID(ctx, id)
+	if err != nil {
+		return lookupError("table", err)
+	}
+	if err := checkVersion(table.Version, version); err != nil {
+		return err
+	}
+	if table.GroupID != nil {
+		return conflictError("table is part of a group; split the group first")
+	}
+
+	err = s.repo.DeleteTable(ctx, id, table.Version)
+	if err == sql.ErrNoRows {
+		return ErrVersionConflict
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete table: %w", err)
 	}
 	return nil
 }
 
+// checkVersion compares the stored version with the one the caller last
+// saw; expected 0 means the caller only needs the record to exist.
+func checkVersion(current, expected int) error {
+	if expected != 0 && current != expected {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
 // Generate UUID v4
 func generateUUID() string {
 	return uuid.New().String()
@@ -0,0 +1,591 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // location time zones must resolve without system zoneinfo
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+func newTestService(t *testing.T) (VenueService, *repository.MemoryVenueRepository) {
+	t.Helper()
+	repo := repository.NewMemoryVenueRepository()
+	return NewVenueService(repo, Config{QRTokenSecret: "test-secret", MenuBaseURL: "http://menu.test"}), repo
+}
+
+func createTestLocation(t *testing.T, svc VenueService, code string) *models.Location {
+	t.Helper()
+	location, err := svc.CreateLocation(context.Background(), &models.CreateLocationRequest{
+		Code:    code,
+		Name:    "Bar " + code,
+		Address: "Calle 1 #2-3",
+	})
+	if err != nil {
+		t.Fatalf("create location %s: %v", code, err)
+	}
+	return location
+}
+
+func createTestTable(t *testing.T, svc VenueService, locationID, code string, seats int) *models.Table {
+	t.Helper()
+	table, err := svc.CreateTable(context.Background(), &models.CreateTableRequest{
+		LocationID: locationID,
+		Code:       code,
+		Seats:      seats,
+	})
+	if err != nil {
+		t.Fatalf("create table %s: %v", code, err)
+	}
+	return table
+}
+
+func createTestZone(t *testing.T, svc VenueService, locationID, name string) *models.Zone {
+	t.Helper()
+	zone, err := svc.CreateZone(context.Background(), &models.CreateZoneRequest{
+		LocationID:  locationID,
+		Name:        name,
+		Environment: models.ZoneEnvironmentIndoor,
+	})
+	if err != nil {
+		t.Fatalf("create zone %s: %v", name, err)
+	}
+	return zone
+}
+
+// assertErrorCode fails unless err is a service error with code, or nil when
+// code is empty.
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	if code == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var serviceErr *Error
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+	if serviceErr.Code != code {
+		t.Fatalf("expected %s error, got %s: %s", code, serviceErr.Code, serviceErr.Message)
+	}
+}
+
+func stringPtr(s string) *string { return &s }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func intPtr(i int) *int { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestCreateLocation(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+	createTestLocation(t, svc, "TAKEN")
+
+	managerID := uuid.NewString()
+	repo.AddUser(managerID, nil)
+
+	tests := []struct {
+		name string
+		req  models.CreateLocationRequest
+		code string
+	}{
+		{
+			name: "minimal",
+			req:  models.CreateLocationRequest{Code: "MIN", Name: "Minimal", Address: "Calle 1"},
+		},
+		{
+			name: "full profile",
+			req: models.CreateLocationRequest{
+				Code: "FULL", Name: "Full", Address: "Calle 2", City: "Bogotá",
+				Phone: "+573001234567", Email: "bar@example.com",
+				Latitude: floatPtr(4.65), Longitude: floatPtr(-74.05), MaxCapacity: intPtr(120),
+				ManagerID: &managerID,
+			},
+		},
+		{
+			name: "duplicate code",
+			req:  models.CreateLocationRequest{Code: "TAKEN", Name: "Again", Address: "Calle 3"},
+			code: models.ErrorCodeDuplicateCode,
+		},
+		{
+			name: "phone not E.164",
+			req:  models.CreateLocationRequest{Code: "PHONE", Name: "Phone", Address: "Calle 4", Phone: "3001234567"},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "invalid email",
+			req:  models.CreateLocationRequest{Code: "MAIL", Name: "Mail", Address: "Calle 5", Email: "not-an-email"},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "latitude without longitude",
+			req:  models.CreateLocationRequest{Code: "GEO", Name: "Geo", Address: "Calle 6", Latitude: floatPtr(4.6)},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "zero capacity",
+			req:  models.CreateLocationRequest{Code: "CAP", Name: "Cap", Address: "Calle 7", MaxCapacity: intPtr(0)},
+			code: models.ErrorCodeValidation,
+		},
+		{
+			name: "unknown manager",
+			req:  models.CreateLocationRequest{Code: "MGR", Name: "Mgr", Address: "Calle 8", ManagerID: stringPtr(uuid.NewString())},
+			code: models.ErrorCodeInvalidReference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := svc.CreateLocation(ctx, &tt.req)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if !location.IsActive || location.Version != 1 {
+				t.Errorf("new location should be active at version 1, got active=%v version=%d", location.IsActive, location.Version)
+			}
+			stored, err := svc.GetLocationByID(ctx, location.ID)
+			if err != nil {
+				t.Fatalf("get location: %v", err)
+			}
+			if stored.Code != tt.req.Code || stored.City != tt.req.City {
+				t.Errorf("stored location = %+v", stored)
+			}
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		req     models.UpdateLocationRequest
+		version func(current int) int
+		code    string
+	}{
+		{
+			name:    "current version",
+			req:     models.UpdateLocationRequest{Name: "Renamed"},
+			version: func(current int) int { return current },
+		},
+		{
+			name:    "no version check",
+			req:     models.UpdateLocationRequest{Name: "Renamed"},
+			version: func(int) int { return 0 },
+		},
+		{
+			name:    "stale version",
+			req:     models.UpdateLocationRequest{Name: "Renamed"},
+			version: func(current int) int { return current - 1 },
+			code:    models.ErrorCodeVersionConflict,
+		},
+		{
+			name:    "code of another location",
+			req:     models.UpdateLocationRequest{Code: "OTHER"},
+			version: func(current int) int { return current },
+			code:    models.ErrorCodeDuplicateCode,
+		},
+		{
+			name:    "invalid phone",
+			req:     models.UpdateLocationRequest{Phone: stringPtr("12345")},
+			version: func(current int) int { return current },
+			code:    models.ErrorCodeValidation,
+		},
+		{
+			name:    "repeat is_active",
+			req:     models.UpdateLocationRequest{Name: "Renamed", IsActive: boolPtr(true)},
+			version: func(current int) int { return current },
+		},
+		{
+			name:    "deactivate instead of delete",
+			req:     models.UpdateLocationRequest{Name: "Renamed", IsActive: boolPtr(false)},
+			version: func(current int) int { return current },
+			code:    models.ErrorCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "MAIN")
+			createTestLocation(t, svc, "OTHER")
+
+			// Move past version 1 so a stale version is still positive
+			location, err := svc.UpdateLocation(ctx, location.ID, &models.UpdateLocationRequest{City: stringPtr("Bogotá")}, location.Version)
+			if err != nil {
+				t.Fatalf("prepare location: %v", err)
+			}
+
+			updated, err := svc.UpdateLocation(ctx, location.ID, &tt.req, tt.version(location.Version))
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if updated.Name != "Renamed" || updated.City != "Bogotá" {
+				t.Errorf("updated location = %+v", updated)
+			}
+			if updated.Version != location.Version+1 {
+				t.Errorf("version = %d, want %d", updated.Version, location.Version+1)
+			}
+		})
+	}
+
+	t.Run("unknown location", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		_, err := svc.UpdateLocation(ctx, uuid.NewString(), &models.UpdateLocationRequest{Name: "x"}, 0)
+		assertErrorCode(t, err, models.ErrorCodeNotFound)
+	})
+}
+
+func TestDeleteAndRestoreLocation(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "SOFT")
+	kept := createTestTable(t, svc, location.ID, "T1", 4)
+	deletedBefore := createTestTable(t, svc, location.ID, "T2", 2)
+
+	if err := svc.DeleteTable(ctx, deletedBefore.ID, deletedBefore.Version); err != nil {
+		t.Fatalf("delete table: %v", err)
+	}
+
+	// Deleting requires the current version
+	err := svc.DeleteLocation(ctx, location.ID, location.Version+1)
+	assertErrorCode(t, err, models.ErrorCodeVersionConflict)
+
+	if err := svc.DeleteLocation(ctx, location.ID, location.Version); err != nil {
+		t.Fatalf("delete location: %v", err)
+	}
+	assertErrorCode(t, svc.DeleteLocation(ctx, location.ID, 0), models.ErrorCodeConflict)
+
+	active, err := svc.GetAllLocations(ctx, nil)
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("deleted location is still listed: %+v", active)
+	}
+	all, err := svc.GetAllLocations(ctx, &models.LocationFilter{IncludeInactive: true})
+	if err != nil {
+		t.Fatalf("list locations: %v", err)
+	}
+	if len(all) != 1 || all[0].DeletedAt == nil {
+		t.Errorf("include_inactive should list the deleted location, got %+v", all)
+	}
+
+	tables, err := svc.GetTablesByLocation(ctx, location.ID, nil)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(tables) != 0 {
+		t.Errorf("tables of a deleted location are still listed: %+v", tables)
+	}
+
+	_, err = svc.RestoreTable(ctx, kept.ID)
+	assertErrorCode(t, err, models.ErrorCodeConflict)
+
+	restored, err := svc.RestoreLocation(ctx, location.ID)
+	if err != nil {
+		t.Fatalf("restore location: %v", err)
+	}
+	if !restored.IsActive || restored.DeletedAt != nil {
+		t.Errorf("restored location = %+v", restored)
+	}
+	_, err = svc.RestoreLocation(ctx, location.ID)
+	assertErrorCode(t, err, models.ErrorCodeConflict)
+
+	tables, err = svc.GetTablesByLocation(ctx, location.ID, nil)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(tables) != 1 || tables[0].ID != kept.ID {
+		t.Errorf("only the table deleted with the location should come back, got %+v", tables)
+	}
+}
+
+func TestPurgeLocation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		delete bool
+		seed   func(repo *repository.MemoryVenueRepository, location *models.Location, table *models.Table)
+		code   string
+	}{
+		{name: "active location", code: models.ErrorCodeConflict},
+		{name: "deleted location", delete: true},
+		{
+			name:   "with orders",
+			delete: true,
+			seed: func(repo *repository.MemoryVenueRepository, location *models.Location, table *models.Table) {
+				repo.AddOrder(repository.MemoryOrder{ID: uuid.NewString(), LocationID: location.ID, TableID: table.ID, Status: "closed"})
+			},
+			code: models.ErrorCodeStillReferenced,
+		},
+		{
+			name:   "with staff",
+			delete: true,
+			seed: func(repo *repository.MemoryVenueRepository, location *models.Location, table *models.Table) {
+				repo.AddUser(uuid.NewString(), &location.ID)
+			},
+			code: models.ErrorCodeStillReferenced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			location := createTestLocation(t, svc, "PURGE")
+			table := createTestTable(t, svc, location.ID, "T1", 4)
+			if tt.seed != nil {
+				tt.seed(repo, location, table)
+			}
+			if tt.delete {
+				if err := svc.DeleteLocation(ctx, location.ID, 0); err != nil {
+					t.Fatalf("delete location: %v", err)
+				}
+			}
+
+			assertErrorCode(t, svc.PurgeLocation(ctx, location.ID), tt.code)
+			if tt.code != "" {
+				return
+			}
+			_, err := svc.GetLocationByID(ctx, location.ID)
+			assertErrorCode(t, err, models.ErrorCodeNotFound)
+			_, err = svc.GetTableByID(ctx, table.ID)
+			assertErrorCode(t, err, models.ErrorCodeNotFound)
+		})
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "TABLES")
+	other := createTestLocation(t, svc, "ELSEWHERE")
+	zone := createTestZone(t, svc, location.ID, "Terrace")
+	foreignZone := createTestZone(t, svc, other.ID, "Terrace")
+	closedZone := createTestZone(t, svc, location.ID, "Closed")
+	if err := svc.DeleteZone(ctx, closedZone.ID); err != nil {
+		t.Fatalf("delete zone: %v", err)
+	}
+	createTestTable(t, svc, location.ID, "T1", 4)
+
+	tests := []struct {
+		name string
+		req  models.CreateTableRequest
+		code string
+	}{
+		{name: "new code", req: models.CreateTableRequest{LocationID: location.ID, Code: "T2", Seats: 2}},
+		{name: "in zone", req: models.CreateTableRequest{LocationID: location.ID, Code: "T3", Seats: 2, ZoneID: &zone.ID}},
+		{name: "code used in another location", req: models.CreateTableRequest{LocationID: other.ID, Code: "T1", Seats: 2}},
+		{name: "duplicate code", req: models.CreateTableRequest{LocationID: location.ID, Code: "T1", Seats: 2}, code: models.ErrorCodeDuplicateCode},
+		{name: "zone of another location", req: models.CreateTableRequest{LocationID: location.ID, Code: "T4", Seats: 2, ZoneID: &foreignZone.ID}, code: models.ErrorCodeInvalidReference},
+		{name: "inactive zone", req: models.CreateTableRequest{LocationID: location.ID, Code: "T5", Seats: 2, ZoneID: &closedZone.ID}, code: models.ErrorCodeInvalidReference},
+		{name: "unknown zone", req: models.CreateTableRequest{LocationID: location.ID, Code: "T6", Seats: 2, ZoneID: stringPtr(uuid.NewString())}, code: models.ErrorCodeInvalidReference},
+		{name: "unknown location", req: models.CreateTableRequest{LocationID: uuid.NewString(), Code: "T7", Seats: 2}, code: models.ErrorCodeInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := svc.CreateTable(ctx, &tt.req)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if table.Status != models.TableStatusAvailable || table.QRToken == nil || table.Version != 1 {
+				t.Errorf("new table = %+v", table)
+			}
+		})
+	}
+}
+
+func TestUpdateTable(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		req     func(zoneID string) models.UpdateTableRequest
+		stale   bool
+		code    string
+		checkFn func(t *testing.T, table *models.Table, zoneID string)
+	}{
+		{
+			name: "seats and zone",
+			req: func(zoneID string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Seats: 6, ZoneID: &zoneID}
+			},
+			checkFn: func(t *testing.T, table *models.Table, zoneID string) {
+				if table.Seats != 6 || table.ZoneID == nil || *table.ZoneID != zoneID {
+					t.Errorf("updated table = %+v", table)
+				}
+			},
+		},
+		{
+			name: "leave zone",
+			req: func(string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Seats: 4, ZoneID: stringPtr("")}
+			},
+			checkFn: func(t *testing.T, table *models.Table, _ string) {
+				if table.ZoneID != nil {
+					t.Errorf("table should have left its zone: %+v", table)
+				}
+			},
+		},
+		{
+			name: "stale version",
+			req: func(string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Seats: 6}
+			},
+			stale: true,
+			code:  models.ErrorCodeVersionConflict,
+		},
+		{
+			name: "code taken",
+			req: func(string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Code: "T2", Seats: 4}
+			},
+			code: models.ErrorCodeDuplicateCode,
+		},
+		{
+			name: "repeat is_active",
+			req: func(string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Seats: 6, IsActive: boolPtr(true)}
+			},
+			checkFn: func(t *testing.T, table *models.Table, _ string) {
+				if table.Seats != 6 || !table.IsActive {
+					t.Errorf("updated table = %+v", table)
+				}
+			},
+		},
+		{
+			name: "deactivate instead of delete",
+			req: func(string) models.UpdateTableRequest {
+				return models.UpdateTableRequest{Seats: 4, IsActive: boolPtr(false)}
+			},
+			code: models.ErrorCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			location := createTestLocation(t, svc, "UPD")
+			zone := createTestZone(t, svc, location.ID, "Bar")
+			table := createTestTable(t, svc, location.ID, "T1", 4)
+			createTestTable(t, svc, location.ID, "T2", 4)
+
+			version := table.Version
+			if tt.stale {
+				if _, err := svc.UpdateTable(ctx, table.ID, &models.UpdateTableRequest{Seats: 5}, 0); err != nil {
+					t.Fatalf("concurrent update: %v", err)
+				}
+			}
+
+			req := tt.req(zone.ID)
+			updated, err := svc.UpdateTable(ctx, table.ID, &req, version)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if updated.Version != version+1 {
+				t.Errorf("version = %d, want %d", updated.Version, version+1)
+			}
+			tt.checkFn(t, updated, zone.ID)
+		})
+	}
+}
+
+func TestDeleteTable(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "DEL")
+	table := createTestTable(t, svc, location.ID, "T1", 4)
+	grouped := createTestTable(t, svc, location.ID, "T2", 4)
+	partner := createTestTable(t, svc, location.ID, "T3", 4)
+	if _, err := svc.CreateTableGroup(ctx, &models.CreateTableGroupRequest{
+		LocationID: location.ID,
+		TableIDs:   []string{grouped.ID, partner.ID},
+	}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		version int
+		code    string
+	}{
+		{name: "stale version", id: table.ID, version: table.Version + 1, code: models.ErrorCodeVersionConflict},
+		{name: "grouped table", id: grouped.ID, code: models.ErrorCodeConflict},
+		{name: "unknown table", id: uuid.NewString(), code: models.ErrorCodeNotFound},
+		{name: "current version", id: table.ID, version: table.Version},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertErrorCode(t, svc.DeleteTable(ctx, tt.id, tt.version), tt.code)
+		})
+	}
+
+	deleted, err := svc.GetTableByID(ctx, table.ID)
+	if err != nil {
+		t.Fatalf("get deleted table: %v", err)
+	}
+	if deleted.IsActive || deleted.DeletedAt == nil {
+		t.Errorf("table should be soft deleted: %+v", deleted)
+	}
+
+	withInactive, err := svc.GetTablesByLocation(ctx, location.ID, &models.TableFilter{IncludeInactive: true})
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(withInactive) != 3 {
+		t.Errorf("include_inactive should list 3 tables, got %d", len(withInactive))
+	}
+}
+
+func TestPurgeTable(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "PT")
+	active := createTestTable(t, svc, location.ID, "T1", 4)
+	withOrders := createTestTable(t, svc, location.ID, "T2", 4)
+	unused := createTestTable(t, svc, location.ID, "T3", 4)
+	repo.AddOrder(repository.MemoryOrder{ID: uuid.NewString(), LocationID: location.ID, TableID: withOrders.ID, Status: "closed", CreatedAt: time.Now()})
+	for _, table := range []*models.Table{withOrders, unused} {
+		if err := svc.DeleteTable(ctx, table.ID, 0); err != nil {
+			t.Fatalf("delete table: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		code string
+	}{
+		{name: "active table", id: active.ID, code: models.ErrorCodeConflict},
+		{name: "table with orders", id: withOrders.ID, code: models.ErrorCodeStillReferenced},
+		{name: "unknown table", id: uuid.NewString(), code: models.ErrorCodeNotFound},
+		{name: "deleted table", id: unused.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertErrorCode(t, svc.PurgeTable(ctx, tt.id), tt.code)
+		})
+	}
+}
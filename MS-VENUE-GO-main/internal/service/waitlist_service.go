@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ms-venue-go/internal/models"
+	"ms-venue-go/internal/repository"
+)
+
+const (
+	// defaultTurnMinutes is assumed when a location has no closed orders yet.
+	defaultTurnMinutes = 60
+
+	// minRemainingTurnMinutes keeps quotes realistic for tables that have
+	// already outstayed the average turn.
+	minRemainingTurnMinutes = 5
+
+	// turnHistoryWindow is how far back closed orders count towards the average turn.
+	turnHistoryWindow = 30 * 24 * time.Hour
+)
+
+// Waitlist operations
+
+// JoinWaitlist queues a walk-in party at the end of the location's waitlist
+// and quotes a wait based on recent table turn times.
+func (s *venueService) JoinWaitlist(ctx context.Context, req *models.CreateWaitlistEntryRequest) (*models.WaitlistEntry, error) {
+	location, err := s.repo.GetLocationByID(ctx, req.LocationID)
+	if err != nil {
+		return nil, referenceError("location", err)
+	}
+	if !location.IsActive {
+		return nil, invalidReference("location is not active")
+	}
+
+	waiting, err := s.repo.GetWaitlistByLocation(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist: %w", err)
+	}
+
+	quote, err := s.quoteWait(ctx, req.LocationID, req.PartySize, waiting)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.WaitlistEntry{
+		ID:                generateUUID(),
+		LocationID:        req.LocationID,
+		PartyName:         req.PartyName,
+		Phone:             req.Phone,
+		PartySize:         req.PartySize,
+		QuotedWaitMinutes: quote,
+		Status:            models.WaitlistStatusWaiting,
+		Notes:             req.Notes,
+	}
+
+	err = s.repo.CreateWaitlistEntry(ctx, entry)
+	if err != nil {
+		return nil, writeError("create", "waitlist entry", entry.ID, err)
+	}
+
+	return entry, nil
+}
+
+func (s *venueService) GetWaitlist(ctx context.Context, locationID string) ([]models.WaitlistEntry, error) {
+	entries, err := s.repo.GetWaitlistByLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist: %w", err)
+	}
+	return entries, nil
+}
+
+// ReorderWaitlist requires the full list of waiting parties so that
+// positions stay contiguous.
+func (s *venueService) ReorderWaitlist(ctx context.Context, locationID string, req *models.ReorderWaitlistRequest) ([]models.WaitlistEntry, error) {
+	entries, err := s.repo.GetWaitlistByLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist: %w", err)
+	}
+
+	current := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		current[entry.ID] = true
+	}
+	seen := make(map[string]bool, len(req.EntryIDs))
+	for _, id := range req.EntryIDs {
+		if !current[id] {
+			return nil, invalidReference("entry %s is not waiting at this location", id)
+		}
+		if seen[id] {
+			return nil, validationError("entry %s appears more than once", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(current) {
+		return nil, validationError("the new order must list all %d waiting entries", len(current))
+	}
+
+	err = s.repo.ReorderWaitlist(ctx, locationID, req.EntryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder waitlist: %w", err)
+	}
+
+	return s.GetWaitlist(ctx, locationID)
+}
+
+// NotifyWaitlistEntry records that the party has been told a table is ready.
+func (s *venueService) NotifyWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("waitlist entry", err)
+	}
+	if entry.Status != models.WaitlistStatusWaiting && entry.Status != models.WaitlistStatusNotified {
+		return nil, conflictError("cannot notify a %s party", entry.Status)
+	}
+
+	now := time.Now()
+	entry.Status = models.WaitlistStatusNotified
+	entry.NotifiedAt = &now
+
+	err = s.repo.UpdateWaitlistEntry(ctx, id, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// SeatWaitlistEntry seats the party at the chosen table, which moves to
+// occupied in the same transaction.
+func (s *venueService) SeatWaitlistEntry(ctx context.Context, id string, req *models.SeatWaitlistEntryRequest, changedBy string) (*models.WaitlistEntry, error) {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("waitlist entry", err)
+	}
+	if entry.Status != models.WaitlistStatusWaiting && entry.Status != models.WaitlistStatusNotified {
+		return nil, conflictError("cannot seat a %s party", entry.Status)
+	}
+
+	// A table held for an upcoming reservation must not go to a walk-in
+	if err := s.syncTableStatus(ctx, req.TableID); err != nil {
+		return nil, err
+	}
+	table, err := s.repo.GetTableByID(ctx, req.TableID)
+	if err != nil {
+		return nil, referenceError("table", err)
+	}
+	if !table.IsActive {
+		return nil, invalidReference("table is not active")
+	}
+	if table.LocationID != entry.LocationID {
+		return nil, invalidReference("table does not belong to location")
+	}
+	if table.GroupID != nil {
+		return nil, conflictError("table is part of a group; seat the party through the group")
+	}
+	if table.Status != models.TableStatusAvailable {
+		return nil, conflictError("table is %s and cannot seat the party", table.Status)
+	}
+	if entry.PartySize > table.Seats {
+		return nil, validationError("party size %d exceeds table seats %d", entry.PartySize, table.Seats)
+	}
+
+	change := &models.TableStatusChange{
+		ID:         generateUUID(),
+		TableID:    table.ID,
+		FromStatus: table.Status,
+		ToStatus:   models.TableStatusOccupied,
+		Reason:     "waitlist party seated",
+	}
+	if changedBy != "" {
+		change.ChangedBy = &changedBy
+	}
+
+	err = s.repo.SeatWaitlistEntry(ctx, entry, change)
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		return nil, tableStatusError(err)
+	case err != nil:
+		return nil, fmt.Errorf("failed to seat waitlist entry: %w", err)
+	}
+
+	return s.GetWaitlistEntry(ctx, id)
+}
+
+func (s *venueService) CancelWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("waitlist entry", err)
+	}
+	if entry.Status != models.WaitlistStatusWaiting && entry.Status != models.WaitlistStatusNotified {
+		return nil, conflictError("cannot cancel a %s party", entry.Status)
+	}
+
+	entry.Status = models.WaitlistStatusCancelled
+
+	err = s.repo.UpdateWaitlistEntry(ctx, id, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *venueService) GetWaitlistEntry(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("waitlist entry", err)
+	}
+	return entry, nil
+}
+
+// quoteWait estimates, in minutes, how long a new party will wait. Each
+// occupied table large enough for the party frees up once its current guests
+// reach the average turn time; parties already queued for such tables take
+// those slots first, one round of turns at a time.
+func (s *venueService) quoteWait(ctx context.Context, locationID string, partySize int, waiting []models.WaitlistEntry) (int, error) {
+	tables, err := s.GetTablesByLocation(ctx, locationID, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	ahead := 0
+	for _, entry := range waiting {
+		if entry.PartySize >= partySize {
+			ahead++
+		}
+	}
+
+	suitable := make([]models.Table, 0)
+	for _, table := range tables {
+		// Reserved tables are held for their booking, not for walk-ins
+		if table.GroupID != nil || table.Seats < partySize || table.Status == models.TableStatusReserved {
+			continue
+		}
+		if table.Status == models.TableStatusAvailable && ahead == 0 {
+			return 0, nil
+		}
+		suitable = append(suitable, table)
+	}
+
+	now := time.Now()
+	avgTurn, err := s.repo.GetAverageTurnMinutes(ctx, locationID, partySize, now.Add(-turnHistoryWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get table turn times: %w", err)
+	}
+	if avgTurn <= 0 {
+		avgTurn = defaultTurnMinutes
+	}
+	if len(suitable) == 0 {
+		// No table can take the party on its own; quote a full turn
+		return int(avgTurn + 0.5), nil
+	}
+
+	startTimes, err := s.repo.GetOpenOrderStartTimes(ctx, locationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	remaining := make([]float64, 0, len(suitable))
+	for _, table := range suitable {
+		left := 0.0
+		switch table.Status {
+		case models.TableStatusOccupied:
+			left = avgTurn
+			if startedAt, ok := startTimes[table.ID]; ok {
+				left = avgTurn - now.Sub(startedAt).Minutes()
+			}
+			if left < minRemainingTurnMinutes {
+				left = minRemainingTurnMinutes
+			}
+		case models.TableStatusNeedsCleaning:
+			left = minRemainingTurnMinutes
+		}
+		remaining = append(remaining, left)
+	}
+	sort.Float64s(remaining)
+
+	slot := ahead % len(remaining)
+	rounds := ahead / len(remaining)
+	quote := remaining[slot] + float64(rounds)*avgTurn
+
+	return int(quote + 0.5), nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ms-venue-go/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func joinTestWaitlist(t *testing.T, svc VenueService, locationID, party string, size int) *models.WaitlistEntry {
+	t.Helper()
+	entry, err := svc.JoinWaitlist(context.Background(), &models.CreateWaitlistEntryRequest{
+		LocationID: locationID,
+		PartyName:  party,
+		Phone:      "+573001234567",
+		PartySize:  size,
+	})
+	if err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+	return entry
+}
+
+func TestJoinWaitlist(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "WL")
+	closed := createTestLocation(t, svc, "CLOSED")
+	if err := svc.DeleteLocation(ctx, closed.ID, 0); err != nil {
+		t.Fatalf("delete location: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		locationID string
+		code       string
+	}{
+		{name: "active location", locationID: location.ID},
+		{name: "deleted location", locationID: closed.ID, code: models.ErrorCodeInvalidReference},
+		{name: "unknown location", locationID: uuid.NewString(), code: models.ErrorCodeInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := svc.JoinWaitlist(ctx, &models.CreateWaitlistEntryRequest{
+				LocationID: tt.locationID,
+				PartyName:  "Vargas",
+				Phone:      "+573001234567",
+				PartySize:  2,
+			})
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && (entry.Status != models.WaitlistStatusWaiting || entry.QuotedWaitMinutes <= 0) {
+				t.Errorf("entry = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestReorderWaitlist(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "WL")
+	first := joinTestWaitlist(t, svc, location.ID, "First", 2)
+	second := joinTestWaitlist(t, svc, location.ID, "Second", 2)
+	third := joinTestWaitlist(t, svc, location.ID, "Third", 2)
+
+	tests := []struct {
+		name     string
+		entryIDs []string
+		code     string
+	}{
+		{name: "unknown entry", entryIDs: []string{first.ID, second.ID, uuid.NewString()}, code: models.ErrorCodeInvalidReference},
+		{name: "duplicate entry", entryIDs: []string{first.ID, first.ID, second.ID}, code: models.ErrorCodeValidation},
+		{name: "missing entry", entryIDs: []string{first.ID, second.ID}, code: models.ErrorCodeValidation},
+		{name: "full list", entryIDs: []string{third.ID, first.ID, second.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := svc.ReorderWaitlist(ctx, location.ID, &models.ReorderWaitlistRequest{EntryIDs: tt.entryIDs})
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			for i, entry := range entries {
+				if entry.ID != tt.entryIDs[i] {
+					t.Fatalf("position %d holds %s, want %s", i, entry.PartyName, tt.entryIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSeatWaitlistEntry(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	location := createTestLocation(t, svc, "WL")
+	small := createTestTable(t, svc, location.ID, "T1", 2)
+	busy := createTestTable(t, svc, location.ID, "T2", 6)
+	free := createTestTable(t, svc, location.ID, "T3", 6)
+	setTableStatus(t, svc, busy.ID, models.TableStatusOccupied)
+	entry := joinTestWaitlist(t, svc, location.ID, "Large party", 5)
+
+	tests := []struct {
+		name    string
+		tableID string
+		code    string
+	}{
+		{name: "table too small", tableID: small.ID, code: models.ErrorCodeValidation},
+		{name: "occupied table", tableID: busy.ID, code: models.ErrorCodeConflict},
+		{name: "unknown table", tableID: uuid.NewString(), code: models.ErrorCodeNotFound},
+		{name: "free table", tableID: free.ID},
+		{name: "already seated", tableID: free.ID, code: models.ErrorCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seated, err := svc.SeatWaitlistEntry(ctx, entry.ID, &models.SeatWaitlistEntryRequest{TableID: tt.tableID}, "")
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && seated.Status != models.WaitlistStatusSeated {
+				t.Errorf("status = %s, want seated", seated.Status)
+			}
+		})
+	}
+
+	table, err := svc.GetTableByID(ctx, free.ID)
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	if table.Status != models.TableStatusOccupied {
+		t.Errorf("seating should occupy the table, got %s", table.Status)
+	}
+}
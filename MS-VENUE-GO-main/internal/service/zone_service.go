@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ms-venue-go/internal/models"
+)
+
+// Zone operations
+func (s *venueService) CreateZone(ctx context.Context, req *models.CreateZoneRequest) (*models.Zone, error) {
+	zone := &models.Zone{
+		ID:          generateUUID(),
+		LocationID:  req.LocationID,
+		Name:        req.Name,
+		IsSmoking:   req.IsSmoking,
+		Environment: req.Environment,
+		SortOrder:   req.SortOrder,
+		IsActive:    true,
+	}
+
+	err := s.repo.CreateZone(ctx, zone)
+	if err != nil {
+		return nil, writeError("create", "zone", zone.Name, err)
+	}
+
+	return zone, nil
+}
+
+func (s *venueService) GetZonesByLocation(ctx context.Context, locationID string) ([]models.Zone, error) {
+	zones, err := s.repo.GetZonesByLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+	return zones, nil
+}
+
+func (s *venueService) GetZoneByID(ctx context.Context, id string) (*models.Zone, error) {
+	zone, err := s.repo.GetZoneByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("zone", err)
+	}
+	return zone, nil
+}
+
+func (s *venueService) UpdateZone(ctx context.Context, id string, req *models.UpdateZoneRequest) (*models.Zone, error) {
+	// Get existing zone
+	zone, err := s.repo.GetZoneByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("zone", err)
+	}
+
+	// Update fields if provided
+	if req.Name != "" {
+		zone.Name = req.Name
+	}
+	if req.IsSmoking != nil {
+		zone.IsSmoking = *req.IsSmoking
+	}
+	if req.Environment != "" {
+		zone.Environment = req.Environment
+	}
+	if req.SortOrder != nil {
+		zone.SortOrder = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+
+	err = s.repo.UpdateZone(ctx, id, zone)
+	if err != nil {
+		return nil, writeError("update", "zone", zone.Name, err)
+	}
+
+	return zone, nil
+}
+
+func (s *venueService) DeleteZone(ctx context.Context, id string) error {
+	if _, err := s.repo.GetZoneByID(ctx, id); err != nil {
+		return lookupError("zone", err)
+	}
+
+	err := s.repo.DeleteZone(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete zone: %w", err)
+	}
+	return nil
+}
+
+func (s *venueService) GetOccupancyByZone(ctx context.Context, locationID string) ([]models.ZoneOccupancy, error) {
+	occupancy, err := s.repo.GetOccupancyByZone(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone occupancy: %w", err)
+	}
+	return occupancy, nil
+}
+
+// validateTableZone ensures a table is only placed in an active zone of its
+// own location.
+func (s *venueService) validateTableZone(ctx context.Context, locationID, zoneID string) error {
+	zone, err := s.repo.GetZoneByID(ctx, zoneID)
+	if err != nil {
+		return referenceError("zone", err)
+	}
+	if !zone.IsActive {
+		return invalidReference("zone is not active")
+	}
+	if zone.LocationID != locationID {
+		return invalidReference("zone does not belong to location")
+	}
+	return nil
+}